@@ -0,0 +1,272 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultLogsLines = 100
+
+	bridgePrimary   = "primary"
+	bridgeSecondary = "secondary"
+)
+
+// Capsule runs the operations exposed by the API on the network.
+type Capsule interface {
+	BootstrapNetwork(ctx context.Context, req BootstrapNetworkRequest) error
+	StartNetwork(ctx context.Context) error
+	StopNetwork(ctx context.Context, req StopNetworkRequest) error
+	NetworkAddresses(ctx context.Context) (*NetworkAddresses, error)
+	ProtocolUpgrade(ctx context.Context, req ProtocolUpgradeRequest) error
+	NodeSets(ctx context.Context) ([]NodeSet, error)
+	AddNodeSets(ctx context.Context, req AddNodeSetsRequest) ([]NodeSet, error)
+	RemoveNodeSet(ctx context.Context, name string) error
+	StartNodeSet(ctx context.Context, name string) error
+	StopNodeSet(ctx context.Context, name string) error
+	Logs(ctx context.Context, jobID string, lines int) (*Logs, error)
+	DepositAsset(ctx context.Context, assetSymbol string, req DepositAssetRequest) (*Transaction, error)
+	StakeAsset(ctx context.Context, assetSymbol string, req StakeAssetRequest) (*Transaction, error)
+	MintAsset(ctx context.Context, assetSymbol string, req MintAssetRequest) (*Transaction, error)
+}
+
+// Error is returned to the client with its HTTP status.
+type Error struct {
+	status int
+	err    error
+}
+
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+func BadRequest(err error) error {
+	return &Error{status: http.StatusBadRequest, err: err}
+}
+
+func NotFound(err error) error {
+	return &Error{status: http.StatusNotFound, err: err}
+}
+
+func Conflict(err error) error {
+	return &Error{status: http.StatusConflict, err: err}
+}
+
+type queryParam struct {
+	name        string
+	description string
+}
+
+type route struct {
+	method      string
+	path        string
+	operationID string
+	summary     string
+	query       []queryParam
+	// request is a value of the request body type, nil when the route has no body
+	request         any
+	optionalRequest bool
+	// response is a value of the response type, nil when the route responds with no content
+	response any
+	handle   func(ctx context.Context, req *request) (any, error)
+}
+
+type request struct {
+	r      *http.Request
+	params map[string]string
+}
+
+func (r *request) param(name string) string {
+	return r.params[name]
+}
+
+// decode decodes JSON body of the request, empty body leaves v untouched.
+func (r *request) decode(v any) error {
+	d := json.NewDecoder(r.r.Body)
+	d.DisallowUnknownFields()
+
+	if err := d.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		return BadRequest(fmt.Errorf("failed to decode request body: %w", err))
+	}
+
+	return nil
+}
+
+func (r *request) queryInt(name string, def int) (int, error) {
+	v := r.r.URL.Query().Get(name)
+	if v == "" {
+		return def, nil
+	}
+
+	i, err := strconv.Atoi(v)
+	if err != nil || i < 1 {
+		return 0, BadRequest(fmt.Errorf("query parameter %q has to be a positive number", name))
+	}
+
+	return i, nil
+}
+
+type handler struct {
+	capsule Capsule
+	routes  []route
+}
+
+// NewHandler returns HTTP handler of the API.
+func NewHandler(capsule Capsule) http.Handler {
+	h := &handler{capsule: capsule}
+	h.routes = h.newRoutes()
+	return h
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet && r.URL.Path == openAPIPath {
+		h.serveOpenAPI(w)
+		return
+	}
+
+	rt, params, err := h.match(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+	if r.Method != http.MethodGet {
+		// operations changing the network are finished even when the client goes away
+		ctx = context.WithoutCancel(ctx)
+	}
+
+	res, err := rt.handle(ctx, &request{r: r, params: params})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if rt.response == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (h *handler) match(r *http.Request) (*route, map[string]string, error) {
+	segments := splitPath(r.URL.Path)
+	pathFound := false
+
+	for i, rt := range h.routes {
+		params, ok := matchPath(splitPath(rt.path), segments)
+		if !ok {
+			continue
+		}
+
+		pathFound = true
+		if rt.method == r.Method {
+			return &h.routes[i], params, nil
+		}
+	}
+
+	if pathFound {
+		return nil, nil, &Error{status: http.StatusMethodNotAllowed, err: fmt.Errorf("method %s is not allowed for %q", r.Method, r.URL.Path)}
+	}
+
+	return nil, nil, NotFound(fmt.Errorf("path %q not found", r.URL.Path))
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+func matchPath(pattern, segments []string) (map[string]string, bool) {
+	if len(pattern) != len(segments) {
+		return nil, false
+	}
+
+	params := map[string]string{}
+	for i, p := range pattern {
+		if name, ok := pathParamName(p); ok && segments[i] != "" {
+			params[name] = segments[i]
+			continue
+		}
+
+		if p != segments[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+func pathParamName(segment string) (string, bool) {
+	if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return segment[1 : len(segment)-1], true
+	}
+	return "", false
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("failed to write response: %s", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusInternalServerError
+
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		status = apiErr.status
+	}
+
+	log.Printf("%s %s failed: %s", r.Method, r.URL.Path, err)
+
+	writeJSON(w, status, ErrorResponse{
+		Code:    errorCode(status),
+		Message: err.Error(),
+	})
+}
+
+// errorCode returns status text in snake case, e.g. not_found.
+func errorCode(status int) string {
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+}
+
+func validateBridge(bridge *string) error {
+	if *bridge == "" {
+		*bridge = bridgePrimary
+	}
+
+	if *bridge != bridgePrimary && *bridge != bridgeSecondary {
+		return BadRequest(fmt.Errorf("unknown bridge %q, use %q or %q", *bridge, bridgePrimary, bridgeSecondary))
+	}
+
+	return nil
+}
+
+func validateAmount(amount int64) error {
+	if amount <= 0 {
+		return BadRequest(fmt.Errorf("amount has to be > 0"))
+	}
+	return nil
+}
+
+func required(name, value string) error {
+	if value == "" {
+		return BadRequest(fmt.Errorf("%s is required", name))
+	}
+	return nil
+}
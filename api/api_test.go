@@ -0,0 +1,153 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"code.vegaprotocol.io/vegacapsule/api"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the OpenAPI document")
+
+type fakeCapsule struct {
+	api.Capsule
+
+	addReq  *api.AddNodeSetsRequest
+	stopped []string
+}
+
+func (c *fakeCapsule) AddNodeSets(ctx context.Context, req api.AddNodeSetsRequest) ([]api.NodeSet, error) {
+	c.addReq = &req
+	return []api.NodeSet{{Name: "testnode-3", Running: *req.Start}}, nil
+}
+
+func (c *fakeCapsule) StopNodeSet(ctx context.Context, name string) error {
+	if name != "testnode-0" {
+		return api.NotFound(fmt.Errorf("node set %q not found", name))
+	}
+
+	c.stopped = append(c.stopped, name)
+	return nil
+}
+
+func (c *fakeCapsule) Logs(ctx context.Context, jobID string, lines int) (*api.Logs, error) {
+	return &api.Logs{Job: jobID, Lines: []string{fmt.Sprintf("%d lines", lines)}}, nil
+}
+
+func (c *fakeCapsule) StartNetwork(ctx context.Context) error {
+	return fmt.Errorf("failed to start network")
+}
+
+func do(t *testing.T, h http.Handler, method, path, body string) (int, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec.Code, rec.Body.String()
+}
+
+func TestHandler(t *testing.T) {
+	c := &fakeCapsule{}
+	h := api.NewHandler(c)
+
+	status, body := do(t, h, http.MethodPost, "/node-sets", `{"base_on_group": "validators"}`)
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `[{"name": "testnode-3", "group_name": "", "index": 0, "mode": "", "running": true, "has_data_node": false, "has_visor": false}]`, body)
+	require.NotNil(t, c.addReq)
+	assert.Equal(t, 1, c.addReq.Count)
+
+	status, _ = do(t, h, http.MethodPost, "/node-sets/testnode-0/stop", "")
+	assert.Equal(t, http.StatusNoContent, status)
+	assert.Equal(t, []string{"testnode-0"}, c.stopped)
+
+	status, body = do(t, h, http.MethodGet, "/logs/testnode-0?lines=5", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"job": "testnode-0", "lines": ["5 lines"]}`, body)
+}
+
+func TestHandlerErrors(t *testing.T) {
+	h := api.NewHandler(&fakeCapsule{})
+
+	tcs := []struct {
+		method, path, body string
+		status             int
+		response           api.ErrorResponse
+	}{
+		{
+			method: http.MethodPost, path: "/node-sets/testnode-9/stop",
+			status:   http.StatusNotFound,
+			response: api.ErrorResponse{Code: "not_found", Message: `node set "testnode-9" not found`},
+		},
+		{
+			method: http.MethodPost, path: "/node-sets", body: `{"base_on": "testnode-0", "base_on_group": "validators"}`,
+			status:   http.StatusBadRequest,
+			response: api.ErrorResponse{Code: "bad_request", Message: "either base_on or base_on_group has to be provided"},
+		},
+		{
+			method: http.MethodPost, path: "/ethereum/assets/VEGA/deposit", body: `{"pub_key": "abc", "amount": 1, "unknown": true}`,
+			status:   http.StatusBadRequest,
+			response: api.ErrorResponse{Code: "bad_request", Message: `failed to decode request body: json: unknown field "unknown"`},
+		},
+		{
+			method: http.MethodGet, path: "/logs/testnode-0?lines=-1",
+			status:   http.StatusBadRequest,
+			response: api.ErrorResponse{Code: "bad_request", Message: `query parameter "lines" has to be a positive number`},
+		},
+		{
+			method: http.MethodPost, path: "/network/start",
+			status:   http.StatusInternalServerError,
+			response: api.ErrorResponse{Code: "internal_server_error", Message: "failed to start network"},
+		},
+		{
+			method: http.MethodGet, path: "/network/start",
+			status:   http.StatusMethodNotAllowed,
+			response: api.ErrorResponse{Code: "method_not_allowed", Message: `method GET is not allowed for "/network/start"`},
+		},
+		{
+			method: http.MethodGet, path: "/unknown",
+			status:   http.StatusNotFound,
+			response: api.ErrorResponse{Code: "not_found", Message: `path "/unknown" not found`},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.method+" "+tc.path, func(t *testing.T) {
+			status, body := do(t, h, tc.method, tc.path, tc.body)
+			assert.Equal(t, tc.status, status)
+
+			var resp api.ErrorResponse
+			require.NoError(t, json.Unmarshal([]byte(body), &resp))
+			assert.Equal(t, tc.response, resp)
+		})
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	doc, err := api.OpenAPI()
+	require.NoError(t, err)
+
+	docPath := "openapi.json"
+	if *update {
+		require.NoError(t, os.WriteFile(docPath, append(doc, '\n'), 0o644))
+	}
+
+	expected, err := os.ReadFile(docPath)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(doc)+"\n", "OpenAPI document is outdated, run 'go test ./api -update'")
+
+	status, body := do(t, api.NewHandler(&fakeCapsule{}), http.MethodGet, "/openapi.json", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, string(doc), body)
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+const (
+	openAPIPath    = "/openapi.json"
+	schemasRefPath = "#/components/schemas/"
+)
+
+// OpenAPI returns OpenAPI document describing the API.
+func OpenAPI() ([]byte, error) {
+	h := &handler{}
+	return json.MarshalIndent(openAPIDocument(h.newRoutes()), "", "  ")
+}
+
+func (h *handler) serveOpenAPI(w http.ResponseWriter) {
+	writeJSON(w, http.StatusOK, openAPIDocument(h.routes))
+}
+
+func openAPIDocument(routes []route) map[string]any {
+	schemas := schemas{}
+	paths := map[string]map[string]any{}
+
+	errorResponse := map[string]any{
+		"description": "Error",
+		"content":     jsonContent(schemas.schemaFor(reflect.TypeOf(ErrorResponse{}))),
+	}
+
+	for _, rt := range routes {
+		op := map[string]any{
+			"operationId": rt.operationID,
+			"summary":     rt.summary,
+		}
+
+		params := []map[string]any{}
+		for _, segment := range splitPath(rt.path) {
+			if name, ok := pathParamName(segment); ok {
+				params = append(params, map[string]any{
+					"name":     name,
+					"in":       "path",
+					"required": true,
+					"schema":   map[string]any{"type": "string"},
+				})
+			}
+		}
+		for _, q := range rt.query {
+			params = append(params, map[string]any{
+				"name":        q.name,
+				"in":          "query",
+				"description": q.description,
+				"schema":      map[string]any{"type": "integer"},
+			})
+		}
+		if len(params) != 0 {
+			op["parameters"] = params
+		}
+
+		if rt.request != nil {
+			op["requestBody"] = map[string]any{
+				"required": !rt.optionalRequest,
+				"content":  jsonContent(schemas.schemaFor(reflect.TypeOf(rt.request))),
+			}
+		}
+
+		responses := map[string]any{"default": errorResponse}
+		if rt.response != nil {
+			responses["200"] = map[string]any{
+				"description": "Success",
+				"content":     jsonContent(schemas.schemaFor(reflect.TypeOf(rt.response))),
+			}
+		} else {
+			responses["204"] = map[string]any{"description": "Success"}
+		}
+		op["responses"] = responses
+
+		if paths[rt.path] == nil {
+			paths[rt.path] = map[string]any{}
+		}
+		paths[rt.path][strings.ToLower(rt.method)] = op
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "Vega Capsule API",
+			"description": "Local API controlling a network run by Vega Capsule, served by the 'vegacapsule serve' command.",
+			"version":     "1.0.0",
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+		},
+	}
+}
+
+func jsonContent(schema map[string]any) map[string]any {
+	return map[string]any{
+		"application/json": map[string]any{"schema": schema},
+	}
+}
+
+// schemas holds JSON schemas of the structs, they are referenced by their name.
+type schemas map[string]map[string]any
+
+func (s schemas) schemaFor(t reflect.Type) map[string]any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return s.schemaFor(t.Elem())
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": s.schemaFor(t.Elem())}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Int:
+		return map[string]any{"type": "integer"}
+	case reflect.Struct:
+		if _, ok := s[t.Name()]; !ok {
+			s.addStruct(t)
+		}
+		return map[string]any{"$ref": schemasRefPath + t.Name()}
+	}
+
+	return map[string]any{}
+}
+
+func (s schemas) addStruct(t reflect.Type) {
+	properties := map[string]any{}
+	required := []string{}
+
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	// registered before the fields so recursive types terminate
+	s[t.Name()] = schema
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		property := s.schemaFor(f.Type)
+		if description := f.Tag.Get("description"); description != "" {
+			if _, isRef := property["$ref"]; isRef {
+				property = map[string]any{"allOf": []any{property}, "description": description}
+			} else {
+				property["description"] = description
+			}
+		}
+		properties[name] = property
+
+		if !strings.Contains(opts, "omitempty") {
+			required = append(required, name)
+		}
+	}
+
+	if len(required) != 0 {
+		schema["required"] = required
+	}
+}
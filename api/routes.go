@@ -0,0 +1,254 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+func (h *handler) newRoutes() []route {
+	return []route{
+		{
+			method:      http.MethodPost,
+			path:        "/network/bootstrap",
+			operationID: "bootstrapNetwork",
+			summary:     "Generates and starts new network",
+			request:     BootstrapNetworkRequest{},
+			handle: func(ctx context.Context, r *request) (any, error) {
+				var req BootstrapNetworkRequest
+				if err := r.decode(&req); err != nil {
+					return nil, err
+				}
+
+				if err := required("config_path", req.ConfigPath); err != nil {
+					return nil, err
+				}
+
+				return nil, h.capsule.BootstrapNetwork(ctx, req)
+			},
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/network/start",
+			operationID: "startNetwork",
+			summary:     "Starts existing network",
+			handle: func(ctx context.Context, r *request) (any, error) {
+				return nil, h.capsule.StartNetwork(ctx)
+			},
+		},
+		{
+			method:          http.MethodPost,
+			path:            "/network/stop",
+			operationID:     "stopNetwork",
+			summary:         "Stops existing network",
+			request:         StopNetworkRequest{},
+			optionalRequest: true,
+			handle: func(ctx context.Context, r *request) (any, error) {
+				var req StopNetworkRequest
+				if err := r.decode(&req); err != nil {
+					return nil, err
+				}
+
+				return nil, h.capsule.StopNetwork(ctx, req)
+			},
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/network/addresses",
+			operationID: "networkAddresses",
+			summary:     "Lists all exposed addresses and ports per running network job",
+			response:    NetworkAddresses{},
+			handle: func(ctx context.Context, r *request) (any, error) {
+				return h.capsule.NetworkAddresses(ctx)
+			},
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/network/protocol-upgrade",
+			operationID: "protocolUpgrade",
+			summary:     "Prepares protocol upgrade for the node sets and sends the proposal to network if allowed",
+			request:     ProtocolUpgradeRequest{},
+			handle: func(ctx context.Context, r *request) (any, error) {
+				var req ProtocolUpgradeRequest
+				if err := r.decode(&req); err != nil {
+					return nil, err
+				}
+
+				if req.Height <= 0 {
+					return nil, BadRequest(fmt.Errorf("height has to be > 0"))
+				}
+				if err := required("release_tag", req.ReleaseTag); err != nil {
+					return nil, err
+				}
+				if err := required("template_path", req.TemplatePath); err != nil {
+					return nil, err
+				}
+				if len(req.IncludeNodeSets) != 0 && len(req.ExcludeNodeSets) != 0 {
+					return nil, BadRequest(fmt.Errorf("combining include_node_sets and exclude_node_sets is not allowed"))
+				}
+
+				return nil, h.capsule.ProtocolUpgrade(ctx, req)
+			},
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/node-sets",
+			operationID: "listNodeSets",
+			summary:     "Lists node sets of the network",
+			response:    []NodeSet{},
+			handle: func(ctx context.Context, r *request) (any, error) {
+				return h.capsule.NodeSets(ctx)
+			},
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/node-sets",
+			operationID: "addNodeSets",
+			summary:     "Adds new node sets based on existing node set or group",
+			request:     AddNodeSetsRequest{},
+			response:    []NodeSet{},
+			handle: func(ctx context.Context, r *request) (any, error) {
+				var req AddNodeSetsRequest
+				if err := r.decode(&req); err != nil {
+					return nil, err
+				}
+
+				if req.Count == 0 {
+					req.Count = 1
+				}
+				if req.Count < 0 {
+					return nil, BadRequest(fmt.Errorf("count has to be > 0"))
+				}
+				if (req.BaseOn == "") == (req.BaseOnGroup == "") {
+					return nil, BadRequest(fmt.Errorf("either base_on or base_on_group has to be provided"))
+				}
+				if req.Start == nil {
+					start := true
+					req.Start = &start
+				}
+
+				return h.capsule.AddNodeSets(ctx, req)
+			},
+		},
+		{
+			method:      http.MethodDelete,
+			path:        "/node-sets/{name}",
+			operationID: "removeNodeSet",
+			summary:     "Stops and removes the node set",
+			handle: func(ctx context.Context, r *request) (any, error) {
+				return nil, h.capsule.RemoveNodeSet(ctx, r.param("name"))
+			},
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/node-sets/{name}/start",
+			operationID: "startNodeSet",
+			summary:     "Starts the node set",
+			handle: func(ctx context.Context, r *request) (any, error) {
+				return nil, h.capsule.StartNodeSet(ctx, r.param("name"))
+			},
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/node-sets/{name}/stop",
+			operationID: "stopNodeSet",
+			summary:     "Stops the node set",
+			handle: func(ctx context.Context, r *request) (any, error) {
+				return nil, h.capsule.StopNodeSet(ctx, r.param("name"))
+			},
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/logs/{job}",
+			operationID: "jobLogs",
+			summary:     "Returns latest logs of the job",
+			query: []queryParam{
+				{name: "lines", description: fmt.Sprintf("Maximum number of lines, defaults to %d", defaultLogsLines)},
+			},
+			response: Logs{},
+			handle: func(ctx context.Context, r *request) (any, error) {
+				lines, err := r.queryInt("lines", defaultLogsLines)
+				if err != nil {
+					return nil, err
+				}
+
+				return h.capsule.Logs(ctx, r.param("job"), lines)
+			},
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/ethereum/assets/{symbol}/deposit",
+			operationID: "depositAsset",
+			summary:     "Deposits the asset to given Vega public key",
+			request:     DepositAssetRequest{},
+			response:    Transaction{},
+			handle: func(ctx context.Context, r *request) (any, error) {
+				var req DepositAssetRequest
+				if err := r.decode(&req); err != nil {
+					return nil, err
+				}
+
+				if err := required("pub_key", req.PubKey); err != nil {
+					return nil, err
+				}
+				if err := validateAmount(req.Amount); err != nil {
+					return nil, err
+				}
+				if err := validateBridge(&req.Bridge); err != nil {
+					return nil, err
+				}
+
+				return h.capsule.DepositAsset(ctx, r.param("symbol"), req)
+			},
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/ethereum/assets/{symbol}/stake",
+			operationID: "stakeAsset",
+			summary:     "Stakes the asset to given Vega public key",
+			request:     StakeAssetRequest{},
+			response:    Transaction{},
+			handle: func(ctx context.Context, r *request) (any, error) {
+				var req StakeAssetRequest
+				if err := r.decode(&req); err != nil {
+					return nil, err
+				}
+
+				if err := required("pub_key", req.PubKey); err != nil {
+					return nil, err
+				}
+				if err := validateAmount(req.Amount); err != nil {
+					return nil, err
+				}
+
+				return h.capsule.StakeAsset(ctx, r.param("symbol"), req)
+			},
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/ethereum/assets/{symbol}/mint",
+			operationID: "mintAsset",
+			summary:     "Mints the asset to given Ethereum address",
+			request:     MintAssetRequest{},
+			response:    Transaction{},
+			handle: func(ctx context.Context, r *request) (any, error) {
+				var req MintAssetRequest
+				if err := r.decode(&req); err != nil {
+					return nil, err
+				}
+
+				if err := required("to_address", req.ToAddress); err != nil {
+					return nil, err
+				}
+				if err := validateAmount(req.Amount); err != nil {
+					return nil, err
+				}
+				if err := validateBridge(&req.Bridge); err != nil {
+					return nil, err
+				}
+
+				return h.capsule.MintAsset(ctx, r.param("symbol"), req)
+			},
+		},
+	}
+}
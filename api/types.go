@@ -0,0 +1,88 @@
+package api
+
+// Fields are described by the description tag, it is used to generate the OpenAPI document.
+
+type BootstrapNetworkRequest struct {
+	ConfigPath        string `json:"config_path" description:"Path to the config file to generate network from"`
+	Seed              string `json:"seed,omitempty" description:"Seed to derive all keys of the network from, overwrites seed from the config file"`
+	InstallReleaseTag string `json:"install_release_tag,omitempty" description:"Installs specific release tag version of vega, data-node and wallet binaries"`
+	Force             bool   `json:"force,omitempty" description:"Force creating even if folders exists"`
+}
+
+type StopNetworkRequest struct {
+	NodesOnly bool `json:"nodes_only,omitempty" description:"Only stops running nodes sets in the network"`
+}
+
+type ProtocolUpgradeRequest struct {
+	Height          int64    `json:"height" description:"The block height at which the upgrade should be made"`
+	ReleaseTag      string   `json:"release_tag" description:"A valid vega core release tag for the upgrade"`
+	TemplatePath    string   `json:"template_path" description:"Visor run config template to be applied"`
+	Propose         bool     `json:"propose,omitempty" description:"Sends protocol upgrade proposal transaction to network"`
+	Force           bool     `json:"force,omitempty" description:"Forces to run upgrade"`
+	IncludeNodeSets []string `json:"include_node_sets,omitempty" description:"Names of node sets that should be included in the upgrade"`
+	ExcludeNodeSets []string `json:"exclude_node_sets,omitempty" description:"Names of node sets that should be excluded from the upgrade"`
+}
+
+type AddNodeSetsRequest struct {
+	BaseOn      string `json:"base_on,omitempty" description:"Name of the node set that the new node sets should be based on"`
+	BaseOnGroup string `json:"base_on_group,omitempty" description:"Name of the group that the new node sets should be based on"`
+	Count       int    `json:"count,omitempty" description:"Number of node sets to add, defaults to 1"`
+	Start       *bool  `json:"start,omitempty" description:"Whether the new node sets should be started, defaults to true"`
+}
+
+type DepositAssetRequest struct {
+	PubKey string `json:"pub_key" description:"Vega public key the asset is deposited to"`
+	Amount int64  `json:"amount" description:"Amount to be deposited"`
+	Bridge string `json:"bridge,omitempty" description:"Bridge linked to the deposit, primary or secondary. Defaults to primary"`
+}
+
+type StakeAssetRequest struct {
+	PubKey string `json:"pub_key" description:"Vega public key the asset is staked to"`
+	Amount int64  `json:"amount" description:"Amount to be staked"`
+}
+
+type MintAssetRequest struct {
+	ToAddress string `json:"to_address" description:"Ethereum address the asset is minted to"`
+	Amount    int64  `json:"amount" description:"Amount to be minted"`
+	Bridge    string `json:"bridge,omitempty" description:"Bridge of the asset, primary or secondary. Defaults to primary"`
+}
+
+type NodeSet struct {
+	Name        string `json:"name"`
+	GroupName   string `json:"group_name"`
+	Index       int    `json:"index"`
+	Mode        string `json:"mode" description:"Mode of the node set, validator or full"`
+	Running     bool   `json:"running"`
+	HasDataNode bool   `json:"has_data_node"`
+	HasVisor    bool   `json:"has_visor"`
+}
+
+type Address struct {
+	Name    string `json:"name,omitempty"`
+	Address string `json:"address"`
+}
+
+type JobAddresses struct {
+	Job       string    `json:"job"`
+	Task      string    `json:"task,omitempty"`
+	Addresses []Address `json:"addresses"`
+}
+
+type NetworkAddresses struct {
+	Jobs           []JobAddresses `json:"jobs"`
+	AllocatedPorts []Address      `json:"allocated_ports" description:"Ports allocated to the network by Capsule"`
+}
+
+type Logs struct {
+	Job   string   `json:"job"`
+	Lines []string `json:"lines" description:"Latest lines of logs of all tasks of the job, prefixed by the task name"`
+}
+
+type Transaction struct {
+	Hash string `json:"hash" description:"Hash of the Ethereum transaction"`
+}
+
+type ErrorResponse struct {
+	Code    string `json:"code" description:"Machine readable code of the error, e.g. not_found"`
+	Message string `json:"message"`
+}
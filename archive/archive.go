@@ -0,0 +1,320 @@
+package archive
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"code.vegaprotocol.io/vegacapsule/utils"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// ManifestFileName is the name of the archive entry describing the exported network.
+const ManifestFileName = "capsule-archive.json"
+
+// Manifest describes the exported network.
+type Manifest struct {
+	// NetworkName is the name of the exported network.
+	NetworkName string
+	// HomePath is the network home path on the machine where the network has been exported.
+	// It is used to rewrite absolute paths when the network is imported into different home.
+	HomePath string
+	// StateVersion is the version of the exported network state.
+	StateVersion int
+	// ChainData is true when the archive contains chain data of the nodes.
+	ChainData bool
+}
+
+// ExportOptions configures the content of the archive.
+type ExportOptions struct {
+	// Exclude are absolute paths of files and directories that are not exported.
+	Exclude []string
+	// Override maps absolute paths of files to the content exported instead of the original one.
+	Override map[string][]byte
+}
+
+// rewrittenExtensions are extensions of text files in which absolute paths are rewritten on import.
+var rewrittenExtensions = map[string]struct{}{
+	".toml": {},
+	".json": {},
+	".hcl":  {},
+	".yaml": {},
+	".yml":  {},
+	".env":  {},
+}
+
+// Export writes zstd compressed tar archive of the network home directory into w.
+func Export(w io.Writer, homePath string, manifest Manifest, opts ExportOptions) error {
+	zw, err := zstd.NewWriter(w)
+	if err != nil {
+		return fmt.Errorf("failed to create zstd writer: %w", err)
+	}
+
+	tw := tar.NewWriter(zw)
+
+	if err := writeManifest(tw, manifest); err != nil {
+		return err
+	}
+
+	excluded := make(map[string]struct{}, len(opts.Exclude))
+	for _, p := range opts.Exclude {
+		excluded[filepath.Clean(p)] = struct{}{}
+	}
+
+	walkErr := filepath.WalkDir(homePath, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if _, ok := excluded[filepath.Clean(p)]; ok {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		relPath, err := filepath.Rel(homePath, p)
+		if err != nil {
+			return err
+		}
+
+		if relPath == "." {
+			return nil
+		}
+
+		return writeEntry(tw, p, filepath.ToSlash(relPath), d, opts.Override)
+	})
+	if walkErr != nil {
+		return fmt.Errorf("failed to archive network home %q: %w", homePath, walkErr)
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finish tar archive: %w", err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to finish zstd compression: %w", err)
+	}
+
+	return nil
+}
+
+func writeManifest(tw *tar.Writer, manifest Manifest) error {
+	b, err := json.MarshalIndent(manifest, "", "\t")
+	if err != nil {
+		return fmt.Errorf("failed to encode archive manifest: %w", err)
+	}
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:     ManifestFileName,
+		Mode:     0o644,
+		Size:     int64(len(b)),
+		Typeflag: tar.TypeReg,
+	}); err != nil {
+		return fmt.Errorf("failed to write archive manifest: %w", err)
+	}
+
+	if _, err := tw.Write(b); err != nil {
+		return fmt.Errorf("failed to write archive manifest: %w", err)
+	}
+
+	return nil
+}
+
+func writeEntry(tw *tar.Writer, p, name string, d fs.DirEntry, override map[string][]byte) error {
+	info, err := d.Info()
+	if err != nil {
+		return err
+	}
+
+	var link string
+	if info.Mode()&fs.ModeSymlink != 0 {
+		if link, err = os.Readlink(p); err != nil {
+			return err
+		}
+	}
+
+	header, err := tar.FileInfoHeader(info, link)
+	if err != nil {
+		return err
+	}
+	header.Name = name
+
+	if !info.Mode().IsRegular() {
+		return tw.WriteHeader(header)
+	}
+
+	if content, ok := override[filepath.Clean(p)]; ok {
+		header.Size = int64(len(content))
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		_, err := tw.Write(content)
+		return err
+	}
+
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// Import extracts the network archive into homePath and rewrites absolute paths of the exported home
+// in configuration files to homePath. The network state is left as it is and must be relocated by the caller.
+func Import(r io.Reader, homePath string) (*Manifest, error) {
+	zr, err := zstd.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd reader: %w", err)
+	}
+	defer zr.Close()
+
+	var (
+		manifest *Manifest
+		files    []string
+	)
+
+	tr := tar.NewReader(zr)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read network archive: %w", err)
+		}
+
+		if header.Name == ManifestFileName {
+			manifest = &Manifest{}
+			if err := json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, fmt.Errorf("failed to decode archive manifest: %w", err)
+			}
+			continue
+		}
+
+		target, err := extractPath(homePath, header.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if header.Typeflag == tar.TypeSymlink {
+			if filepath.IsAbs(header.Linkname) {
+				return nil, fmt.Errorf("invalid network archive: symlink %q points to absolute path", header.Name)
+			}
+
+			if _, err := extractPath(homePath, filepath.Join(filepath.Dir(header.Name), header.Linkname)); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := extractEntry(tr, header, target); err != nil {
+			return nil, fmt.Errorf("failed to extract %q: %w", header.Name, err)
+		}
+
+		if header.Typeflag == tar.TypeReg {
+			files = append(files, target)
+		}
+	}
+
+	if manifest == nil {
+		return nil, fmt.Errorf("invalid network archive: missing %s", ManifestFileName)
+	}
+
+	if err := rewritePaths(files, manifest.HomePath, homePath); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// extractPath returns the path where the entry is extracted and makes sure it doesn't escape the home directory.
+func extractPath(homePath, name string) (string, error) {
+	target := filepath.Join(homePath, filepath.FromSlash(name))
+
+	rel, err := filepath.Rel(homePath, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid network archive: entry %q is outside of the network home", name)
+	}
+
+	return target, nil
+}
+
+func extractEntry(tr *tar.Reader, header *tar.Header, target string) error {
+	mode := fs.FileMode(header.Mode).Perm()
+
+	switch header.Typeflag {
+	case tar.TypeDir:
+		return os.MkdirAll(target, mode|0o700)
+	case tar.TypeSymlink:
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		return os.Symlink(header.Linkname, target)
+	case tar.TypeReg:
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = io.Copy(f, tr)
+		return err
+	default:
+		return nil
+	}
+}
+
+func rewritePaths(files []string, oldHomePath, newHomePath string) error {
+	if oldHomePath == "" || filepath.Clean(oldHomePath) == filepath.Clean(newHomePath) {
+		return nil
+	}
+
+	oldPath := filepath.Clean(oldHomePath)
+	newPath := filepath.Clean(newHomePath)
+
+	for _, f := range files {
+		if _, ok := rewrittenExtensions[filepath.Ext(f)]; !ok {
+			continue
+		}
+
+		content, err := os.ReadFile(f)
+		if err != nil {
+			return fmt.Errorf("failed to read %q: %w", f, err)
+		}
+
+		rewritten := utils.ReplacePathPrefix(string(content), oldPath, newPath)
+		if rewritten == string(content) {
+			continue
+		}
+
+		info, err := os.Stat(f)
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(f, []byte(rewritten), info.Mode().Perm()); err != nil {
+			return fmt.Errorf("failed to rewrite paths in %q: %w", f, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package archive_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"code.vegaprotocol.io/vegacapsule/archive"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImport(t *testing.T) {
+	exportHome := t.TempDir()
+	importHome := filepath.Join(t.TempDir(), "imported")
+
+	// paths sharing prefix with the network home are not rewritten
+	configTemplate := "Home = \"%s/vega/node0\"\nRoot = \"%s\"\nOther = \"" + exportHome + "2/vega\"\n"
+	writeFile(t, filepath.Join(exportHome, "vega", "node0", "config", "node", "config.toml"), fmt.Sprintf(configTemplate, exportHome, exportHome))
+	writeFile(t, filepath.Join(exportHome, "tendermint", "node0", "data", "priv_validator_state.json"), `{"height": "100"}`)
+	writeFile(t, filepath.Join(exportHome, "tendermint", "node0", "data", "blockstore.db"), "blocks")
+	writeFile(t, filepath.Join(exportHome, "logs", "node0.log"), "log")
+
+	manifest := archive.Manifest{
+		NetworkName:  "testnet",
+		HomePath:     exportHome,
+		StateVersion: 1,
+	}
+
+	buff := bytes.NewBuffer(nil)
+	require.NoError(t, archive.Export(buff, exportHome, manifest, archive.ExportOptions{
+		Exclude: []string{
+			filepath.Join(exportHome, "logs"),
+			filepath.Join(exportHome, "tendermint", "node0", "data", "blockstore.db"),
+		},
+		Override: map[string][]byte{
+			filepath.Join(exportHome, "tendermint", "node0", "data", "priv_validator_state.json"): []byte(`{"height": "0"}`),
+		},
+	}))
+
+	importedManifest, err := archive.Import(buff, importHome)
+	require.NoError(t, err)
+	assert.Equal(t, manifest, *importedManifest)
+
+	config, err := os.ReadFile(filepath.Join(importHome, "vega", "node0", "config", "node", "config.toml"))
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf(configTemplate, importHome, importHome), string(config))
+
+	privValidatorState, err := os.ReadFile(filepath.Join(importHome, "tendermint", "node0", "data", "priv_validator_state.json"))
+	require.NoError(t, err)
+	assert.Equal(t, `{"height": "0"}`, string(privValidatorState))
+
+	assert.NoFileExists(t, filepath.Join(importHome, "tendermint", "node0", "data", "blockstore.db"))
+	assert.NoDirExists(t, filepath.Join(importHome, "logs"))
+}
+
+func writeFile(t *testing.T, p, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+	require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+}
@@ -0,0 +1,257 @@
+// Package capsule allows Go programs to generate and run Vega networks the same way as the vegacapsule commands do.
+//
+// The network state is persisted in the network home path, so networks created by the package
+// can be inspected and controlled by the commands too and vice versa.
+// Operations changing the network hold the lock obtained from state.LockNetworkState as the commands do,
+// so they can't overwrite changes made by the commands or other processes sharing the home path.
+// Operations of a single Network are not safe for concurrent use.
+package capsule
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/jobrunner"
+	"code.vegaprotocol.io/vegacapsule/state"
+	"code.vegaprotocol.io/vegacapsule/types"
+)
+
+var (
+	ErrNotGenerated = errors.New("network is not generated")
+	ErrNotRunning   = errors.New("network is not running")
+)
+
+const defaultLockTimeout = time.Second * 30
+
+type options struct {
+	name          string
+	stopOnFailure bool
+	waitReady     bool
+	lockTimeout   time.Duration
+	stateLoadOpts []state.LoadOption
+}
+
+type Option func(*options)
+
+// WithName sets name the network is registered with, the network name from the config is used by default.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.name = name
+	}
+}
+
+// WithoutStopOnFailure keeps partially running network when it fails to start or to become ready.
+func WithoutStopOnFailure() Option {
+	return func(o *options) {
+		o.stopOnFailure = false
+	}
+}
+
+// WithoutWaitReady does not wait for readiness probes of the network to pass when it is started.
+func WithoutWaitReady() Option {
+	return func(o *options) {
+		o.waitReady = false
+	}
+}
+
+// WithLockTimeout sets how long to wait for other processes modifying the same network to finish.
+func WithLockTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.lockTimeout = timeout
+	}
+}
+
+// WithStateLoadOptions sets options used to load the network state, e.g. passphrase of encrypted state.
+func WithStateLoadOptions(opts ...state.LoadOption) Option {
+	return func(o *options) {
+		o.stateLoadOpts = append(o.stateLoadOpts, opts...)
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		stopOnFailure: true,
+		waitReady:     true,
+		lockTimeout:   defaultLockTimeout,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// Network is a Vega network generated and run by Capsule.
+// All operations changing the network persist its state in the network home path.
+type Network struct {
+	homePath string
+	state    *state.NetworkState
+	opts     options
+	// lockState is false for network with state loaded by the caller, the caller holds the lock then.
+	lockState bool
+}
+
+// New returns network for the config, the network is going to be generated in the config output directory.
+// Config parsed by ParseConfigFile makes sure the ports allocated for the network don't collide with other networks.
+func New(cfg *config.Config, opts ...Option) (*Network, error) {
+	if cfg.OutputDir == nil {
+		return nil, fmt.Errorf("config output directory is not set")
+	}
+
+	n, err := Load(*cfg.OutputDir, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.generated() {
+		return nil, fmt.Errorf("network in %q is already generated, use Load to control it", n.homePath)
+	}
+
+	portAllocator := cfg.PortAllocator()
+	if portAllocator == nil {
+		if portAllocator, err = NewPortAllocator(n.homePath); err != nil {
+			return nil, fmt.Errorf("failed to create port allocator: %w", err)
+		}
+		cfg.SetPortAllocator(portAllocator)
+	}
+
+	n.state.Config = cfg
+	n.state.Ports = portAllocator
+
+	return n, nil
+}
+
+// Load returns network with the state persisted in the home path.
+func Load(homePath string, opts ...Option) (*Network, error) {
+	o := newOptions(opts)
+
+	netState, err := loadState(homePath, o)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Network{
+		homePath:  homePath,
+		state:     netState,
+		opts:      o,
+		lockState: true,
+	}, nil
+}
+
+// FromState returns network with already loaded state, changes made by the network are visible in the state.
+// The network doesn't lock the state, the caller is expected to hold the lock from loading the state until the last change.
+func FromState(homePath string, netState *state.NetworkState, opts ...Option) *Network {
+	return &Network{
+		homePath: homePath,
+		state:    netState,
+		opts:     newOptions(opts),
+	}
+}
+
+// loadState loads the network state, its port allocator doesn't allocate ports of other registered networks.
+func loadState(homePath string, o options) (*state.NetworkState, error) {
+	netState, err := state.LoadNetworkState(homePath, o.stateLoadOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load network state: %w", err)
+	}
+
+	if err := ReserveOtherNetworksPorts(netState, homePath); err != nil {
+		return nil, err
+	}
+
+	return netState, nil
+}
+
+// ParseConfigFile parses the config file of network with given home path.
+func ParseConfigFile(configPath, homePath string) (*config.Config, error) {
+	portAllocator, err := NewPortAllocator(homePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create port allocator: %w", err)
+	}
+
+	conf, err := config.ParseConfigFile(configPath, homePath, types.DefaultGeneratedServices(), portAllocator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	return conf, nil
+}
+
+// HomePath returns directory with all files of the network.
+func (n *Network) HomePath() string {
+	return n.homePath
+}
+
+// State returns current state of the network.
+func (n *Network) State() *state.NetworkState {
+	return n.state
+}
+
+func (n *Network) generated() bool {
+	return n.state.Config != nil && n.state.GeneratedServices != nil
+}
+
+func (n *Network) running() bool {
+	return n.generated() && n.state.Running()
+}
+
+func (n *Network) runner() (jobrunner.JobRunner, error) {
+	runner, err := jobrunner.New(n.state.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ProtectOtherNetworksJobs(runner, n.homePath); err != nil {
+		return nil, fmt.Errorf("failed to load network registry: %w", err)
+	}
+
+	return runner, nil
+}
+
+// lock acquires the network state lock for operation changing the network. State of generated network
+// is reloaded under the lock, so changes made by other processes since the network has been loaded are not overwritten.
+func (n *Network) lock() (unlock func(), err error) {
+	if !n.lockState {
+		return func() {}, nil
+	}
+
+	lock, err := state.LockNetworkState(n.homePath, n.opts.lockTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock = func() {
+		if err := lock.Unlock(); err != nil {
+			log.Printf("failed to release network state lock: %s", err)
+		}
+	}
+
+	if n.generated() {
+		netState, err := loadState(n.homePath, n.opts)
+		if err != nil {
+			unlock()
+			return nil, err
+		}
+		*n.state = *netState
+	}
+
+	return unlock, nil
+}
+
+// persist persists the network state after the operation, also when the operation failed
+// as some of its changes, e.g. started jobs, could have been already made.
+func (n *Network) persist(opErr error) error {
+	if err := n.state.Persist(); err != nil {
+		if opErr != nil {
+			log.Printf("failed to persist network state: %s", err)
+			return opErr
+		}
+
+		return fmt.Errorf("failed to persist network state: %w", err)
+	}
+
+	return opErr
+}
@@ -0,0 +1,137 @@
+package capsule_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"code.vegaprotocol.io/vegacapsule/capsule"
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/ports"
+	"code.vegaprotocol.io/vegacapsule/state"
+	"code.vegaprotocol.io/vegacapsule/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkNotGenerated(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	homePath := t.TempDir()
+	conf, err := config.DefaultConfig()
+	require.NoError(t, err)
+	conf.OutputDir = &homePath
+
+	n, err := capsule.New(conf)
+	require.NoError(t, err)
+	assert.Equal(t, homePath, n.HomePath())
+	assert.NotNil(t, n.State().Ports)
+
+	ctx := context.Background()
+	assert.ErrorIs(t, n.Start(ctx), capsule.ErrNotGenerated)
+	assert.ErrorIs(t, n.StopNodeSet(ctx, "testnet-nodeset-validators-0-full"), capsule.ErrNotGenerated)
+
+	_, err = n.AddNodeSet(ctx, capsule.AddNodeSetOptions{BaseOnGroup: "validators"})
+	assert.ErrorIs(t, err, capsule.ErrNotGenerated)
+
+	_, err = n.Addresses(ctx)
+	assert.ErrorIs(t, err, capsule.ErrNotRunning)
+
+	require.NoError(t, n.Destroy(ctx))
+	assert.NoDirExists(t, homePath)
+}
+
+func TestNetworkWaitsForStateLock(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	homePath := t.TempDir()
+	conf, err := config.DefaultConfig()
+	require.NoError(t, err)
+	conf.OutputDir = &homePath
+
+	n, err := capsule.New(conf, capsule.WithLockTimeout(100*time.Millisecond))
+	require.NoError(t, err)
+
+	lock, err := state.LockNetworkState(homePath, time.Second)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	assert.ErrorIs(t, n.Generate(), state.ErrLockTimeout)
+	assert.ErrorIs(t, n.Start(ctx), state.ErrLockTimeout)
+	assert.ErrorIs(t, n.Destroy(ctx), state.ErrLockTimeout)
+	assert.DirExists(t, homePath)
+
+	require.NoError(t, lock.Unlock())
+
+	assert.ErrorIs(t, n.Start(ctx), capsule.ErrNotGenerated)
+}
+
+func TestLoadReservesPortsOfOtherNetworks(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	persistNetwork := func(name string, allocator *ports.Allocator) string {
+		homePath := t.TempDir()
+		conf, err := config.DefaultConfig()
+		require.NoError(t, err)
+		conf.OutputDir = &homePath
+		conf.Network.Name = name
+
+		netState := state.NetworkState{
+			Config:            conf,
+			GeneratedServices: &types.GeneratedServices{},
+			Ports:             allocator,
+		}
+		require.NoError(t, netState.Persist())
+		require.NoError(t, capsule.Register(netState, name))
+
+		return homePath
+	}
+
+	otherAllocator := ports.NewAllocator(nil)
+	otherPort, err := otherAllocator.Port("vega-grpc-0")
+	require.NoError(t, err)
+	persistNetwork("other", otherAllocator)
+
+	homePath := persistNetwork("testnet", ports.NewAllocator(nil))
+
+	n, err := capsule.Load(homePath)
+	require.NoError(t, err)
+
+	port, err := n.State().Ports.Port("vega-grpc-0")
+	require.NoError(t, err)
+	assert.NotEqual(t, otherPort, port)
+}
+
+func TestNetworkLogs(t *testing.T) {
+	homePath := t.TempDir()
+	conf, err := config.DefaultConfig()
+	require.NoError(t, err)
+	conf.OutputDir = &homePath
+
+	n := capsule.FromState(homePath, &state.NetworkState{
+		Config:            conf,
+		GeneratedServices: &types.GeneratedServices{},
+	})
+
+	jobLogsDir := filepath.Join(conf.LogsDir(), "testnet-nodeset-validators-0-full")
+	require.NoError(t, os.MkdirAll(jobLogsDir, 0o755))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(jobLogsDir, "vega.stdout-2024-01-02T15:04:05Z.log"),
+		[]byte("first\nsecond\nthird\n"),
+		0o644,
+	))
+
+	lines, err := n.Logs("testnet-nodeset-validators-0-full", 2)
+	require.NoError(t, err)
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "second")
+	assert.Contains(t, lines[1], "third")
+
+	for _, jobID := range []string{"testnet-faucet", "../logs", "testnet-nodeset-validators-0-full/../.."} {
+		_, err = n.Logs(jobID, 2)
+		assert.ErrorIs(t, err, capsule.ErrJobLogsNotFound, jobID)
+	}
+}
@@ -0,0 +1,39 @@
+// Package capsuletest provides helpers for running a network in Go tests.
+package capsuletest
+
+import (
+	"context"
+	"testing"
+
+	"code.vegaprotocol.io/vegacapsule/capsule"
+	"code.vegaprotocol.io/vegacapsule/config"
+)
+
+// StartNetwork generates and starts the network for the config and fails the test when it can't.
+// The network is destroyed when the test and all its subtests complete, so a test suite
+// running its tests as subtests of a single test shares one network.
+func StartNetwork(t testing.TB, cfg *config.Config, opts ...capsule.Option) *capsule.Network {
+	t.Helper()
+
+	n, err := capsule.New(cfg, opts...)
+	if err != nil {
+		t.Fatalf("failed to create network: %s", err)
+	}
+
+	// registered before the network is generated, so partially generated network is cleaned up too
+	t.Cleanup(func() {
+		if err := n.Destroy(context.Background()); err != nil {
+			t.Errorf("failed to destroy network: %s", err)
+		}
+	})
+
+	if err := n.Generate(); err != nil {
+		t.Fatalf("failed to generate network: %s", err)
+	}
+
+	if err := n.Start(context.Background()); err != nil {
+		t.Fatalf("failed to start network: %s", err)
+	}
+
+	return n
+}
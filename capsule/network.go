@@ -0,0 +1,334 @@
+package capsule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/generator"
+	"code.vegaprotocol.io/vegacapsule/logscollector"
+	"code.vegaprotocol.io/vegacapsule/nomad"
+	"code.vegaprotocol.io/vegacapsule/ports"
+	"code.vegaprotocol.io/vegacapsule/probes"
+	"code.vegaprotocol.io/vegacapsule/types"
+	"code.vegaprotocol.io/vegacapsule/utils"
+)
+
+var ErrJobLogsNotFound = errors.New("job logs not found")
+
+// JobPorts are ports open by a job or by a task of the job.
+type JobPorts struct {
+	ports.JobWithTask
+	Ports []ports.PortWithName
+}
+
+// Generate generates all files of the network and registers the network in the network registry.
+func (n *Network) Generate() error {
+	unlock, err := n.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if n.state.Config == nil {
+		return fmt.Errorf("failed to generate network: network has no config")
+	}
+
+	if n.state.GeneratedServices != nil {
+		return fmt.Errorf("failed to generate network: network is already generated")
+	}
+
+	conf := n.state.Config
+
+	if netDirEmpty, _ := utils.DirEmpty(*conf.OutputDir, filepath.Base(conf.BinariesDir())); !netDirEmpty {
+		return fmt.Errorf("output directory %q already exists and it's not empty", *conf.OutputDir)
+	}
+
+	if err := checkRegistration(*n.state, n.opts.name); err != nil {
+		return err
+	}
+
+	log.Println("generating network")
+
+	n.state.VegaChainID = conf.Network.Name + "-001"
+
+	runner, err := n.runner()
+	if err != nil {
+		return err
+	}
+
+	gen, err := generator.New(conf, types.GeneratedServices{}, runner, n.state.VegaChainID)
+	if err != nil {
+		return err
+	}
+
+	generatedSvcs, err := gen.Generate()
+	if err != nil {
+		return err
+	}
+
+	if err := conf.Persist(); err != nil {
+		return fmt.Errorf("failed to persist config in output directory %s: %w", *conf.OutputDir, err)
+	}
+
+	log.Println("generating network success")
+
+	n.state.GeneratedServices = generatedSvcs
+
+	n.state.RunningJobs = &types.NetworkJobs{}
+	n.state.RunningJobs.AddExtraJobIDs(generatedSvcs.PreGenerateJobsIDs())
+
+	if err := Register(*n.state, n.opts.name); err != nil {
+		return fmt.Errorf("failed to register network: %w", err)
+	}
+
+	return n.persist(nil)
+}
+
+// Start starts all jobs of the generated network and waits until the network is ready unless WithoutWaitReady is used.
+func (n *Network) Start(ctx context.Context) error {
+	unlock, err := n.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if !n.generated() {
+		return ErrNotGenerated
+	}
+
+	return n.persist(n.start(ctx))
+}
+
+func (n *Network) start(ctx context.Context) error {
+	log.Println("starting network")
+
+	runner, err := n.runner()
+	if err != nil {
+		return err
+	}
+
+	conf, err := config.ApplyConfigContext(n.state.Config, n.state.GeneratedServices)
+	if err != nil {
+		return fmt.Errorf("failed to apply config context: %w", err)
+	}
+	n.state.Config = conf
+
+	if n.state.RunningJobs == nil {
+		n.state.RunningJobs = &types.NetworkJobs{}
+	}
+
+	if conf.P2PProxy {
+		// nodes connect to each other through the proxy, so it has to run first
+		jobID, err := runner.RunExecJob(ctx, p2pProxyJob(conf))
+		if err != nil {
+			return fmt.Errorf("failed to start P2P proxy: %w", err)
+		}
+		n.state.RunningJobs.AddExtraJobIDs([]string{jobID})
+	}
+
+	res, err := runner.StartNetwork(ctx, conf, n.state.GeneratedServices, n.opts.stopOnFailure)
+	if err != nil {
+		return fmt.Errorf("failed to start network: %w", err)
+	}
+
+	n.state.RunningJobs.MergeNetworkJobs(*res)
+
+	if n.opts.waitReady {
+		if err := waitReady(ctx, conf, n.state.GeneratedServices); err != nil {
+			if n.opts.stopOnFailure {
+				if err := n.stop(context.Background(), false); err != nil {
+					log.Printf("Failed to stop network that is not ready: %s", err)
+				}
+			} else {
+				log.Println("The --do-not-stop-on-failure flag present. Network won't be stopped")
+			}
+			return fmt.Errorf("failed to wait for network: %w", err)
+		}
+	}
+
+	log.Println("Network successfully started.")
+
+	return nil
+}
+
+// WaitReady waits until readiness probes of all node sets, the wallet, the faucet and pre/post start services pass.
+func (n *Network) WaitReady(ctx context.Context) error {
+	if !n.running() {
+		return ErrNotRunning
+	}
+
+	conf, err := config.ApplyConfigContext(n.state.Config, n.state.GeneratedServices)
+	if err != nil {
+		return fmt.Errorf("failed to apply config context: %w", err)
+	}
+
+	return waitReady(ctx, conf, n.state.GeneratedServices)
+}
+
+func waitReady(ctx context.Context, conf *config.Config, genServices *types.GeneratedServices) error {
+	log.Println("waiting for network to be ready")
+
+	if err := probes.WaitReady(ctx, probes.ReadinessChecks(conf, genServices)); err != nil {
+		return err
+	}
+
+	log.Println("Network is ready.")
+
+	return nil
+}
+
+// Stop stops all running jobs of the network.
+func (n *Network) Stop(ctx context.Context) error {
+	unlock, err := n.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if !n.generated() {
+		return ErrNotGenerated
+	}
+
+	return n.persist(n.stop(ctx, false))
+}
+
+// StopNodeSets stops all running node sets, other services of the network keep running.
+func (n *Network) StopNodeSets(ctx context.Context) error {
+	unlock, err := n.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if !n.generated() {
+		return ErrNotGenerated
+	}
+
+	return n.persist(n.stop(ctx, true))
+}
+
+func (n *Network) stop(ctx context.Context, nodesOnly bool) error {
+	log.Println("Stopping network...")
+
+	runner, err := n.runner()
+	if err != nil {
+		return err
+	}
+
+	stoppedJobs, err := runner.StopNetwork(ctx, n.state.RunningJobs, nodesOnly)
+	if err != nil {
+		return fmt.Errorf("failed to stop network: %w", err)
+	}
+
+	if n.state.RunningJobs != nil {
+		n.state.RunningJobs.RemoveRunningJobsIDs(stoppedJobs)
+	}
+
+	log.Println("Network successfully stopped.")
+	return nil
+}
+
+// Destroy stops the network, removes all of its files and unregisters it from the network registry.
+// Jobs are not stopped when Nomad is not reachable.
+func (n *Network) Destroy(ctx context.Context) error {
+	unlock, err := n.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if n.generated() {
+		if err := n.stop(ctx, false); err != nil {
+			if !nomad.IsConnectionErr(err) {
+				return fmt.Errorf("failed to stop network: %w", err)
+			}
+			log.Println("Couldn't connect to nomad, skipping network shutdown...")
+		}
+	}
+
+	log.Println("Cleaning up network...")
+
+	if err := os.RemoveAll(n.homePath); err != nil {
+		return fmt.Errorf("failed to cleanup network: %w", err)
+	}
+
+	log.Println("Network has been successfully cleaned up.")
+
+	n.state.GeneratedServices = nil
+	n.state.RunningJobs = nil
+
+	return Unregister(n.homePath)
+}
+
+// Addresses returns ports open by the running network jobs, the jobs are sorted by name and task name.
+func (n *Network) Addresses(ctx context.Context) ([]JobPorts, error) {
+	if !n.running() {
+		return nil, ErrNotRunning
+	}
+
+	runner, err := n.runner()
+	if err != nil {
+		return nil, err
+	}
+
+	nomadExposedPorts, err := runner.ListExposedPorts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allOpenPorts, err := ports.OpenPortsPerJob(ctx, nomadExposedPorts, n.state.GeneratedServices)
+	if err != nil {
+		return nil, err
+	}
+
+	jobsPorts := make([]JobPorts, 0, len(allOpenPorts))
+	for job, openPorts := range allOpenPorts {
+		jobsPorts = append(jobsPorts, JobPorts{JobWithTask: job, Ports: openPorts})
+	}
+
+	sort.Slice(jobsPorts, func(i, j int) bool {
+		if jobsPorts[i].Name == jobsPorts[j].Name {
+			return jobsPorts[i].TaskName < jobsPorts[j].TaskName
+		}
+		return jobsPorts[i].Name < jobsPorts[j].Name
+	})
+
+	return jobsPorts, nil
+}
+
+// Logs returns up to given number of latest log lines of the job, prefixed by the task name.
+func (n *Network) Logs(jobID string, lines int) ([]string, error) {
+	if !n.generated() {
+		return nil, ErrNotGenerated
+	}
+
+	logsDir := filepath.Join(n.state.Config.LogsDir(), jobID)
+	// the job ID must not point outside the logs directory
+	if filepath.Dir(logsDir) != filepath.Clean(n.state.Config.LogsDir()) {
+		return nil, fmt.Errorf("logs of job %q: %w", jobID, ErrJobLogsNotFound)
+	}
+
+	if _, err := os.Stat(logsDir); err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("logs of job %q: %w", jobID, ErrJobLogsNotFound)
+		}
+		return nil, err
+	}
+
+	return logscollector.LastLines(logsDir, lines)
+}
+
+// p2pProxyJob returns job running proxies of Tendermint P2P connections, see config.Config.P2PProxy.
+func p2pProxyJob(conf *config.Config) config.ExecConfig {
+	return config.ExecConfig{
+		Name:    conf.P2PProxyJobName(),
+		Command: *conf.VegaCapsuleBinary,
+		Args:    []string{"chaos", "proxy", "--home-path", *conf.OutputDir},
+	}
+}
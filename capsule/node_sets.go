@@ -0,0 +1,374 @@
+package capsule
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"code.vegaprotocol.io/vegacapsule/generator"
+	"code.vegaprotocol.io/vegacapsule/nomad"
+	"code.vegaprotocol.io/vegacapsule/types"
+	"code.vegaprotocol.io/vegacapsule/utils"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// AddNodeSetOptions define which existing node set or group the new node set is based on.
+// Exactly one of BaseOn and BaseOnGroup has to be set.
+type AddNodeSetOptions struct {
+	// BaseOn is name of the node set the new node set is based on.
+	BaseOn string
+	// BaseOnGroup is name of the group the new node set is based on.
+	BaseOnGroup string
+	// Start starts the new node set once it's generated.
+	Start bool
+}
+
+type startOptions struct {
+	vegaBinary string
+}
+
+type StartOption func(*startOptions)
+
+// WithVegaBinary starts the node set with given Vega binary instead of the generated one.
+func WithVegaBinary(vegaBinary string) StartOption {
+	return func(o *startOptions) {
+		o.vegaBinary = vegaBinary
+	}
+}
+
+type stopOptions struct {
+	keepPreGenerateJobs bool
+}
+
+type StopOption func(*stopOptions)
+
+// KeepPreGenerateJobs keeps pre-generate jobs of the node set running.
+func KeepPreGenerateJobs() StopOption {
+	return func(o *stopOptions) {
+		o.keepPreGenerateJobs = true
+	}
+}
+
+// AddNodeSet generates new node set and adds it to the network.
+func (n *Network) AddNodeSet(ctx context.Context, opts AddNodeSetOptions) (*types.NodeSet, error) {
+	nodeSets, err := n.AddNodeSets(ctx, 1, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return nodeSets[0], nil
+}
+
+// AddNodeSets generates count of new node sets in parallel and adds them to the network.
+// When any of the node sets fails to be generated or started, the node sets added so far
+// are stopped, their files removed and their ports released.
+func (n *Network) AddNodeSets(ctx context.Context, count int, opts AddNodeSetOptions) ([]*types.NodeSet, error) {
+	unlock, err := n.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	if !n.generated() {
+		return nil, ErrNotGenerated
+	}
+
+	if count < 1 {
+		return nil, fmt.Errorf("count has to be > 0")
+	}
+
+	if !n.lockState {
+		// state loaded by the caller, Load reserves the ports already
+		if err := ReserveOtherNetworksPorts(n.state, n.homePath); err != nil {
+			return nil, err
+		}
+	}
+
+	portsBefore := n.state.Ports.Clone()
+
+	var eg errgroup.Group
+	var m sync.Mutex
+	newNodeSets := make([]*types.NodeSet, 0, count)
+	startedJobIDs := types.JobIDMap{}
+
+	for i := 0; i < count; i++ {
+		i := i + 1
+		eg.Go(func() error {
+			newNodeSet, err := n.addNodeSet(i, opts.BaseOn, opts.BaseOnGroup)
+			if err != nil {
+				return fmt.Errorf("failed to add new node: %w", err)
+			}
+
+			m.Lock()
+			newNodeSets = append(newNodeSets, newNodeSet)
+			m.Unlock()
+
+			if opts.Start {
+				jobID, err := n.runNodeSet(ctx, newNodeSet, "")
+				if err != nil {
+					return fmt.Errorf("failed start node: %w", err)
+				}
+
+				m.Lock()
+				startedJobIDs[jobID] = true
+				m.Unlock()
+			}
+
+			return nil
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		n.rollbackNodeSets(newNodeSets, startedJobIDs)
+		if portsBefore != nil {
+			n.state.Ports = portsBefore
+			n.state.Config.SetPortAllocator(portsBefore)
+		}
+		return nil, err
+	}
+
+	for _, ns := range newNodeSets {
+		n.state.GeneratedServices.NodeSets[ns.Name] = *ns
+	}
+
+	if n.state.RunningJobs == nil {
+		n.state.RunningJobs = &types.NetworkJobs{}
+	}
+	n.state.RunningJobs.MergeNetworkJobs(types.NetworkJobs{NodesSetsJobIDs: startedJobIDs})
+
+	return newNodeSets, n.persist(nil)
+}
+
+// rollbackNodeSets stops jobs of node sets that failed to be added and removes their files.
+// Errors are only logged as the error that caused the rollback is returned.
+func (n *Network) rollbackNodeSets(nodeSets []*types.NodeSet, startedJobIDs types.JobIDMap) {
+	jobIDs := startedJobIDs.ToSlice()
+	for _, ns := range nodeSets {
+		jobIDs = append(jobIDs, ns.PreGenerateJobsIDs()...)
+	}
+
+	if len(jobIDs) != 0 {
+		runner, err := n.runner()
+		if err != nil {
+			log.Printf("Failed to stop jobs of node sets that failed to be added: %s", err)
+		} else if _, err := runner.StopJobs(context.Background(), jobIDs); err != nil {
+			log.Printf("Failed to stop jobs of node sets that failed to be added: %s", err)
+		}
+	}
+
+	gen, err := generator.New(n.state.Config, *n.state.GeneratedServices, nomad.NewVoidJobRunner(), n.state.VegaChainID)
+	if err != nil {
+		log.Printf("Failed to remove node sets that failed to be added: %s", err)
+		return
+	}
+
+	for _, ns := range nodeSets {
+		if err := gen.RemoveNodeSet(*ns); err != nil {
+			log.Printf("Failed to remove node set %q that failed to be added: %s", ns.Name, err)
+		}
+	}
+}
+
+func (n *Network) addNodeSet(index int, baseOnNode, baseOnGroup string) (*types.NodeSet, error) {
+	if baseOnNode != "" && baseOnGroup != "" {
+		return nil, fmt.Errorf("provide either node set or group the new node set is based on, not both")
+	}
+
+	if baseOnNode == "" && baseOnGroup == "" {
+		return nil, fmt.Errorf("node set or group the new node set is based on must be provided")
+	}
+
+	runner, err := n.runner()
+	if err != nil {
+		return nil, err
+	}
+
+	gen, err := generator.New(n.state.Config, *n.state.GeneratedServices, runner, n.state.VegaChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		nodeSet    *types.NodeSet
+		groupName  string
+		groupIndex int = -1
+	)
+	if baseOnNode != "" {
+		nodeSet, err = n.state.GeneratedServices.GetNodeSet(baseOnNode)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get node set by name: %w", err)
+		}
+
+		groupName = nodeSet.GroupName
+		groupIndex = nodeSet.GroupIndex
+	} else {
+		for groupIdx, group := range n.state.Config.Network.Nodes {
+			if group.Name == baseOnGroup {
+				groupIndex = groupIdx
+				break
+			}
+		}
+
+		if groupIndex < 0 {
+			return nil, fmt.Errorf("the %s nodes group not found", baseOnGroup)
+		}
+
+		nodes := n.state.GeneratedServices.GetNodeSetsByGroupName(baseOnGroup)
+		if len(nodes) < 1 {
+			// Nodes within given group does not exists, fallback to the first available node
+			nodes = n.state.GeneratedServices.NodeSets.ToSlice()
+		}
+
+		nodeSet = &(nodes[0])
+		groupName = baseOnGroup
+	}
+
+	nodeConfig, err := n.state.Config.Network.GetNodeConfig(groupName)
+	if err != nil {
+		return nil, err
+	}
+
+	groupNodeSets := n.state.GeneratedServices.GetNodeSetsByGroupName(groupName)
+
+	return gen.AddNodeSet(
+		nextNodeSetIndex(*n.state.GeneratedServices)-1+index,
+		len(groupNodeSets),
+		groupIndex,
+		*nodeConfig,
+		*nodeSet,
+		n.state.GeneratedServices.Faucet,
+	)
+}
+
+// nextNodeSetIndex returns index following the highest index of existing node sets,
+// so it doesn't collide with existing node sets when some of them have been removed.
+func nextNodeSetIndex(genServices types.GeneratedServices) int {
+	next := 0
+	for _, ns := range genServices.NodeSets {
+		if ns.Index >= next {
+			next = ns.Index + 1
+		}
+	}
+
+	return next
+}
+
+// StartNodeSet starts the node set together with its pre-generate jobs.
+func (n *Network) StartNodeSet(ctx context.Context, name string, opts ...StartOption) error {
+	unlock, err := n.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if !n.generated() {
+		return ErrNotGenerated
+	}
+
+	o := &startOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	nodeSet, err := n.state.GeneratedServices.GetNodeSet(name)
+	if err != nil {
+		return err
+	}
+
+	jobID, err := n.runNodeSet(ctx, nodeSet, o.vegaBinary)
+	if err != nil {
+		return fmt.Errorf("failed start node: %w", err)
+	}
+
+	if n.state.RunningJobs == nil {
+		n.state.RunningJobs = &types.NetworkJobs{}
+	}
+	n.state.RunningJobs.MergeNetworkJobs(types.NetworkJobs{
+		NodesSetsJobIDs: types.JobIDMap{jobID: true},
+	})
+
+	return n.persist(nil)
+}
+
+func (n *Network) runNodeSet(ctx context.Context, nodeSet *types.NodeSet, vegaBinary string) (string, error) {
+	log.Printf("starting %s node set", nodeSet.Name)
+
+	runner, err := n.runner()
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := runner.RunRawNomadJobs(ctx, nodeSet.PreGenerateRawJobs()); err != nil {
+		return "", fmt.Errorf("failed to start node set %q pre generate jobs: %w", nodeSet.Name, err)
+	}
+
+	if vegaBinary != "" {
+		vegaBinPath, err := utils.BinaryAbsPath(vegaBinary)
+		if err != nil {
+			return "", fmt.Errorf("failed to get absolute path for %q: %w", vegaBinary, err)
+		}
+
+		nodeSet.Vega.BinaryPath = vegaBinPath
+
+		if nodeSet.DataNode != nil {
+			nodeSet.DataNode.BinaryPath = vegaBinPath
+		}
+	}
+
+	res, err := runner.RunNodeSets(ctx, []types.NodeSet{*nodeSet}, n.opts.stopOnFailure)
+	if err != nil {
+		return "", fmt.Errorf("failed to start nomad node set %q : %w", nodeSet.Name, err)
+	}
+
+	log.Printf("starting %s node set success", nodeSet.Name)
+	return res[0], nil
+}
+
+// StopNodeSet stops the node set together with its pre-generate jobs unless KeepPreGenerateJobs is used.
+func (n *Network) StopNodeSet(ctx context.Context, name string, opts ...StopOption) error {
+	unlock, err := n.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if !n.generated() {
+		return ErrNotGenerated
+	}
+
+	o := &stopOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	log.Printf("stopping %s node set", name)
+
+	ns, err := n.state.GeneratedServices.GetNodeSet(name)
+	if err != nil {
+		return err
+	}
+
+	runner, err := n.runner()
+	if err != nil {
+		return err
+	}
+
+	toRemove := []string{name}
+	if !o.keepPreGenerateJobs {
+		toRemove = append(toRemove, ns.PreGenerateJobsIDs()...)
+	}
+
+	stoppedJobs, err := runner.StopJobs(ctx, toRemove)
+	if err != nil {
+		return fmt.Errorf("failed to stop nomad job %q: %w", name, err)
+	}
+
+	if n.state.RunningJobs != nil {
+		n.state.RunningJobs.RemoveRunningJobsIDs(stoppedJobs)
+	}
+
+	log.Printf("stopping %s node set success", name)
+	return n.persist(nil)
+}
@@ -0,0 +1,165 @@
+package capsule
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/jobrunner"
+	"code.vegaprotocol.io/vegacapsule/ports"
+	"code.vegaprotocol.io/vegacapsule/registry"
+	"code.vegaprotocol.io/vegacapsule/state"
+)
+
+// NewPortAllocator returns port allocator that never allocates ports already allocated by other registered networks.
+func NewPortAllocator(homePath string) (*ports.Allocator, error) {
+	reserved, err := otherNetworksPorts(homePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return ports.NewAllocator(reserved), nil
+}
+
+// ReserveOtherNetworksPorts makes sure port allocator restored from the network state
+// never allocates ports already allocated by other registered networks.
+func ReserveOtherNetworksPorts(netState *state.NetworkState, homePath string) error {
+	if netState.Ports == nil {
+		return nil
+	}
+
+	reserved, err := otherNetworksPorts(homePath)
+	if err != nil {
+		return fmt.Errorf("failed to reserve ports of other networks: %w", err)
+	}
+
+	netState.Ports.Reserve(reserved...)
+
+	return nil
+}
+
+func otherNetworksPorts(homePath string) ([]int64, error) {
+	reg, err := registry.LoadDefault()
+	if err != nil {
+		return nil, err
+	}
+
+	reserved := []int64{}
+	for _, n := range reg.List() {
+		if filepath.Clean(n.HomePath) == filepath.Clean(homePath) {
+			continue
+		}
+
+		otherState, err := state.LoadNetworkState(n.HomePath, state.WithoutSecrets())
+		if err != nil {
+			log.Printf("failed to load state of network %q, its ports are not reserved: %s", n.Name, err)
+			continue
+		}
+
+		for _, p := range otherState.Ports.AllocatedPorts() {
+			reserved = append(reserved, p.Port)
+		}
+	}
+
+	return reserved, nil
+}
+
+// ProtectOtherNetworksJobs makes sure the runner never stops jobs of other registered networks.
+func ProtectOtherNetworksJobs(runner jobrunner.JobRunner, homePath string) error {
+	reg, err := registry.LoadDefault()
+	if err != nil {
+		return err
+	}
+
+	runner.IgnoreJobsWithPrefixes(reg.OtherNetworksJobPrefixes(homePath)...)
+
+	return nil
+}
+
+// Register registers the network in the network registry under given name,
+// the network name from the config is used when the name is empty.
+func Register(netState state.NetworkState, name string) error {
+	n := registryNetwork(netState, name)
+
+	err := registry.UpdateDefault(func(reg *registry.NetworkRegistry) error {
+		if err := reg.Register(n); err != nil {
+			return err
+		}
+
+		if reg.Current == "" {
+			reg.Current = n.Name
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("network registered as %q", n.Name)
+
+	return nil
+}
+
+// Unregister removes the network with given home path from the network registry.
+func Unregister(homePath string) error {
+	return registry.UpdateDefault(func(reg *registry.NetworkRegistry) error {
+		if n := reg.GetByHomePath(homePath); n != nil {
+			reg.Unregister(n.Name)
+		}
+
+		return nil
+	})
+}
+
+// checkRegistration fails when the network can't be registered, e.g. because of colliding jobs with other network.
+func checkRegistration(netState state.NetworkState, name string) error {
+	reg, err := registry.LoadDefault()
+	if err != nil {
+		return err
+	}
+
+	return reg.Register(registryNetwork(netState, name))
+}
+
+func registryNetwork(netState state.NetworkState, name string) registry.Network {
+	if name == "" {
+		name = netState.Config.Network.Name
+	}
+
+	return registry.Network{
+		Name:        name,
+		HomePath:    *netState.Config.OutputDir,
+		JobPrefixes: networkJobPrefixes(netState),
+	}
+}
+
+// networkJobPrefixes returns prefixes of all Nomad jobs the network can run.
+func networkJobPrefixes(netState state.NetworkState) []string {
+	prefixes := []string{}
+	if netState.Config == nil {
+		return prefixes
+	}
+
+	conf := netState.Config
+	prefixes = append(prefixes, conf.Network.Name+"-")
+
+	for _, pStart := range []*config.PStartConfig{conf.Network.PreStart, conf.Network.PostStart} {
+		if pStart == nil {
+			continue
+		}
+		for _, dc := range pStart.Docker {
+			prefixes = append(prefixes, dc.Name)
+		}
+		for _, ec := range pStart.Exec {
+			prefixes = append(prefixes, ec.Name)
+		}
+	}
+
+	if netState.GeneratedServices != nil {
+		prefixes = append(prefixes, netState.GeneratedServices.PreGenerateJobsIDs()...)
+	}
+
+	return prefixes
+}
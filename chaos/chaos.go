@@ -0,0 +1,339 @@
+// Package chaos injects failures into processes of a running network to test its resilience.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"time"
+
+	"code.vegaprotocol.io/vegacapsule/types"
+)
+
+type Action string
+
+const (
+	// ActionKill kills the process with SIGKILL.
+	ActionKill Action = "kill"
+	// ActionPause freezes the process with SIGSTOP.
+	ActionPause Action = "pause"
+	// ActionResume resumes the paused process with SIGCONT.
+	ActionResume Action = "resume"
+	// ActionRestart restarts the process, or starts the node set again when it isn't running.
+	ActionRestart Action = "restart"
+	// ActionPartition cuts links between node sets, see Rules.
+	ActionPartition Action = "partition"
+	// ActionLatency adds latency to links of a node set, see Rules.
+	ActionLatency Action = "latency"
+	// ActionHeal removes all partitions and latencies.
+	ActionHeal Action = "heal"
+)
+
+const (
+	ProcessVega = "vega"
+	// ProcessTendermint is run inside of the Vega node process, so it's the same process as ProcessVega.
+	ProcessTendermint = "tendermint"
+	ProcessDataNode   = "data-node"
+)
+
+// ErrUnsafe is returned when the action would take down more validators than allowed.
+var ErrUnsafe = errors.New("too many validators would be down")
+
+// ErrNoPauseDuration is returned when the pause action has no duration, there's no way to resume the process later.
+var ErrNoPauseDuration = errors.New("pause duration has to be greater than 0")
+
+// Runner controls the jobs of the network, it's implemented by jobrunner.JobRunner.
+type Runner interface {
+	SignalTask(ctx context.Context, jobID, taskName, signal string) error
+	RestartTask(ctx context.Context, jobID, taskName string) error
+	JobStatus(ctx context.Context, jobID string) (*types.JobStatus, error)
+	RunNodeSets(ctx context.Context, nodeSets []types.NodeSet, stopOnFailure bool) ([]string, error)
+}
+
+// Target selects node set and its process the action is made on.
+// A random node set of the group or of all node sets is selected when NodeSet is empty.
+type Target struct {
+	NodeSet string
+	Group   string
+	Process string
+}
+
+// Monkey makes chaos actions on node sets of the network. An action on validator node set is skipped
+// when more than MaxValidatorsDown validators would be down, paused or killed, because of it.
+type Monkey struct {
+	runner            Runner
+	nodeSets          []types.NodeSet
+	log               *Log
+	maxValidatorsDown int
+	rnd               *rand.Rand
+}
+
+func New(runner Runner, nodeSets []types.NodeSet, chaosLog *Log, maxValidatorsDown int, rnd *rand.Rand) *Monkey {
+	sort.Slice(nodeSets, func(i, j int) bool { return nodeSets[i].Name < nodeSets[j].Name })
+
+	return &Monkey{
+		runner:            runner,
+		nodeSets:          nodeSets,
+		log:               chaosLog,
+		maxValidatorsDown: maxValidatorsDown,
+		rnd:               rnd,
+	}
+}
+
+// DefaultMaxValidatorsDown returns how many of the validators can be down while the network keeps producing blocks.
+func DefaultMaxValidatorsDown(nodeSets []types.NodeSet) int {
+	validators := 0
+	for _, ns := range nodeSets {
+		if ns.IsValidator() {
+			validators++
+		}
+	}
+
+	if validators == 0 {
+		return 0
+	}
+
+	return (validators - 1) / 3
+}
+
+// Do makes the action on the target. Killed processes are restarted after the duration when it's not zero,
+// paused processes are always resumed after the duration, which has to be greater than zero. Processes are recovered
+// also when the context is cancelled in the meantime. All actions are recorded in the chaos log.
+func (m *Monkey) Do(ctx context.Context, action Action, target Target, duration time.Duration) error {
+	if err := validateDuration(action, duration); err != nil {
+		return err
+	}
+
+	ns, reason, err := m.selectNodeSet(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	if ns == nil {
+		m.record(Entry{Action: action, Process: target.Process, Skipped: reason})
+		return fmt.Errorf("%s skipped: %w", action, ErrUnsafe)
+	}
+
+	taskName, err := processTask(*ns, target.Process)
+	if err != nil {
+		return err
+	}
+
+	entry := Entry{Action: action, NodeSet: ns.Name, Process: target.Process, Task: taskName}
+
+	switch action {
+	case ActionKill:
+		if err := m.signal(ctx, entry, "SIGKILL"); err != nil {
+			return err
+		}
+
+		if duration == 0 {
+			return nil
+		}
+
+		m.wait(ctx, duration)
+		entry.Action = ActionRestart
+		return m.restart(context.WithoutCancel(ctx), *ns, entry)
+	case ActionPause:
+		if err := m.signal(ctx, entry, "SIGSTOP"); err != nil {
+			return err
+		}
+
+		m.wait(ctx, duration)
+		entry.Action = ActionResume
+		return m.signal(context.WithoutCancel(ctx), entry, "SIGCONT")
+	case ActionRestart:
+		return m.restart(ctx, *ns, entry)
+	}
+
+	return fmt.Errorf("unknown chaos action %q", action)
+}
+
+func validateDuration(action Action, duration time.Duration) error {
+	if action == ActionPause && duration <= 0 {
+		return ErrNoPauseDuration
+	}
+	return nil
+}
+
+// Run makes the action on the schedule until the context is cancelled or count of actions is made, zero count means no limit.
+// Failed and skipped actions don't stop the run.
+func (m *Monkey) Run(ctx context.Context, schedule Schedule, count int, action Action, target Target, duration time.Duration) error {
+	if err := validateDuration(action, duration); err != nil {
+		return err
+	}
+
+	for i := 0; count == 0 || i < count; i++ {
+		next := schedule.Next(time.Now())
+		if next.IsZero() {
+			return nil
+		}
+
+		log.Printf("next %s at %s", action, next.Format(time.RFC3339))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(time.Until(next)):
+		}
+
+		if err := m.Do(ctx, action, target, duration); err != nil {
+			if errors.Is(err, ErrUnsafe) {
+				log.Printf("chaos %s: %s", action, err)
+				continue
+			}
+			log.Printf("chaos %s failed: %s", action, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *Monkey) signal(ctx context.Context, entry Entry, signal string) error {
+	err := m.runner.SignalTask(ctx, entry.NodeSet, entry.Task, signal)
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	m.record(entry)
+
+	return err
+}
+
+func (m *Monkey) restart(ctx context.Context, ns types.NodeSet, entry Entry) error {
+	err := m.restartNodeSet(ctx, ns, entry.Task)
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	m.record(entry)
+
+	return err
+}
+
+func (m *Monkey) restartNodeSet(ctx context.Context, ns types.NodeSet, taskName string) error {
+	status, err := m.runner.JobStatus(ctx, ns.Name)
+	if err != nil {
+		return err
+	}
+
+	if status.Status == types.JobStatusRunning {
+		return m.runner.RestartTask(ctx, ns.Name, taskName)
+	}
+
+	// the node set failed, e.g. because of the killed process, and has to be started again
+	_, err = m.runner.RunNodeSets(ctx, []types.NodeSet{ns}, false)
+	return err
+}
+
+func (m *Monkey) record(e Entry) {
+	e.Time = time.Now().UTC()
+
+	if err := m.log.Record(e); err != nil {
+		log.Printf("failed to record chaos action: %s", err)
+	}
+
+	log.Printf("chaos %s %s %s: %s", e.Action, e.NodeSet, e.Process, e.Result())
+}
+
+func (m *Monkey) wait(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+}
+
+// selectNodeSet returns node set for the target that can be taken down without breaking the validators limit.
+// Nil node set with the reason is returned when no such node set exists.
+func (m *Monkey) selectNodeSet(ctx context.Context, target Target) (*types.NodeSet, string, error) {
+	candidates := []types.NodeSet{}
+	for _, ns := range m.nodeSets {
+		switch {
+		case target.NodeSet != "" && ns.Name != target.NodeSet:
+			continue
+		case target.Group != "" && ns.GroupName != target.Group:
+			continue
+		}
+		candidates = append(candidates, ns)
+	}
+
+	if len(candidates) == 0 {
+		if target.NodeSet != "" {
+			return nil, "", fmt.Errorf("failed to get node set with name %q: %w", target.NodeSet, types.ErrNodeSetNotFound)
+		}
+		return nil, "", fmt.Errorf("no node sets in group %q", target.Group)
+	}
+
+	m.rnd.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+
+	down, err := m.validatorsDown(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for _, ns := range candidates {
+		if !ns.IsValidator() || down[ns.Name] || len(down)+1 <= m.maxValidatorsDown {
+			return &ns, "", nil
+		}
+	}
+
+	return nil, fmt.Sprintf("%d validators are down and at most %d can be down", len(down), m.maxValidatorsDown), nil
+}
+
+// validatorsDown returns validator node sets which are not running, have a task which is not running or are paused.
+func (m *Monkey) validatorsDown(ctx context.Context) (map[string]bool, error) {
+	paused, err := m.log.paused()
+	if err != nil {
+		return nil, err
+	}
+
+	down := map[string]bool{}
+	for _, ns := range m.nodeSets {
+		if !ns.IsValidator() {
+			continue
+		}
+
+		if paused[ns.Name] {
+			down[ns.Name] = true
+			continue
+		}
+
+		status, err := m.runner.JobStatus(ctx, ns.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get status of node set %q: %w", ns.Name, err)
+		}
+
+		if status.Status != types.JobStatusRunning {
+			down[ns.Name] = true
+			continue
+		}
+
+		for _, t := range status.Tasks {
+			if t.State != "running" {
+				down[ns.Name] = true
+			}
+		}
+	}
+
+	return down, nil
+}
+
+// processTask returns name of the task running the process of the node set.
+func processTask(ns types.NodeSet, process string) (string, error) {
+	if ns.Visor != nil {
+		return "", fmt.Errorf("node set %q is run by Visor, its processes can't be targeted", ns.Name)
+	}
+
+	switch process {
+	case ProcessVega, ProcessTendermint:
+		return ns.Vega.Name, nil
+	case ProcessDataNode:
+		if ns.DataNode == nil {
+			return "", fmt.Errorf("node set %q has no data node", ns.Name)
+		}
+		return ns.DataNode.Name, nil
+	}
+
+	return "", fmt.Errorf("unknown process %q, expected one of %s, %s, %s", process, ProcessVega, ProcessTendermint, ProcessDataNode)
+}
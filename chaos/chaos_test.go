@@ -0,0 +1,166 @@
+package chaos_test
+
+import (
+	"context"
+	"math/rand"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"code.vegaprotocol.io/vegacapsule/chaos"
+	"code.vegaprotocol.io/vegacapsule/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRunner struct {
+	status   map[string]string
+	signals  []string
+	restarts []string
+}
+
+func (r *fakeRunner) SignalTask(ctx context.Context, jobID, taskName, signal string) error {
+	r.signals = append(r.signals, jobID+" "+taskName+" "+signal)
+	return nil
+}
+
+func (r *fakeRunner) RestartTask(ctx context.Context, jobID, taskName string) error {
+	r.restarts = append(r.restarts, jobID)
+	return nil
+}
+
+func (r *fakeRunner) JobStatus(ctx context.Context, jobID string) (*types.JobStatus, error) {
+	status, ok := r.status[jobID]
+	if !ok {
+		status = types.JobStatusRunning
+	}
+
+	return &types.JobStatus{ID: jobID, Status: status}, nil
+}
+
+func (r *fakeRunner) RunNodeSets(ctx context.Context, nodeSets []types.NodeSet, stopOnFailure bool) ([]string, error) {
+	for _, ns := range nodeSets {
+		r.restarts = append(r.restarts, ns.Name)
+		r.status[ns.Name] = types.JobStatusRunning
+	}
+	return nil, nil
+}
+
+func testNodeSets(validators int) []types.NodeSet {
+	nodeSets := []types.NodeSet{}
+	for i := 0; i < validators; i++ {
+		name := "validator-" + string(rune('a'+i))
+		nodeSets = append(nodeSets, types.NodeSet{
+			Name:      name,
+			GroupName: "validators",
+			Mode:      types.NodeModeValidator,
+			Vega:      types.VegaNode{GeneratedService: types.GeneratedService{Name: name + "-vega"}},
+		})
+	}
+
+	return append(nodeSets, types.NodeSet{
+		Name:      "full",
+		GroupName: "full",
+		Mode:      "full",
+		Vega:      types.VegaNode{GeneratedService: types.GeneratedService{Name: "full-vega"}},
+		DataNode:  &types.DataNode{GeneratedService: types.GeneratedService{Name: "full-data-node"}},
+	})
+}
+
+func TestDefaultMaxValidatorsDown(t *testing.T) {
+	assert.Equal(t, 0, chaos.DefaultMaxValidatorsDown(testNodeSets(0)))
+	assert.Equal(t, 0, chaos.DefaultMaxValidatorsDown(testNodeSets(3)))
+	assert.Equal(t, 1, chaos.DefaultMaxValidatorsDown(testNodeSets(4)))
+	assert.Equal(t, 2, chaos.DefaultMaxValidatorsDown(testNodeSets(7)))
+}
+
+func TestMonkeyRespectsMaxValidatorsDown(t *testing.T) {
+	ctx := context.Background()
+	runner := &fakeRunner{status: map[string]string{}}
+	chaosLog := chaos.NewLog(filepath.Join(t.TempDir(), chaos.LogFileName))
+	m := chaos.New(runner, testNodeSets(4), chaosLog, 1, rand.New(rand.NewSource(1)))
+
+	validators := chaos.Target{Group: "validators", Process: chaos.ProcessVega}
+
+	require.NoError(t, m.Do(ctx, chaos.ActionKill, validators, 0))
+	require.Len(t, runner.signals, 1)
+	assert.Contains(t, runner.signals[0], "SIGKILL")
+
+	// the killed validator is down now, so another one can't be taken down
+	var killed, other string
+	for _, ns := range testNodeSets(4) {
+		switch {
+		case !ns.IsValidator():
+		case runner.signals[0] == ns.Name+" "+ns.Vega.Name+" SIGKILL":
+			killed = ns.Name
+		default:
+			other = ns.Name
+		}
+	}
+	runner.status[killed] = types.JobStatusFailed
+
+	assert.ErrorIs(t, m.Do(ctx, chaos.ActionPause, chaos.Target{NodeSet: other, Process: chaos.ProcessVega}, time.Millisecond), chaos.ErrUnsafe)
+	assert.Len(t, runner.signals, 1)
+
+	// non validators are not limited
+	require.NoError(t, m.Do(ctx, chaos.ActionKill, chaos.Target{NodeSet: "full", Process: chaos.ProcessDataNode}, 0))
+	assert.Equal(t, "full full-data-node SIGKILL", runner.signals[1])
+
+	// the killed validator itself can be restarted
+	require.NoError(t, m.Do(ctx, chaos.ActionRestart, validators, 0))
+	assert.Equal(t, []string{killed}, runner.restarts)
+
+	entries, err := chaosLog.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	assert.Equal(t, chaos.ActionPause, entries[1].Action)
+	assert.NotEmpty(t, entries[1].Skipped)
+	assert.Equal(t, "ok", entries[3].Result())
+}
+
+func TestMonkeyPauseIsResumed(t *testing.T) {
+	runner := &fakeRunner{status: map[string]string{}}
+	chaosLog := chaos.NewLog(filepath.Join(t.TempDir(), chaos.LogFileName))
+	m := chaos.New(runner, testNodeSets(4), chaosLog, 1, rand.New(rand.NewSource(1)))
+
+	target := chaos.Target{NodeSet: "validator-a", Process: chaos.ProcessTendermint}
+	require.NoError(t, m.Do(context.Background(), chaos.ActionPause, target, time.Millisecond))
+	assert.Equal(t, []string{
+		"validator-a validator-a-vega SIGSTOP",
+		"validator-a validator-a-vega SIGCONT",
+	}, runner.signals)
+
+	entries, err := chaosLog.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, chaos.ActionResume, entries[1].Action)
+
+	// without duration the process would be resumed right away
+	assert.ErrorIs(t, m.Do(context.Background(), chaos.ActionPause, target, 0), chaos.ErrNoPauseDuration)
+	assert.Len(t, runner.signals, 2)
+}
+
+func TestParseSchedule(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	now := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	s, err := chaos.ParseSchedule("@every 30s", rnd)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(30*time.Second), s.Next(now))
+
+	s, err = chaos.ParseSchedule("@random 10s-1m", rnd)
+	require.NoError(t, err)
+	next := s.Next(now)
+	assert.False(t, next.Before(now.Add(10*time.Second)))
+	assert.False(t, next.After(now.Add(time.Minute)))
+
+	s, err = chaos.ParseSchedule("*/5 * * * *", rnd)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(5*time.Minute), s.Next(now))
+
+	for _, invalid := range []string{"@every x", "@every -1s", "@random 1m-10s", "@random 10s", "not a cron"} {
+		_, err := chaos.ParseSchedule(invalid, rnd)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,129 @@
+package chaos
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// LogFileName is the name of the chaos log file in the network home.
+const LogFileName = "chaos.log"
+
+// Entry is a single chaos action recorded in the chaos log.
+type Entry struct {
+	Time    time.Time `json:"time"`
+	Action  Action    `json:"action"`
+	NodeSet string    `json:"node_set,omitempty"`
+	Process string    `json:"process,omitempty"`
+	Task    string    `json:"task,omitempty"`
+	// Detail describes network faults, e.g. the partition or the delay.
+	Detail string `json:"detail,omitempty"`
+	// Skipped is the reason why the action has not been made.
+	Skipped string `json:"skipped,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Result returns the outcome of the action.
+func (e Entry) Result() string {
+	switch {
+	case e.Error != "":
+		return "failed: " + e.Error
+	case e.Skipped != "":
+		return "skipped: " + e.Skipped
+	}
+
+	return "ok"
+}
+
+// Log is an append only log of chaos actions, one JSON encoded entry per line.
+// It's shared by all chaos commands running against the network.
+type Log struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewLog(path string) *Log {
+	return &Log{path: path}
+}
+
+// Record appends the entry to the log.
+func (l *Log) Record(e Entry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to marshal chaos log entry: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open chaos log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("failed to write chaos log: %w", err)
+	}
+
+	return nil
+}
+
+// Entries returns all entries of the log in the order they have been recorded.
+func (l *Log) Entries() ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.Open(l.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open chaos log: %w", err)
+	}
+	defer f.Close()
+
+	entries := []Entry{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal chaos log entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read chaos log: %w", err)
+	}
+
+	return entries, nil
+}
+
+// paused returns node sets paused by successful pause actions that have not been resumed yet.
+func (l *Log) paused() (map[string]bool, error) {
+	entries, err := l.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	paused := map[string]bool{}
+	for _, e := range entries {
+		if e.Error != "" || e.Skipped != "" {
+			continue
+		}
+
+		switch e.Action {
+		case ActionPause:
+			paused[e.NodeSet] = true
+		case ActionResume:
+			delete(paused, e.NodeSet)
+		}
+	}
+
+	return paused, nil
+}
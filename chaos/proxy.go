@@ -0,0 +1,259 @@
+package chaos
+
+import (
+	"context"
+	"log"
+	"net"
+	"reflect"
+	"sync"
+	"time"
+)
+
+const (
+	proxyDialTimeout = 5 * time.Second
+	proxyBufferSize  = 32 * 1024
+)
+
+// Proxy forwards P2P connections of the links and applies rules from the rules file to them.
+// Connections of the cut links are closed and new connections are refused, data sent over delayed links
+// is held back for the delay. Rules and links are reloaded periodically, so they can be changed while the proxy runs.
+type Proxy struct {
+	rulesPath string
+	links     func() ([]Link, error)
+
+	mu      sync.RWMutex
+	rules   Rules
+	proxied map[string]*proxiedLink
+}
+
+type proxiedLink struct {
+	Link
+	listener net.Listener
+
+	mu    sync.Mutex
+	conns map[net.Conn]struct{}
+}
+
+func NewProxy(rulesPath string, links func() ([]Link, error)) *Proxy {
+	return &Proxy{
+		rulesPath: rulesPath,
+		links:     links,
+		proxied:   map[string]*proxiedLink{},
+	}
+}
+
+// Run runs the proxy until the context is cancelled, rules and links are reloaded every interval.
+func (p *Proxy) Run(ctx context.Context, interval time.Duration) error {
+	defer p.close()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		p.reload()
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Proxy) reload() {
+	rules, err := LoadRules(p.rulesPath)
+	if err != nil {
+		log.Printf("failed to reload rules, keeping previous ones: %s", err)
+	} else {
+		p.applyRules(rules)
+	}
+
+	links, err := p.links()
+	if err != nil {
+		log.Printf("failed to reload links, keeping previous ones: %s", err)
+		return
+	}
+
+	for _, l := range links {
+		p.mu.RLock()
+		_, ok := p.proxied[l.Listen]
+		p.mu.RUnlock()
+
+		if ok {
+			continue
+		}
+
+		if err := p.listen(l); err != nil {
+			log.Printf("failed to proxy link from %s to %s: %s", l.From, l.To, err)
+		}
+	}
+}
+
+func (p *Proxy) applyRules(rules Rules) {
+	p.mu.Lock()
+	changed := !reflect.DeepEqual(p.rules, rules)
+	p.rules = rules
+	proxied := make([]*proxiedLink, 0, len(p.proxied))
+	for _, pl := range p.proxied {
+		proxied = append(proxied, pl)
+	}
+	p.mu.Unlock()
+
+	if changed {
+		log.Printf("applying rules: partitions %v, delays %v", rules.Partitions, rules.Delays)
+	}
+
+	for _, pl := range proxied {
+		if rules.Cut(pl.From, pl.To) {
+			pl.closeConns()
+		}
+	}
+}
+
+func (p *Proxy) currentRules() Rules {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.rules
+}
+
+func (p *Proxy) listen(l Link) error {
+	listener, err := net.Listen("tcp", l.Listen)
+	if err != nil {
+		return err
+	}
+
+	pl := &proxiedLink{
+		Link:     l,
+		listener: listener,
+		conns:    map[net.Conn]struct{}{},
+	}
+
+	p.mu.Lock()
+	p.proxied[l.Listen] = pl
+	p.mu.Unlock()
+
+	log.Printf("proxying link from %s to %s: %s -> %s", l.From, l.To, l.Listen, l.Target)
+
+	go p.serve(pl)
+
+	return nil
+}
+
+func (p *Proxy) serve(pl *proxiedLink) {
+	for {
+		conn, err := pl.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go p.handle(pl, conn)
+	}
+}
+
+func (p *Proxy) handle(pl *proxiedLink, in net.Conn) {
+	defer in.Close()
+
+	if p.currentRules().Cut(pl.From, pl.To) {
+		return
+	}
+
+	out, err := net.DialTimeout("tcp", pl.Target, proxyDialTimeout)
+	if err != nil {
+		log.Printf("failed to connect link from %s to %s: %s", pl.From, pl.To, err)
+		return
+	}
+	defer out.Close()
+
+	pl.addConns(in, out)
+	defer pl.removeConns(in, out)
+
+	done := make(chan struct{}, 2)
+	go func() {
+		p.pipe(pl, out, in)
+		done <- struct{}{}
+	}()
+	go func() {
+		p.pipe(pl, in, out)
+		done <- struct{}{}
+	}()
+
+	// the connection is closed as soon as any side closes it
+	<-done
+}
+
+type chunk struct {
+	data   []byte
+	sendAt time.Time
+}
+
+// pipe copies data from src to dst, every read chunk is sent after the current delay of the link.
+func (p *Proxy) pipe(pl *proxiedLink, dst, src net.Conn) {
+	chunks := make(chan chunk, 1024)
+
+	go func() {
+		defer close(chunks)
+		for {
+			buf := make([]byte, proxyBufferSize)
+			n, err := src.Read(buf)
+			if n > 0 {
+				chunks <- chunk{data: buf[:n], sendAt: time.Now().Add(p.currentRules().Delay(pl.From, pl.To))}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	failed := false
+	for c := range chunks {
+		// keep reading until the source is closed, so the reading goroutine never blocks
+		if failed {
+			continue
+		}
+
+		time.Sleep(time.Until(c.sendAt))
+
+		if _, err := dst.Write(c.data); err != nil {
+			failed = true
+			src.Close()
+		}
+	}
+}
+
+func (p *Proxy) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, pl := range p.proxied {
+		pl.listener.Close()
+		pl.closeConns()
+	}
+}
+
+func (pl *proxiedLink) addConns(conns ...net.Conn) {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	for _, c := range conns {
+		pl.conns[c] = struct{}{}
+	}
+}
+
+func (pl *proxiedLink) removeConns(conns ...net.Conn) {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	for _, c := range conns {
+		delete(pl.conns, c)
+	}
+}
+
+func (pl *proxiedLink) closeConns() {
+	pl.mu.Lock()
+	defer pl.mu.Unlock()
+
+	for c := range pl.conns {
+		c.Close()
+	}
+}
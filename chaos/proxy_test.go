@@ -0,0 +1,129 @@
+package chaos_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"code.vegaprotocol.io/vegacapsule/chaos"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func echoServer(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn) // nolint
+			}()
+		}
+	}()
+
+	return l.Addr().String()
+}
+
+func freeAddress(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	return l.Addr().String()
+}
+
+// roundTrip sends a line over a new connection and returns the time it took to receive it back.
+func roundTrip(addr string) (time.Duration, error) {
+	conn, err := net.DialTimeout("tcp", addr, time.Second)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(2 * time.Second)) // nolint
+
+	start := time.Now()
+	if _, err := fmt.Fprintln(conn, "ping"); err != nil {
+		return 0, err
+	}
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return 0, err
+	}
+	if line != "ping\n" {
+		return 0, fmt.Errorf("unexpected response %q", line)
+	}
+
+	return time.Since(start), nil
+}
+
+func TestProxyAppliesRules(t *testing.T) {
+	homeDir := t.TempDir()
+	rulesPath := filepath.Join(homeDir, chaos.RulesFileName)
+	chaosLog := chaos.NewLog(filepath.Join(homeDir, chaos.LogFileName))
+
+	link := chaos.Link{From: "node-a", To: "node-b", Listen: freeAddress(t), Target: echoServer(t)}
+	proxy := chaos.NewProxy(rulesPath, func() ([]chaos.Link, error) {
+		return []chaos.Link{link}, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	interval := 10 * time.Millisecond
+	go proxy.Run(ctx, interval) // nolint
+
+	require.Eventually(t, func() bool {
+		_, err := roundTrip(link.Listen)
+		return err == nil
+	}, time.Second, interval)
+
+	require.NoError(t, chaos.SetDelay(rulesPath, chaosLog, "node-b", 100*time.Millisecond))
+	require.Eventually(t, func() bool {
+		d, err := roundTrip(link.Listen)
+		return err == nil && d >= 200*time.Millisecond
+	}, 2*time.Second, interval)
+
+	require.NoError(t, chaos.AddPartition(rulesPath, chaosLog, chaos.Partition{Sides: [][]string{{"node-a"}, {"node-b"}}}))
+	require.Eventually(t, func() bool {
+		_, err := roundTrip(link.Listen)
+		return err != nil
+	}, 2*time.Second, interval)
+
+	require.NoError(t, chaos.Heal(rulesPath, chaosLog))
+	require.Eventually(t, func() bool {
+		d, err := roundTrip(link.Listen)
+		return err == nil && d < 100*time.Millisecond
+	}, 2*time.Second, interval)
+
+	entries, err := chaosLog.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, chaos.ActionLatency, entries[0].Action)
+	assert.Equal(t, "100ms", entries[0].Detail)
+	assert.Equal(t, "node-a | node-b", entries[1].Detail)
+	assert.Equal(t, chaos.ActionHeal, entries[2].Action)
+}
+
+func TestRulesCut(t *testing.T) {
+	rules := chaos.Rules{Partitions: []chaos.Partition{{Sides: [][]string{{"a", "b"}, {"c"}}}}}
+
+	assert.True(t, rules.Cut("a", "c"))
+	assert.True(t, rules.Cut("c", "b"))
+	assert.False(t, rules.Cut("a", "b"))
+	assert.False(t, rules.Cut("a", "d"))
+}
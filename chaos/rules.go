@@ -0,0 +1,221 @@
+package chaos
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"code.vegaprotocol.io/vegacapsule/generator/tendermint"
+	"code.vegaprotocol.io/vegacapsule/ports"
+	"code.vegaprotocol.io/vegacapsule/types"
+)
+
+// RulesFileName is the name of the file with network rules in the network home, it's applied by the P2P proxy.
+const RulesFileName = "chaos-rules.json"
+
+// Partition splits node sets to sides, node sets on different sides can't connect to each other.
+// Node sets that are not on any side can connect to all node sets.
+type Partition struct {
+	Sides [][]string `json:"sides"`
+}
+
+func (p Partition) String() string {
+	sides := make([]string, 0, len(p.Sides))
+	for _, s := range p.Sides {
+		sides = append(sides, strings.Join(s, ","))
+	}
+
+	return strings.Join(sides, " | ")
+}
+
+func (p Partition) side(nodeSet string) int {
+	for i, s := range p.Sides {
+		for _, name := range s {
+			if name == nodeSet {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// Rules are faults of the links between node sets applied by the P2P proxy.
+type Rules struct {
+	Partitions []Partition `json:"partitions,omitempty"`
+	// Delays are latencies added to all links of the node set.
+	Delays map[string]time.Duration `json:"delays,omitempty"`
+}
+
+// Cut returns whether the link between the node sets is cut by a partition.
+func (r Rules) Cut(from, to string) bool {
+	for _, p := range r.Partitions {
+		fromSide, toSide := p.side(from), p.side(to)
+		if fromSide != -1 && toSide != -1 && fromSide != toSide {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Delay returns latency added to the link between the node sets, delays of both node sets are summed.
+func (r Rules) Delay(from, to string) time.Duration {
+	return r.Delays[from] + r.Delays[to]
+}
+
+// LoadRules loads rules from the file, empty rules are returned when the file doesn't exist.
+func LoadRules(path string) (Rules, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return Rules{}, nil
+		}
+		return Rules{}, fmt.Errorf("failed to read chaos rules: %w", err)
+	}
+
+	var r Rules
+	if err := json.Unmarshal(b, &r); err != nil {
+		return Rules{}, fmt.Errorf("failed to unmarshal chaos rules: %w", err)
+	}
+
+	return r, nil
+}
+
+// SaveRules replaces the rules file, so the P2P proxy never reads partially written rules.
+func SaveRules(path string, r Rules) error {
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal chaos rules: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), RulesFileName)
+	if err != nil {
+		return fmt.Errorf("failed to create chaos rules file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(b); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("failed to write chaos rules: %w", err)
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to write chaos rules: %w", err)
+	}
+
+	if err := os.Rename(tmpFile.Name(), path); err != nil {
+		return fmt.Errorf("failed to save chaos rules: %w", err)
+	}
+
+	return nil
+}
+
+// Link is a proxied P2P connection from Tendermint of one node set to Tendermint of another node set.
+type Link struct {
+	From string
+	To   string
+	// Listen is address of the proxy the From node set connects to.
+	Listen string
+	// Target is address of the P2P endpoint of the To node set.
+	Target string
+}
+
+// Links returns links of the node sets with ports allocated for the P2P proxy, see config.Config.P2PProxy.
+// Links are sorted by the listen address.
+func Links(nodeSets []types.NodeSet, allocator *ports.Allocator) ([]Link, error) {
+	if allocator == nil {
+		return nil, nil
+	}
+
+	targets := make(map[string]string, len(nodeSets))
+	links := []Link{}
+	for _, from := range nodeSets {
+		for _, to := range nodeSets {
+			port, ok := allocator.Ports[tendermint.P2PProxyPortName(from.Index, to.Index)]
+			if !ok {
+				continue
+			}
+
+			target, ok := targets[to.Name]
+			if !ok {
+				tmConf, err := tendermint.ReadConfig(to.Tendermint.ConfigFilePath)
+				if err != nil {
+					return nil, err
+				}
+
+				target = tendermint.LocalAddress(tmConf.P2P.ListenAddress)
+				targets[to.Name] = target
+			}
+
+			links = append(links, Link{
+				From:   from.Name,
+				To:     to.Name,
+				Listen: fmt.Sprintf("127.0.0.1:%d", port),
+				Target: target,
+			})
+		}
+	}
+
+	sort.Slice(links, func(i, j int) bool { return links[i].Listen < links[j].Listen })
+
+	return links, nil
+}
+
+// AddPartition adds the partition to the rules and records it in the chaos log.
+func AddPartition(rulesPath string, chaosLog *Log, p Partition) error {
+	return updateRules(rulesPath, chaosLog, Entry{Action: ActionPartition, Detail: p.String()}, func(r *Rules) {
+		r.Partitions = append(r.Partitions, p)
+	})
+}
+
+// SetDelay sets latency added to all links of the node set and records it in the chaos log, zero delay removes the latency.
+func SetDelay(rulesPath string, chaosLog *Log, nodeSet string, delay time.Duration) error {
+	return updateRules(rulesPath, chaosLog, Entry{Action: ActionLatency, NodeSet: nodeSet, Detail: delay.String()}, func(r *Rules) {
+		if delay == 0 {
+			delete(r.Delays, nodeSet)
+			return
+		}
+
+		if r.Delays == nil {
+			r.Delays = map[string]time.Duration{}
+		}
+		r.Delays[nodeSet] = delay
+	})
+}
+
+// Heal removes all partitions and latencies and records it in the chaos log.
+func Heal(rulesPath string, chaosLog *Log) error {
+	return updateRules(rulesPath, chaosLog, Entry{Action: ActionHeal}, func(r *Rules) {
+		*r = Rules{}
+	})
+}
+
+func updateRules(rulesPath string, chaosLog *Log, entry Entry, update func(r *Rules)) error {
+	err := func() error {
+		rules, err := LoadRules(rulesPath)
+		if err != nil {
+			return err
+		}
+
+		update(&rules)
+
+		return SaveRules(rulesPath, rules)
+	}()
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	entry.Time = time.Now().UTC()
+	if recErr := chaosLog.Record(entry); recErr != nil {
+		log.Printf("failed to record chaos action: %s", recErr)
+	}
+
+	return err
+}
@@ -0,0 +1,90 @@
+package chaos
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/cronexpr"
+)
+
+const (
+	everyPrefix  = "@every "
+	randomPrefix = "@random "
+)
+
+// Schedule returns when the next action is made.
+type Schedule interface {
+	Next(now time.Time) time.Time
+}
+
+type everySchedule time.Duration
+
+func (s everySchedule) Next(now time.Time) time.Time {
+	return now.Add(time.Duration(s))
+}
+
+type randomSchedule struct {
+	min, max time.Duration
+	rnd      *rand.Rand
+}
+
+func (s randomSchedule) Next(now time.Time) time.Time {
+	return now.Add(s.min + time.Duration(s.rnd.Int63n(int64(s.max-s.min)+1)))
+}
+
+type cronSchedule struct {
+	expr *cronexpr.Expression
+}
+
+func (s cronSchedule) Next(now time.Time) time.Time {
+	return s.expr.Next(now)
+}
+
+// ParseSchedule parses the schedule, it's either `@every <duration>`, `@random <min duration>-<max duration>`
+// or a cron expression, e.g. `*/5 * * * *`.
+func ParseSchedule(schedule string, rnd *rand.Rand) (Schedule, error) {
+	schedule = strings.TrimSpace(schedule)
+
+	switch {
+	case strings.HasPrefix(schedule, everyPrefix):
+		d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(schedule, everyPrefix)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse schedule %q: %w", schedule, err)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("failed to parse schedule %q: interval has to be > 0", schedule)
+		}
+
+		return everySchedule(d), nil
+	case strings.HasPrefix(schedule, randomPrefix):
+		minStr, maxStr, ok := strings.Cut(strings.TrimSpace(strings.TrimPrefix(schedule, randomPrefix)), "-")
+		if !ok {
+			return nil, fmt.Errorf("failed to parse schedule %q: expected interval in <min>-<max> format", schedule)
+		}
+
+		minD, err := time.ParseDuration(minStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse schedule %q: %w", schedule, err)
+		}
+
+		maxD, err := time.ParseDuration(maxStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse schedule %q: %w", schedule, err)
+		}
+
+		if minD <= 0 || maxD < minD {
+			return nil, fmt.Errorf("failed to parse schedule %q: expected 0 < min <= max", schedule)
+		}
+
+		return randomSchedule{min: minD, max: maxD, rnd: rnd}, nil
+	}
+
+	expr, err := cronexpr.Parse(schedule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schedule %q: %w", schedule, err)
+	}
+
+	return cronSchedule{expr: expr}, nil
+}
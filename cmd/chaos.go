@@ -0,0 +1,340 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"code.vegaprotocol.io/vegacapsule/capsule"
+	"code.vegaprotocol.io/vegacapsule/chaos"
+	"code.vegaprotocol.io/vegacapsule/jobrunner"
+	"code.vegaprotocol.io/vegacapsule/state"
+
+	"github.com/spf13/cobra"
+)
+
+// chaosProxyReloadInterval is how often the P2P proxy reloads rules and links of the network.
+const chaosProxyReloadInterval = 2 * time.Second
+
+var (
+	chaosNodeSet           string
+	chaosGroup             string
+	chaosProcess           string
+	chaosSchedule          string
+	chaosCount             int
+	chaosMaxValidatorsDown int
+	chaosRestartAfter      time.Duration
+	chaosPauseDuration     time.Duration
+	chaosGroups            []string
+	chaosDelay             time.Duration
+)
+
+var chaosCmd = &cobra.Command{
+	Use:   "chaos",
+	Short: "Inject process and network faults into a running network",
+	Long: `Kill, pause or restart Vega, Tendermint or data node process of a node set to test resilience of the network.
+The node set is given by --name, otherwise a random node set of --group or of the whole network is used.
+Actions are made once or repeatedly on the --schedule. Actions taking down more than --max-validators-down
+validators are skipped. All actions are recorded with timestamps in the chaos log in the network home.
+
+Network partitions and latencies are applied by proxies of Tendermint P2P connections between node sets,
+they are available when the network is generated with "p2p_proxy = true" in the config.`,
+}
+
+var chaosKillCmd = &cobra.Command{
+	Use:   "kill",
+	Short: "Kill process of a node set with SIGKILL",
+	Example: `# Kill Vega of a random validator every 2 to 5 minutes and start it again after 30 seconds
+vegacapsule chaos kill --group validators --schedule "@random 2m-5m" --restart-after 30s`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runChaos(chaos.ActionKill, chaosRestartAfter)
+	},
+}
+
+var chaosPauseCmd = &cobra.Command{
+	Use:   "pause",
+	Short: "Pause process of a node set with SIGSTOP and resume it with SIGCONT after the duration",
+	Example: `# Pause data node of the node set for one minute
+vegacapsule chaos pause --name testnet-nodeset-full-0-full --process data-node --duration 1m`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runChaos(chaos.ActionPause, chaosPauseDuration)
+	},
+}
+
+var chaosRestartCmd = &cobra.Command{
+	Use:   "restart",
+	Short: "Restart process of a node set",
+	Example: `# Restart random node set every 10 minutes, 6 times
+vegacapsule chaos restart --schedule "*/10 * * * *" --count 6`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runChaos(chaos.ActionRestart, 0)
+	},
+}
+
+var chaosLogCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Print all recorded chaos actions",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := chaosLog().Entries()
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "TIME\tACTION\tNODE SET\tPROCESS\tDETAIL\tRESULT")
+		for _, e := range entries {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", e.Time.Format(time.RFC3339), e.Action, e.NodeSet, e.Process, e.Detail, e.Result())
+		}
+
+		return w.Flush()
+	},
+}
+
+var chaosPartitionCmd = &cobra.Command{
+	Use:   "partition",
+	Short: "Cut P2P connections between node sets of the groups",
+	Long: `Cut P2P connections between node sets of different groups, connections within a group are kept.
+Node sets of other groups can still connect to all node sets. Use "chaos heal" to remove the partition.`,
+	Example: `# Split validators from the full nodes
+vegacapsule chaos partition --groups validators,full`,
+	RunE: withNetworkLock(func(cmd *cobra.Command, args []string) error {
+		netState, err := loadChaosProxyNetwork("partition")
+		if err != nil {
+			return err
+		}
+
+		if len(chaosGroups) < 2 {
+			return fmt.Errorf("at least 2 groups are required to partition the network")
+		}
+
+		partition := chaos.Partition{}
+		for _, group := range chaosGroups {
+			nodeSets := netState.GeneratedServices.GetNodeSetsByGroupName(group)
+			if len(nodeSets) == 0 {
+				return fmt.Errorf("no node sets in group %q", group)
+			}
+
+			names := make([]string, 0, len(nodeSets))
+			for _, ns := range nodeSets {
+				names = append(names, ns.Name)
+			}
+			partition.Sides = append(partition.Sides, names)
+		}
+
+		return chaos.AddPartition(chaosRulesPath(), chaosLog(), partition)
+	}),
+}
+
+var chaosLatencyCmd = &cobra.Command{
+	Use:   "latency",
+	Short: "Add latency to all P2P connections of a node set",
+	Example: `# Delay all data sent to and from the node set by 200ms
+vegacapsule chaos latency --node testnet-nodeset-validators-0-validator --delay 200ms`,
+	RunE: withNetworkLock(func(cmd *cobra.Command, args []string) error {
+		netState, err := loadChaosProxyNetwork("latency")
+		if err != nil {
+			return err
+		}
+
+		if _, err := netState.GeneratedServices.GetNodeSet(chaosNodeSet); err != nil {
+			return err
+		}
+
+		return chaos.SetDelay(chaosRulesPath(), chaosLog(), chaosNodeSet, chaosDelay)
+	}),
+}
+
+var chaosHealCmd = &cobra.Command{
+	Use:   "heal",
+	Short: "Remove all network partitions and latencies",
+	RunE: withNetworkLock(func(cmd *cobra.Command, args []string) error {
+		if _, err := loadChaosProxyNetwork("heal"); err != nil {
+			return err
+		}
+
+		return chaos.Heal(chaosRulesPath(), chaosLog())
+	}),
+}
+
+var chaosProxyCmd = &cobra.Command{
+	Use:    "proxy",
+	Short:  "Run proxies of Tendermint P2P connections between node sets",
+	Long:   "Run proxies of Tendermint P2P connections between node sets. It's started as a job of the network generated with the P2P proxy enabled.",
+	Hidden: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer cancel()
+
+		proxy := chaos.NewProxy(chaosRulesPath(), func() ([]chaos.Link, error) {
+			netState, err := state.LoadNetworkState(homePath, stateLoadOptsWithoutSecrets()...)
+			if err != nil {
+				return nil, err
+			}
+
+			if netState.Empty() {
+				return nil, networkNotBootstrappedErr("proxy")
+			}
+
+			return chaos.Links(netState.GeneratedServices.NodeSets.ToSlice(), netState.Ports)
+		})
+
+		return proxy.Run(ctx, chaosProxyReloadInterval)
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{chaosKillCmd, chaosPauseCmd, chaosRestartCmd} {
+		c.Flags().StringVar(&chaosNodeSet,
+			"name",
+			"",
+			"Name of the node set, random node set is used when not provided",
+		)
+		c.Flags().StringVar(&chaosGroup,
+			"group",
+			"",
+			"Name of the node sets group the random node set is selected from",
+		)
+		c.Flags().StringVar(&chaosProcess,
+			"process",
+			chaos.ProcessVega,
+			fmt.Sprintf("Process of the node set, one of %s, %s, %s", chaos.ProcessVega, chaos.ProcessTendermint, chaos.ProcessDataNode),
+		)
+		c.Flags().StringVar(&chaosSchedule,
+			"schedule",
+			"",
+			`Repeat the action on the schedule: "@every <duration>", "@random <min>-<max>" or a cron expression`,
+		)
+		c.Flags().IntVar(&chaosCount,
+			"count",
+			0,
+			"Stop after number of scheduled actions, 0 means until interrupted",
+		)
+		c.Flags().IntVar(&chaosMaxValidatorsDown,
+			"max-validators-down",
+			-1,
+			"Maximum number of validators down at the same time, defaults to (validators - 1) / 3",
+		)
+	}
+
+	chaosKillCmd.Flags().DurationVar(&chaosRestartAfter,
+		"restart-after",
+		0,
+		"Start the killed process again after the duration, 0 keeps it down",
+	)
+	chaosPauseCmd.Flags().DurationVar(&chaosPauseDuration,
+		"duration",
+		30*time.Second,
+		"How long the process stays paused, has to be greater than 0 as there is no other way to resume it",
+	)
+
+	chaosPartitionCmd.Flags().StringSliceVar(&chaosGroups,
+		"groups",
+		nil,
+		"Comma separated names of the node sets groups that can't connect to each other",
+	)
+	chaosPartitionCmd.MarkFlagRequired("groups") // nolint
+
+	chaosLatencyCmd.Flags().StringVar(&chaosNodeSet,
+		"node",
+		"",
+		"Name of the node set",
+	)
+	chaosLatencyCmd.Flags().DurationVar(&chaosDelay,
+		"delay",
+		0,
+		"Latency added to all P2P connections of the node set, 0 removes the latency",
+	)
+	chaosLatencyCmd.MarkFlagRequired("node")  // nolint
+	chaosLatencyCmd.MarkFlagRequired("delay") // nolint
+
+	chaosCmd.AddCommand(chaosKillCmd)
+	chaosCmd.AddCommand(chaosPauseCmd)
+	chaosCmd.AddCommand(chaosRestartCmd)
+	chaosCmd.AddCommand(chaosLogCmd)
+	chaosCmd.AddCommand(chaosPartitionCmd)
+	chaosCmd.AddCommand(chaosLatencyCmd)
+	chaosCmd.AddCommand(chaosHealCmd)
+	chaosCmd.AddCommand(chaosProxyCmd)
+}
+
+func chaosRulesPath() string {
+	return filepath.Join(homePath, chaos.RulesFileName)
+}
+
+func chaosLog() *chaos.Log {
+	return chaos.NewLog(filepath.Join(homePath, chaos.LogFileName))
+}
+
+// loadChaosProxyNetwork loads state of the network that has been generated with the P2P proxy.
+func loadChaosProxyNetwork(cmd string) (*state.NetworkState, error) {
+	netState, err := state.LoadNetworkState(homePath, stateLoadOptsWithoutSecrets()...)
+	if err != nil {
+		return nil, fmt.Errorf("failed load network state: %w", err)
+	}
+
+	if netState.Empty() {
+		return nil, networkNotBootstrappedErr(cmd)
+	}
+
+	if !netState.Config.P2PProxy {
+		return nil, fmt.Errorf("failed to %s network: network has been generated without P2P proxy, set \"p2p_proxy = true\" in the config", cmd)
+	}
+
+	return netState, nil
+}
+
+// runChaos doesn't hold the network lock, so the network can be managed while the scheduled actions run.
+func runChaos(action chaos.Action, duration time.Duration) error {
+	netState, err := state.LoadNetworkState(homePath, stateLoadOptsWithoutSecrets()...)
+	if err != nil {
+		return fmt.Errorf("failed load network state: %w", err)
+	}
+
+	if netState.Empty() {
+		return networkNotBootstrappedErr("chaos")
+	}
+
+	if !netState.Running() {
+		return networkNotRunningErr("chaos")
+	}
+
+	runner, err := jobrunner.New(netState.Config)
+	if err != nil {
+		return err
+	}
+
+	if err := capsule.ProtectOtherNetworksJobs(runner, homePath); err != nil {
+		return err
+	}
+
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	nodeSets := netState.GeneratedServices.NodeSets.ToSlice()
+
+	maxDown := chaosMaxValidatorsDown
+	if maxDown < 0 {
+		maxDown = chaos.DefaultMaxValidatorsDown(nodeSets)
+	}
+
+	monkey := chaos.New(runner, nodeSets, chaosLog(), maxDown, rnd)
+	target := chaos.Target{NodeSet: chaosNodeSet, Group: chaosGroup, Process: chaosProcess}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	if chaosSchedule == "" {
+		return monkey.Do(ctx, action, target, duration)
+	}
+
+	schedule, err := chaos.ParseSchedule(chaosSchedule, rnd)
+	if err != nil {
+		return err
+	}
+
+	return monkey.Run(ctx, schedule, chaosCount, action, target, duration)
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	"code.vegaprotocol.io/vegacapsule/dashboard"
+	"code.vegaprotocol.io/vegacapsule/jobrunner"
+	"code.vegaprotocol.io/vegacapsule/state"
+
+	"github.com/spf13/cobra"
+)
+
+var dashboardRefreshInterval time.Duration
+
+var dashboardCmd = &cobra.Command{
+	Use:   "dashboard",
+	Short: "Shows interactive terminal dashboard of a running network",
+	Long: `Shows node sets of a running network with their state, block height, number of peers and restarts refreshed in place,
+together with logs of a selected job. Node sets can be stopped, started and restarted directly from the dashboard.`,
+	Example: `# Show the dashboard refreshed every 5 seconds
+vegacapsule dashboard --refresh 5s`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		netState, err := state.LoadNetworkState(homePath, stateLoadOptsWithoutSecrets()...)
+		if err != nil {
+			return err
+		}
+
+		if netState.Empty() {
+			return networkNotBootstrappedErr("dashboard")
+		}
+
+		if !netState.Running() {
+			return networkNotRunningErr("dashboard")
+		}
+
+		runner, err := jobrunner.New(netState.Config)
+		if err != nil {
+			return err
+		}
+
+		d := dashboard.New(runner, dashboardActions{}, netState.GeneratedServices, netState.Config.LogsDir())
+
+		extraJobs := netState.RunningJobs.ExtraJobIDs.ToSlice()
+		sort.Strings(extraJobs)
+		d.AddJobs(extraJobs...)
+
+		// logs of the actions would break the dashboard drawn in the terminal
+		log.SetOutput(io.Discard)
+		defer log.SetOutput(os.Stderr)
+
+		return d.Run(cmd.Context(), os.Stdin, os.Stdout, dashboardRefreshInterval)
+	},
+}
+
+func init() {
+	dashboardCmd.PersistentFlags().DurationVar(&dashboardRefreshInterval,
+		"refresh",
+		time.Second*2,
+		"How often the state of the node sets is refreshed",
+	)
+}
+
+// dashboardActions changes node sets the same way as the nodes start and nodes stop commands do.
+type dashboardActions struct{}
+
+func (dashboardActions) StartNodeSet(ctx context.Context, name string) error {
+	return lockNetwork(func() error {
+		return runNodesStart(ctx, name, "")
+	})
+}
+
+func (dashboardActions) StopNodeSet(ctx context.Context, name string) error {
+	return lockNetwork(func() error {
+		return runNodesStop(ctx, name, true)
+	})
+}
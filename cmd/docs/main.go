@@ -20,7 +20,7 @@ var (
 func init() {
 	flag.StringVar(&tagName, "tag-name", "", "name of the tag")
 	flag.StringVar(&typeNames, "type-names", "", "comma separated types to be processed")
-	flag.StringVar(&directoryPath, "dir-path", "", "directory path of the file to generate docs from")
+	flag.StringVar(&directoryPath, "dir-path", "", "comma separated directory paths of the files to generate docs from")
 	flag.StringVar(&descriptionPath, "description-path", "", "path of file with description")
 }
 
@@ -42,7 +42,7 @@ func main() {
 		panic(err)
 	}
 
-	gen, err := docsgenerator.NewTypeDocGenerator(directoryPath, tagName)
+	gen, err := docsgenerator.NewTypeDocGenerator(strings.Split(directoryPath, ","), tagName)
 	if err != nil {
 		panic(err)
 	}
@@ -3,13 +3,11 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"time"
 
 	"code.vegaprotocol.io/vegacapsule/ethereum"
 	"code.vegaprotocol.io/vegacapsule/state"
 	"code.vegaprotocol.io/vegacapsule/types"
-	"code.vegaprotocol.io/vegacapsule/utils"
 
 	"github.com/spf13/cobra"
 )
@@ -91,7 +89,7 @@ var ethereumMultisigSetupCmd = &cobra.Command{
 	Short: "Setups the multisig smart contract",
 	Long:  `Adds all validators to the multisig smart contract`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		netState, err := state.LoadNetworkState(homePath)
+		netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
 		if err != nil {
 			return err
 		}
@@ -108,60 +106,15 @@ var ethereumMultisigSetupCmd = &cobra.Command{
 
 		validatorsKeyPairs := getSigners(netState.GeneratedServices.ListValidators())
 
-		// Primary bridge
-
-		primarySmartContracts, err := netState.Config.PrimarySmartContractsInfo()
-		if err != nil {
-			return fmt.Errorf("failed getting primary smart contract informations: %w", err)
-		}
-
-		primaryChainID, err := strconv.Atoi(netState.Config.Network.Ethereum.ChainID)
-		if err != nil {
-			return err
-		}
-
-		primaryClient, err := ethereum.NewEthereumMultisigClient(ctx, ethereum.EthereumMultisigClientParameters{
-			VegaBinary: *netState.Config.VegaBinary,
-			VegaHome:   utils.VegaNodeHomePath(homePath, 0),
-
-			ChainID:            primaryChainID,
-			EthereumAddress:    netState.Config.Network.Ethereum.Endpoint,
-			SmartContractsInfo: *primarySmartContracts,
-		})
-		if err != nil {
-			return fmt.Errorf("failed to create primary ethereum client: %w", err)
-		}
-
-		if err := primaryClient.InitMultisig(ctx, *primarySmartContracts, validatorsKeyPairs); err != nil {
-			return fmt.Errorf("failed to init primary multisig smart contract: %w", err)
-		}
-
-		// Secondary bridge
-
-		secondarySmartContracts, err := netState.Config.SecondarySmartContractsInfo()
-		if err != nil {
-			return fmt.Errorf("failed getting primary smart contract informations: %w", err)
-		}
-
-		secondaryChainID, err := strconv.Atoi(netState.Config.Network.SecondaryEthereum.ChainID)
+		bridges, err := newMultisigBridges(ctx, *netState)
 		if err != nil {
 			return err
 		}
 
-		secondaryClient, err := ethereum.NewEthereumMultisigClient(ctx, ethereum.EthereumMultisigClientParameters{
-			VegaBinary: *netState.Config.VegaBinary,
-			VegaHome:   utils.VegaNodeHomePath(homePath, 0),
-
-			ChainID:            secondaryChainID,
-			EthereumAddress:    netState.Config.Network.SecondaryEthereum.Endpoint,
-			SmartContractsInfo: *secondarySmartContracts,
-		})
-		if err != nil {
-			return fmt.Errorf("failed to create secondary ethereum client: %w", err)
-		}
-
-		if err := secondaryClient.InitMultisig(ctx, *secondarySmartContracts, validatorsKeyPairs); err != nil {
-			return fmt.Errorf("failed to init secondary multisig smart contract: %w", err)
+		for _, b := range bridges {
+			if err := b.client.InitMultisig(ctx, b.smartContracts, validatorsKeyPairs); err != nil {
+				return fmt.Errorf("failed to init %s multisig smart contract: %w", b.name, err)
+			}
 		}
 
 		return nil
@@ -1,22 +1,33 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/types"
+
+	"github.com/ethereum/go-ethereum/common"
 	vgtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/spf13/cobra"
 )
 
+var errAssetNotFound = errors.New("asset not found")
+
 var ethereumAssetCmd = &cobra.Command{
 	Use:   "asset",
-	Short: "Allows to deposit/stake/mint tokens through smartcontract",
+	Short: "Allows to deposit/stake/mint/withdraw/unstake/fund tokens through smartcontract",
 }
 
 func init() {
 	ethereumAssetCmd.AddCommand(ethereumAssetStakeCmd)
 	ethereumAssetCmd.AddCommand(ethereumAssetDepositCmd)
 	ethereumAssetCmd.AddCommand(ethereumAssetMintCmd)
+	ethereumAssetCmd.AddCommand(ethereumAssetWithdrawCmd)
+	ethereumAssetCmd.AddCommand(ethereumAssetUnstakeCmd)
+	ethereumAssetCmd.AddCommand(ethereumAssetFundCmd)
 }
 
 func printEthereumTx(tx *vgtypes.Transaction) error {
@@ -30,6 +41,39 @@ func printEthereumTx(tx *vgtypes.Transaction) error {
 	return nil
 }
 
+func printEthereumBalance(address common.Address, balance *big.Int) {
+	fmt.Printf("Balance of %s: %s\n", address, balance)
+}
+
 func defeaultSyncTimeout() time.Duration {
 	return time.Second * defaultEthreumWaitTimeout
 }
+
+func getSmartContractToken(conf config.Config, assetSymbol string) (*types.SmartContractsToken, error) {
+	asset := conf.GetSmartContractToken(assetSymbol)
+	if asset == nil {
+		return nil, fmt.Errorf("failed to get asset %q: %w", assetSymbol, errAssetNotFound)
+	}
+
+	return asset, nil
+}
+
+// bridgeSmartContracts returns the Ethereum endpoint and the smart contracts of the primary or the secondary bridge.
+func bridgeSmartContracts(conf config.Config, bridge string) (string, *types.SmartContractsInfo, error) {
+	switch bridge {
+	case "primary":
+		smartContracts, err := conf.PrimarySmartContractsInfo()
+		if err != nil {
+			return "", nil, fmt.Errorf("failed getting primary smart contract informations: %w", err)
+		}
+		return conf.Network.Ethereum.Endpoint, smartContracts, nil
+	case "secondary":
+		smartContracts, err := conf.SecondarySmartContractsInfo()
+		if err != nil {
+			return "", nil, fmt.Errorf("failed getting secondary smart contract informations: %w", err)
+		}
+		return conf.Network.SecondaryEthereum.Endpoint, smartContracts, nil
+	}
+
+	return "", nil, fmt.Errorf("unknown bridge %q, use primary or secondary", bridge)
+}
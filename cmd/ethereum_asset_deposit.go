@@ -7,9 +7,9 @@ import (
 
 	vgethereum "code.vegaprotocol.io/vegacapsule/libs/ethereum"
 	"code.vegaprotocol.io/vegacapsule/state"
-	"code.vegaprotocol.io/vegacapsule/types"
 
 	"github.com/ethereum/go-ethereum/common"
+	vgtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/spf13/cobra"
 )
 
@@ -43,62 +43,66 @@ var ethereumAssetDepositCmd = &cobra.Command{
 	Use:   "deposit",
 	Short: "Deposit allows to deposit an asset to given Vega public key.",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		netState, err := state.LoadNetworkState(homePath)
+		depositArgs, err := newEthereumAssetDepositArgs(
+			ethereumAssetDepositFlags.assetSymbol,
+			ethereumAssetDepositFlags.vegaPubKey,
+			ethereumAssetDepositFlags.amount,
+			ethereumAssetDepositFlags.bridge,
+		)
 		if err != nil {
 			return err
 		}
 
-		if netState.Empty() {
-			return networkNotBootstrappedErr("ethereum asset deposit")
+		tx, err := ethereumAssetDeposit(cmd.Context(), *depositArgs)
+		if err != nil {
+			return err
 		}
 
-		if !netState.Running() {
-			return networkNotRunningErr("ethereum asset deposit")
-		}
+		return printEthereumTx(tx)
+	},
+}
 
-		conf := netState.Config
+// newEthereumAssetDepositArgs resolves the asset and the bridge of a running network.
+func newEthereumAssetDepositArgs(assetSymbol, vegaPubKey string, amount int64, bridge string) (*ethereumAssetDepositOrStakeArgs, error) {
+	netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
+	if err != nil {
+		return nil, err
+	}
 
-		asset := conf.GetSmartContractToken(ethereumAssetDepositFlags.assetSymbol)
-		if asset == nil {
-			return fmt.Errorf("failed to get non existing asset: %q", ethereumAssetDepositFlags.assetSymbol)
-		}
+	if netState.Empty() {
+		return nil, networkNotBootstrappedErr("ethereum asset deposit")
+	}
 
-		var (
-			networkAddress string
-			smartContracts *types.SmartContractsInfo
-		)
-		switch ethereumAssetDepositFlags.bridge {
-		case "primary":
-			networkAddress = conf.Network.Ethereum.Endpoint
-			smartContracts, err = conf.PrimarySmartContractsInfo()
-			if err != nil {
-				return fmt.Errorf("failed getting primary smart contract informations: %w", err)
-			}
-		case "secondary":
-			networkAddress = conf.Network.SecondaryEthereum.Endpoint
-			smartContracts, err = conf.SecondarySmartContractsInfo()
-			if err != nil {
-				return fmt.Errorf("failed getting secondary smart contract informations: %w", err)
-			}
-		}
+	if !netState.Running() {
+		return nil, networkNotRunningErr("ethereum asset deposit")
+	}
 
-		depositArgs := ethereumAssetDepositOrStakeArgs{
-			amount:          ethereumAssetDepositFlags.amount,
-			vegaPubKey:      ethereumAssetDepositFlags.vegaPubKey,
-			ownerPrivateKey: smartContracts.EthereumOwner.Private,
-			bridgeAddress:   smartContracts.ERC20Bridge.EthereumAddress,
-			assetAddress:    asset.EthereumAddress,
-			networkAddress:  networkAddress,
-		}
+	conf := netState.Config
 
-		return ethereumAssetDeposit(cmd.Context(), depositArgs)
-	},
+	asset, err := getSmartContractToken(*conf, assetSymbol)
+	if err != nil {
+		return nil, err
+	}
+
+	networkAddress, smartContracts, err := bridgeSmartContracts(*conf, bridge)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ethereumAssetDepositOrStakeArgs{
+		amount:          amount,
+		vegaPubKey:      vegaPubKey,
+		ownerPrivateKey: smartContracts.EthereumOwner.Private,
+		bridgeAddress:   smartContracts.ERC20Bridge.EthereumAddress,
+		assetAddress:    asset.EthereumAddress,
+		networkAddress:  networkAddress,
+	}, nil
 }
 
-func ethereumAssetDeposit(ctx context.Context, args ethereumAssetDepositOrStakeArgs) error {
+func ethereumAssetDeposit(ctx context.Context, args ethereumAssetDepositOrStakeArgs) (*vgtypes.Transaction, error) {
 	client, err := vgethereum.NewClient(ctx, args.networkAddress)
 	if err != nil {
-		return fmt.Errorf("failed to create Ethereum client: %w", err)
+		return nil, fmt.Errorf("failed to create Ethereum client: %w", err)
 	}
 
 	syncTimeout := defeaultSyncTimeout()
@@ -111,7 +115,7 @@ func ethereumAssetDeposit(ctx context.Context, args ethereumAssetDepositOrStakeA
 		&syncTimeout,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to create erc20 bridge session for %s: %w", args.bridgeAddress, err)
+		return nil, fmt.Errorf("failed to create erc20 bridge session for %s: %w", args.bridgeAddress, err)
 	}
 
 	tokenSession, err := client.NewBaseTokenSession(
@@ -121,18 +125,18 @@ func ethereumAssetDeposit(ctx context.Context, args ethereumAssetDepositOrStakeA
 		&syncTimeout,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to create token session for %s: %w", args.assetAddress, err)
+		return nil, fmt.Errorf("failed to create token session for %s: %w", args.assetAddress, err)
 	}
 
 	amount := big.NewInt(args.amount)
 
 	if _, err := tokenSession.ApproveSync(bridgeAddr, amount); err != nil {
-		return fmt.Errorf("failed to approve asset amount to bridge: %w", err)
+		return nil, fmt.Errorf("failed to approve asset amount to bridge: %w", err)
 	}
 
 	vegaPubKeyArr, err := vgethereum.HexStringToByte32Array(args.vegaPubKey)
 	if err != nil {
-		return fmt.Errorf("failed to convert Vega pub key string to byte array: %w", err)
+		return nil, fmt.Errorf("failed to convert Vega pub key string to byte array: %w", err)
 	}
 
 	tx, err := bridgeSession.DepositAssetSync(
@@ -141,8 +145,8 @@ func ethereumAssetDeposit(ctx context.Context, args ethereumAssetDepositOrStakeA
 		vegaPubKeyArr,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to deposit asset: %w", err)
+		return nil, fmt.Errorf("failed to deposit asset: %w", err)
 	}
 
-	return printEthereumTx(tx)
+	return tx, nil
 }
@@ -0,0 +1,488 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"code.vegaprotocol.io/vegacapsule/config"
+	vgethereum "code.vegaprotocol.io/vegacapsule/libs/ethereum"
+	"code.vegaprotocol.io/vegacapsule/ports"
+	"code.vegaprotocol.io/vegacapsule/state"
+
+	"github.com/ethereum/go-ethereum/common"
+	vgtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// gas is not estimated for batched transactions, they depend on not yet mined transactions
+	fundApproveGasLimit = 100_000
+	fundDepositGasLimit = 200_000
+
+	fundBalancePollInterval = 2 * time.Second
+	generalAccountType      = "ACCOUNT_TYPE_GENERAL"
+)
+
+var errFundingFailed = errors.New("funding failed")
+
+var ethereumAssetFundFlags = struct {
+	partiesPath    string
+	dataNodeURL    string
+	balanceTimeout time.Duration
+}{}
+
+func init() {
+	ethereumAssetFundCmd.Flags().StringVar(&ethereumAssetFundFlags.partiesPath, "parties", "", "path to JSON file with Vega public keys and amounts of assets to be deposited to them")
+	ethereumAssetFundCmd.Flags().StringVar(&ethereumAssetFundFlags.dataNodeURL, "data-node-url", "", "URL of the data node REST API used to check the balances, defaults to the first data node of the network")
+	ethereumAssetFundCmd.Flags().DurationVar(&ethereumAssetFundFlags.balanceTimeout, "balance-timeout", 2*time.Minute, "how long to wait for the deposits to show up in the Vega balances, 0 skips the check")
+	ethereumAssetFundCmd.MarkFlagRequired("parties")
+}
+
+var ethereumAssetFundCmd = &cobra.Command{
+	Use:   "fund",
+	Short: "Fund allows to mint and deposit assets to many Vega public keys at once.",
+	Long: `Fund mints the assets, approves them and deposits them to the Vega public keys listed in the parties file.
+Transactions of a bridge are sent one after another with consecutive nonces and they are waited for together.
+When all transactions are mined the Vega balances of the parties are checked through the data node.
+
+The parties file is a list of Vega public keys with amounts of assets, the bridge defaults to primary:
+
+[
+  {
+    "pub_key": "321b470cddc840854fb33b34674683cae3201c7b3eceffa927f663549f17484f",
+    "assets": [
+      {"symbol": "tUSDC", "amount": "100000000000"},
+      {"symbol": "tDAI", "amount": "5000000000", "bridge": "secondary"}
+    ]
+  }
+]`,
+	Example: `vegacapsule ethereum asset fund --parties parties.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return ethereumAssetFund(cmd.Context(), ethereumAssetFundFlags.partiesPath, ethereumAssetFundFlags.dataNodeURL, ethereumAssetFundFlags.balanceTimeout)
+	},
+}
+
+type fundParty struct {
+	PubKey string      `json:"pub_key"`
+	Assets []fundAsset `json:"assets"`
+}
+
+type fundAsset struct {
+	Symbol string `json:"symbol"`
+	Amount string `json:"amount"`
+	Bridge string `json:"bridge,omitempty"`
+}
+
+// fundDeposit is a single deposit of an asset to a Vega public key.
+type fundDeposit struct {
+	pubKey       string
+	pubKeyBytes  [32]byte
+	symbol       string
+	bridge       string
+	amount       *big.Int
+	assetAddress common.Address
+	vegaAssetID  string
+
+	tx  *vgtypes.Transaction
+	err error
+}
+
+func ethereumAssetFund(ctx context.Context, partiesPath, dataNodeURL string, balanceTimeout time.Duration) error {
+	netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
+	if err != nil {
+		return err
+	}
+
+	if netState.Empty() {
+		return networkNotBootstrappedErr("ethereum asset fund")
+	}
+
+	if !netState.Running() {
+		return networkNotRunningErr("ethereum asset fund")
+	}
+
+	deposits, err := readFundParties(*netState.Config, partiesPath)
+	if err != nil {
+		return err
+	}
+
+	if dataNodeURL == "" && balanceTimeout > 0 {
+		dataNodeURL, err = ports.DataNodeRESTURL(netState.GeneratedServices.NodeSets.ToSlice())
+		if err != nil {
+			return err
+		}
+	}
+
+	var initialBalances map[fundBalanceKey]*big.Int
+	if balanceTimeout > 0 {
+		if initialBalances, err = vegaBalances(ctx, dataNodeURL, deposits); err != nil {
+			return err
+		}
+	}
+
+	byBridge := map[string][]*fundDeposit{}
+	for _, d := range deposits {
+		byBridge[d.bridge] = append(byBridge[d.bridge], d)
+	}
+
+	var eg errgroup.Group
+	for bridge, bridgeDeposits := range byBridge {
+		bridge, bridgeDeposits := bridge, bridgeDeposits
+		eg.Go(func() error {
+			return fundBridge(ctx, *netState.Config, bridge, bridgeDeposits)
+		})
+	}
+	bridgesErr := eg.Wait()
+
+	failed := printFundDeposits(deposits)
+
+	if bridgesErr != nil {
+		return bridgesErr
+	}
+
+	if balanceTimeout > 0 {
+		funded, err := waitVegaBalances(ctx, dataNodeURL, deposits, initialBalances, balanceTimeout)
+		if err != nil {
+			return err
+		}
+		if !funded {
+			return fmt.Errorf("%w: some deposits have not been credited on Vega", errFundingFailed)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d deposits failed", errFundingFailed, failed)
+	}
+
+	return nil
+}
+
+// readFundParties reads the parties file and resolves assets of the deposits.
+func readFundParties(conf config.Config, path string) ([]*fundDeposit, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read parties file %q: %w", path, err)
+	}
+
+	var parties []fundParty
+	if err := json.Unmarshal(content, &parties); err != nil {
+		return nil, fmt.Errorf("failed to parse parties file %q: %w", path, err)
+	}
+
+	deposits := []*fundDeposit{}
+	for _, p := range parties {
+		pubKeyBytes, err := vgethereum.HexStringToByte32Array(p.PubKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert Vega pub key %q to byte array: %w", p.PubKey, err)
+		}
+
+		for _, a := range p.Assets {
+			asset, err := getSmartContractToken(conf, a.Symbol)
+			if err != nil {
+				return nil, err
+			}
+
+			amount, ok := new(big.Int).SetString(a.Amount, 10)
+			if !ok || amount.Sign() <= 0 {
+				return nil, fmt.Errorf("amount %q of asset %q for %q must be a positive integer", a.Amount, a.Symbol, p.PubKey)
+			}
+
+			bridge := a.Bridge
+			if bridge == "" {
+				bridge = "primary"
+			}
+
+			deposits = append(deposits, &fundDeposit{
+				pubKey:       p.PubKey,
+				pubKeyBytes:  pubKeyBytes,
+				symbol:       a.Symbol,
+				bridge:       bridge,
+				amount:       amount,
+				assetAddress: common.HexToAddress(asset.EthereumAddress),
+				vegaAssetID:  strings.TrimPrefix(asset.VegaAddress, "0x"),
+			})
+		}
+	}
+
+	return deposits, nil
+}
+
+// fundBridge mints and approves total amounts of the assets and deposits them to the parties.
+// All transactions are sent by the smart contracts owner with consecutive nonces, so they are mined
+// in order without waiting for each other. Failed deposits are recorded in the deposits.
+func fundBridge(ctx context.Context, conf config.Config, bridge string, deposits []*fundDeposit) error {
+	networkAddress, smartContracts, err := bridgeSmartContracts(conf, bridge)
+	if err != nil {
+		return err
+	}
+
+	client, err := vgethereum.NewClient(ctx, networkAddress)
+	if err != nil {
+		return fmt.Errorf("failed to create Ethereum client for %s bridge: %w", bridge, err)
+	}
+
+	syncTimeout := defeaultSyncTimeout()
+	bridgeAddr := common.HexToAddress(smartContracts.ERC20Bridge.EthereumAddress)
+
+	bridgeSession, err := client.NewERC20BridgeSession(ctx, smartContracts.EthereumOwner.Private, bridgeAddr, &syncTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to create erc20 bridge session for %s: %w", bridgeAddr, err)
+	}
+
+	owner := bridgeSession.TransactOpts.From
+
+	totals := map[string]*big.Int{}
+	tokens := map[string]*vgethereum.BaseTokenSession{}
+	for _, d := range deposits {
+		if _, ok := totals[d.symbol]; !ok {
+			tokenSession, err := client.NewBaseTokenSession(ctx, smartContracts.EthereumOwner.Private, d.assetAddress, &syncTimeout)
+			if err != nil {
+				return fmt.Errorf("failed to create token session for %s: %w", d.assetAddress, err)
+			}
+
+			tokens[d.symbol] = tokenSession
+			totals[d.symbol] = new(big.Int)
+		}
+		totals[d.symbol].Add(totals[d.symbol], d.amount)
+	}
+
+	symbols := make([]string, 0, len(totals))
+	for symbol := range totals {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	nonces, err := client.NewNonces(ctx, owner)
+	if err != nil {
+		return err
+	}
+
+	mints := make([]*vgtypes.Transaction, len(symbols))
+	for i, symbol := range symbols {
+		if mints[i], err = tokens[symbol].Batched(nonces.Next(), 0).MintRaw(owner, totals[symbol]); err != nil {
+			return fmt.Errorf("failed to mint %s on %s bridge: %w", symbol, bridge, err)
+		}
+	}
+
+	approvals := make([]*vgtypes.Transaction, len(symbols))
+	for i, symbol := range symbols {
+		if approvals[i], err = tokens[symbol].Batched(nonces.Next(), fundApproveGasLimit).Approve(bridgeAddr, totals[symbol]); err != nil {
+			return fmt.Errorf("failed to approve %s on %s bridge: %w", symbol, bridge, err)
+		}
+	}
+
+	for _, d := range deposits {
+		batched := bridgeSession.Batched(nonces.Next(), fundDepositGasLimit)
+		if d.tx, err = batched.DepositAsset(d.assetAddress, d.amount, d.pubKeyBytes); err != nil {
+			return fmt.Errorf("failed to deposit %s to %s on %s bridge: %w", d.symbol, d.pubKey, bridge, err)
+		}
+	}
+
+	var eg errgroup.Group
+	for i, symbol := range symbols {
+		i, symbol := i, symbol
+		eg.Go(func() error {
+			waitCtx, cancel := context.WithTimeout(ctx, syncTimeout)
+			defer cancel()
+
+			minted, err := tokens[symbol].GetLastTransferValueSync(waitCtx, mints[i])
+			if err != nil {
+				return fmt.Errorf("failed to mint %s on %s bridge: %w", symbol, bridge, err)
+			}
+			if minted.Cmp(totals[symbol]) < 0 {
+				return fmt.Errorf("failed to mint %s on %s bridge: minted %s of %s", symbol, bridge, minted, totals[symbol])
+			}
+
+			if _, err := client.WaitMined(ctx, approvals[i], syncTimeout); err != nil {
+				return fmt.Errorf("failed to approve %s on %s bridge: %w", symbol, bridge, err)
+			}
+
+			return nil
+		})
+	}
+	for _, d := range deposits {
+		d := d
+		eg.Go(func() error {
+			_, d.err = client.WaitMined(ctx, d.tx, syncTimeout)
+			return nil
+		})
+	}
+
+	return eg.Wait()
+}
+
+func printFundDeposits(deposits []*fundDeposit) int {
+	failed := 0
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "PUB KEY\tASSET\tAMOUNT\tBRIDGE\tTX\tRESULT")
+	for _, d := range deposits {
+		tx, result := "-", "ok"
+		if d.tx != nil {
+			tx = d.tx.Hash().String()
+		}
+		switch {
+		case d.err != nil:
+			result = d.err.Error()
+			failed++
+		case d.tx == nil:
+			result = "not sent"
+			failed++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", d.pubKey, d.symbol, d.amount, d.bridge, tx, result)
+	}
+	w.Flush()
+
+	return failed
+}
+
+type fundBalanceKey struct {
+	pubKey      string
+	vegaAssetID string
+}
+
+// vegaBalances returns general account balances of the parties in the assets of the deposits.
+func vegaBalances(ctx context.Context, dataNodeURL string, deposits []*fundDeposit) (map[fundBalanceKey]*big.Int, error) {
+	balances := map[fundBalanceKey]*big.Int{}
+	for _, d := range deposits {
+		key := fundBalanceKey{pubKey: d.pubKey, vegaAssetID: d.vegaAssetID}
+		if _, ok := balances[key]; ok {
+			continue
+		}
+
+		balance, err := vegaGeneralBalance(ctx, dataNodeURL, d.pubKey, d.vegaAssetID)
+		if err != nil {
+			return nil, err
+		}
+		balances[key] = balance
+	}
+
+	return balances, nil
+}
+
+// waitVegaBalances waits until successful deposits are credited to the general accounts of the parties and prints the balances.
+func waitVegaBalances(
+	ctx context.Context,
+	dataNodeURL string,
+	deposits []*fundDeposit,
+	initial map[fundBalanceKey]*big.Int,
+	timeout time.Duration,
+) (bool, error) {
+	expected := map[fundBalanceKey]*big.Int{}
+	for _, d := range deposits {
+		key := fundBalanceKey{pubKey: d.pubKey, vegaAssetID: d.vegaAssetID}
+		if _, ok := expected[key]; !ok {
+			expected[key] = new(big.Int).Set(initial[key])
+		}
+		if d.tx != nil && d.err == nil {
+			expected[key].Add(expected[key], d.amount)
+		}
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		balances, err := vegaBalances(ctx, dataNodeURL, deposits)
+		if err != nil {
+			return false, err
+		}
+
+		funded := true
+		for key, balance := range balances {
+			if balance.Cmp(expected[key]) < 0 {
+				funded = false
+			}
+		}
+
+		if funded || time.Now().After(deadline) {
+			printVegaBalances(balances, expected)
+			return funded, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-time.After(fundBalancePollInterval):
+		}
+	}
+}
+
+func printVegaBalances(balances, expected map[fundBalanceKey]*big.Int) {
+	keys := make([]fundBalanceKey, 0, len(balances))
+	for key := range balances {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].pubKey == keys[j].pubKey {
+			return keys[i].vegaAssetID < keys[j].vegaAssetID
+		}
+		return keys[i].pubKey < keys[j].pubKey
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "PUB KEY\tVEGA ASSET\tBALANCE\tEXPECTED\tFUNDED")
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\n", key.pubKey, key.vegaAssetID, balances[key], expected[key], balances[key].Cmp(expected[key]) >= 0)
+	}
+	w.Flush()
+}
+
+type accountsResponse struct {
+	Accounts struct {
+		Edges []struct {
+			Node struct {
+				Balance string `json:"balance"`
+				Type    string `json:"type"`
+			} `json:"node"`
+		} `json:"edges"`
+	} `json:"accounts"`
+}
+
+// vegaGeneralBalance returns balance of the general account of the party in the asset from the data node REST API.
+func vegaGeneralBalance(ctx context.Context, dataNodeURL, pubKey, assetID string) (*big.Int, error) {
+	url := fmt.Sprintf("%s/api/v2/accounts?filter.partyIds=%s&filter.assetId=%s", strings.TrimSuffix(dataNodeURL, "/"), pubKey, assetID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts of %s: %w", pubKey, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get accounts of %s: unexpected status code %d from %q", pubKey, resp.StatusCode, url)
+	}
+
+	var accounts accountsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&accounts); err != nil {
+		return nil, fmt.Errorf("failed to decode accounts of %s: %w", pubKey, err)
+	}
+
+	balance := new(big.Int)
+	for _, edge := range accounts.Accounts.Edges {
+		if edge.Node.Type != generalAccountType {
+			continue
+		}
+
+		b, ok := new(big.Int).SetString(edge.Node.Balance, 10)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse balance %q of %s", edge.Node.Balance, pubKey)
+		}
+		balance.Add(balance, b)
+	}
+
+	return balance, nil
+}
@@ -7,9 +7,9 @@ import (
 
 	vgethereum "code.vegaprotocol.io/vegacapsule/libs/ethereum"
 	"code.vegaprotocol.io/vegacapsule/state"
-	"code.vegaprotocol.io/vegacapsule/types"
 
 	"github.com/ethereum/go-ethereum/common"
+	vgtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/spf13/cobra"
 )
 
@@ -42,61 +42,65 @@ var ethereumAssetMintCmd = &cobra.Command{
 	Use:   "mint",
 	Short: "Mint allows an asset to be minted by a Base Faucet Token contract.",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		netState, err := state.LoadNetworkState(homePath)
+		mintArgs, err := newEthereumAssetMintArgs(
+			ethereumAssetMintFlags.assetSymbol,
+			ethereumAssetMintFlags.toAddress,
+			ethereumAssetMintFlags.amount,
+			ethereumAssetMintFlags.bridge,
+		)
 		if err != nil {
 			return err
 		}
 
-		if netState.Empty() {
-			return networkNotBootstrappedErr("ethereum asset mint")
+		tx, err := ethereumAssetMint(cmd.Context(), *mintArgs)
+		if err != nil {
+			return err
 		}
 
-		if !netState.Running() {
-			return networkNotRunningErr("ethereum asset mint")
-		}
+		return printEthereumTx(tx)
+	},
+}
 
-		conf := netState.Config
+// newEthereumAssetMintArgs resolves the asset and the bridge of a running network.
+func newEthereumAssetMintArgs(assetSymbol, toAddress string, amount int64, bridge string) (*ethereumAssetMintArgs, error) {
+	netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
+	if err != nil {
+		return nil, err
+	}
 
-		asset := conf.GetSmartContractToken(ethereumAssetMintFlags.assetSymbol)
-		if asset == nil {
-			return fmt.Errorf("failed to get non existing asset: %q", ethereumAssetMintFlags.assetSymbol)
-		}
+	if netState.Empty() {
+		return nil, networkNotBootstrappedErr("ethereum asset mint")
+	}
 
-		var (
-			networkAddress string
-			smartContracts *types.SmartContractsInfo
-		)
-		switch ethereumAssetMintFlags.bridge {
-		case "primary":
-			networkAddress = conf.Network.Ethereum.Endpoint
-			smartContracts, err = conf.PrimarySmartContractsInfo()
-			if err != nil {
-				return fmt.Errorf("failed getting primary smart contract informations: %w", err)
-			}
-		case "secondary":
-			networkAddress = conf.Network.SecondaryEthereum.Endpoint
-			smartContracts, err = conf.SecondarySmartContractsInfo()
-			if err != nil {
-				return fmt.Errorf("failed getting secondary smart contract informations: %w", err)
-			}
-		}
+	if !netState.Running() {
+		return nil, networkNotRunningErr("ethereum asset mint")
+	}
 
-		mintArgs := ethereumAssetMintArgs{
-			amount:          ethereumAssetMintFlags.amount,
-			ownerPrivateKey: smartContracts.EthereumOwner.Private,
-			toAddress:       ethereumAssetMintFlags.toAddress,
-			assetAddress:    asset.EthereumAddress,
-			networkAddress:  networkAddress,
-		}
+	conf := netState.Config
 
-		return ethereumAssetMint(cmd.Context(), mintArgs)
-	},
+	asset, err := getSmartContractToken(*conf, assetSymbol)
+	if err != nil {
+		return nil, err
+	}
+
+	networkAddress, smartContracts, err := bridgeSmartContracts(*conf, bridge)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ethereumAssetMintArgs{
+		amount:          amount,
+		ownerPrivateKey: smartContracts.EthereumOwner.Private,
+		toAddress:       toAddress,
+		assetAddress:    asset.EthereumAddress,
+		networkAddress:  networkAddress,
+	}, nil
 }
 
-func ethereumAssetMint(ctx context.Context, args ethereumAssetMintArgs) error {
+func ethereumAssetMint(ctx context.Context, args ethereumAssetMintArgs) (*vgtypes.Transaction, error) {
 	client, err := vgethereum.NewClient(ctx, args.networkAddress)
 	if err != nil {
-		return fmt.Errorf("failed to create Ethereum client: %w", err)
+		return nil, fmt.Errorf("failed to create Ethereum client: %w", err)
 	}
 
 	syncTimeout := defeaultSyncTimeout()
@@ -108,7 +112,7 @@ func ethereumAssetMint(ctx context.Context, args ethereumAssetMintArgs) error {
 		&syncTimeout,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to create base token session for %s: %w", args.assetAddress, err)
+		return nil, fmt.Errorf("failed to create base token session for %s: %w", args.assetAddress, err)
 	}
 
 	tx, err := tokenSession.MintSync(
@@ -116,8 +120,8 @@ func ethereumAssetMint(ctx context.Context, args ethereumAssetMintArgs) error {
 		big.NewInt(args.amount),
 	)
 	if err != nil {
-		return fmt.Errorf("failed to mint token: %w", err)
+		return nil, fmt.Errorf("failed to mint token: %w", err)
 	}
 
-	return printEthereumTx(tx)
+	return tx, nil
 }
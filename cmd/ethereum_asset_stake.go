@@ -9,6 +9,7 @@ import (
 	"code.vegaprotocol.io/vegacapsule/state"
 
 	"github.com/ethereum/go-ethereum/common"
+	vgtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/spf13/cobra"
 )
 
@@ -31,48 +32,69 @@ var ethereumAssetStakeCmd = &cobra.Command{
 	Use:   "stake",
 	Short: "Stake allows an asset to be staked to a given Vega public key.",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		netState, err := state.LoadNetworkState(homePath)
+		stakeArgs, err := newEthereumAssetStakeArgs(
+			ethereumAssetStakeFlags.assetSymbol,
+			ethereumAssetStakeFlags.vegaPubKey,
+			ethereumAssetStakeFlags.amount,
+		)
 		if err != nil {
 			return err
 		}
 
-		if netState.Empty() {
-			return networkNotBootstrappedErr("ethereum asset stake")
+		tx, err := ethereumAssetStake(cmd.Context(), *stakeArgs)
+		if err != nil {
+			return err
 		}
 
-		if !netState.Running() {
-			return networkNotRunningErr("ethereum asset stake")
-		}
+		return printEthereumTx(tx)
+	},
+}
 
-		conf := netState.Config
+// newEthereumAssetStakeArgs resolves the asset and the staking bridge of a running network.
+func newEthereumAssetStakeArgs(assetSymbol, vegaPubKey string, amount int64) (*ethereumAssetDepositOrStakeArgs, error) {
+	return newStakingBridgeArgs("ethereum asset stake", assetSymbol, vegaPubKey, amount)
+}
 
-		smartContracts, err := conf.PrimarySmartContractsInfo()
-		if err != nil {
-			return fmt.Errorf("failed getting smart contract informations: %w", err)
-		}
+func newStakingBridgeArgs(cmdName, assetSymbol, vegaPubKey string, amount int64) (*ethereumAssetDepositOrStakeArgs, error) {
+	netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
+	if err != nil {
+		return nil, err
+	}
 
-		asset := conf.GetSmartContractToken(ethereumAssetStakeFlags.assetSymbol)
-		if asset == nil {
-			return fmt.Errorf("failed to get non existing asset: %q", ethereumAssetStakeFlags.assetSymbol)
-		}
+	if netState.Empty() {
+		return nil, networkNotBootstrappedErr(cmdName)
+	}
 
-		stakeArgs := ethereumAssetDepositOrStakeArgs{
-			amount:          ethereumAssetStakeFlags.amount,
-			vegaPubKey:      ethereumAssetStakeFlags.vegaPubKey,
-			ownerPrivateKey: smartContracts.EthereumOwner.Private,
-			bridgeAddress:   smartContracts.StakingBridge.EthereumAddress,
-			assetAddress:    asset.EthereumAddress,
-			networkAddress:  conf.Network.Ethereum.Endpoint,
-		}
+	if !netState.Running() {
+		return nil, networkNotRunningErr(cmdName)
+	}
 
-		return ethereumAssetStake(cmd.Context(), stakeArgs)
-	},
+	conf := netState.Config
+
+	smartContracts, err := conf.PrimarySmartContractsInfo()
+	if err != nil {
+		return nil, fmt.Errorf("failed getting smart contract informations: %w", err)
+	}
+
+	asset, err := getSmartContractToken(*conf, assetSymbol)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ethereumAssetDepositOrStakeArgs{
+		amount:          amount,
+		vegaPubKey:      vegaPubKey,
+		ownerPrivateKey: smartContracts.EthereumOwner.Private,
+		bridgeAddress:   smartContracts.StakingBridge.EthereumAddress,
+		assetAddress:    asset.EthereumAddress,
+		networkAddress:  conf.Network.Ethereum.Endpoint,
+	}, nil
 }
 
-func ethereumAssetStake(ctx context.Context, args ethereumAssetDepositOrStakeArgs) error {
+func ethereumAssetStake(ctx context.Context, args ethereumAssetDepositOrStakeArgs) (*vgtypes.Transaction, error) {
 	client, err := vgethereum.NewClient(ctx, args.networkAddress)
 	if err != nil {
-		return fmt.Errorf("failed to create Ethereum client: %w", err)
+		return nil, fmt.Errorf("failed to create Ethereum client: %w", err)
 	}
 
 	syncTimeout := defeaultSyncTimeout()
@@ -85,7 +107,7 @@ func ethereumAssetStake(ctx context.Context, args ethereumAssetDepositOrStakeArg
 		&syncTimeout,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to create staking bridge session for %s: %w", args.bridgeAddress, err)
+		return nil, fmt.Errorf("failed to create staking bridge session for %s: %w", args.bridgeAddress, err)
 	}
 
 	tokenSession, err := client.NewBaseTokenSession(
@@ -95,18 +117,18 @@ func ethereumAssetStake(ctx context.Context, args ethereumAssetDepositOrStakeArg
 		&syncTimeout,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to create token session for %s: %w", args.assetAddress, err)
+		return nil, fmt.Errorf("failed to create token session for %s: %w", args.assetAddress, err)
 	}
 
 	amount := big.NewInt(args.amount)
 
 	if _, err := tokenSession.ApproveSync(bridgeAddr, amount); err != nil {
-		return fmt.Errorf("failef to approve asset amount to bridge: %w", err)
+		return nil, fmt.Errorf("failef to approve asset amount to bridge: %w", err)
 	}
 
 	vegaPubKeyArr, err := vgethereum.HexStringToByte32Array(args.vegaPubKey)
 	if err != nil {
-		return fmt.Errorf("failed to convert Vega pub key string to byte array: %w", err)
+		return nil, fmt.Errorf("failed to convert Vega pub key string to byte array: %w", err)
 	}
 
 	tx, err := bridgeSession.Stake(
@@ -114,8 +136,8 @@ func ethereumAssetStake(ctx context.Context, args ethereumAssetDepositOrStakeArg
 		vegaPubKeyArr,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to stake asset: %w", err)
+		return nil, fmt.Errorf("failed to stake asset: %w", err)
 	}
 
-	return printEthereumTx(tx)
+	return tx, nil
 }
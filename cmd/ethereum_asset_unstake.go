@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	vgethereum "code.vegaprotocol.io/vegacapsule/libs/ethereum"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var ethereumAssetUnstakeFlags = struct {
+	vegaPubKey  string
+	assetSymbol string
+	amount      int64
+}{}
+
+func init() {
+	ethereumAssetUnstakeCmd.Flags().StringVar(&ethereumAssetUnstakeFlags.assetSymbol, "asset-symbol", "", "symbol of the asset to be unstaked")
+	ethereumAssetUnstakeCmd.Flags().StringVar(&ethereumAssetUnstakeFlags.vegaPubKey, "pub-key", "", "Vega public key from where the asset will be unstaked")
+	ethereumAssetUnstakeCmd.Flags().Int64Var(&ethereumAssetUnstakeFlags.amount, "amount", 0, "amount to be unstaked")
+	ethereumAssetUnstakeCmd.MarkFlagRequired("asset-symbol")
+	ethereumAssetUnstakeCmd.MarkFlagRequired("pub-key")
+	ethereumAssetUnstakeCmd.MarkFlagRequired("amount")
+}
+
+var ethereumAssetUnstakeCmd = &cobra.Command{
+	Use:   "unstake",
+	Short: "Unstake allows to remove stake of an asset from a given Vega public key.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		unstakeArgs, err := newStakingBridgeArgs(
+			"ethereum asset unstake",
+			ethereumAssetUnstakeFlags.assetSymbol,
+			ethereumAssetUnstakeFlags.vegaPubKey,
+			ethereumAssetUnstakeFlags.amount,
+		)
+		if err != nil {
+			return err
+		}
+
+		return ethereumAssetUnstake(cmd.Context(), *unstakeArgs)
+	},
+}
+
+func ethereumAssetUnstake(ctx context.Context, args ethereumAssetDepositOrStakeArgs) error {
+	client, err := vgethereum.NewClient(ctx, args.networkAddress)
+	if err != nil {
+		return fmt.Errorf("failed to create Ethereum client: %w", err)
+	}
+
+	syncTimeout := defeaultSyncTimeout()
+
+	bridgeSession, err := client.NewStakingBridgeSession(
+		ctx,
+		args.ownerPrivateKey,
+		common.HexToAddress(args.bridgeAddress),
+		&syncTimeout,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create staking bridge session for %s: %w", args.bridgeAddress, err)
+	}
+
+	tokenSession, err := client.NewBaseTokenSession(
+		ctx,
+		args.ownerPrivateKey,
+		common.HexToAddress(args.assetAddress),
+		&syncTimeout,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create token session for %s: %w", args.assetAddress, err)
+	}
+
+	vegaPubKeyArr, err := vgethereum.HexStringToByte32Array(args.vegaPubKey)
+	if err != nil {
+		return fmt.Errorf("failed to convert Vega pub key string to byte array: %w", err)
+	}
+
+	receipt, err := bridgeSession.RemoveStakeSync(big.NewInt(args.amount), vegaPubKeyArr)
+	if err != nil {
+		return fmt.Errorf("failed to unstake asset: %w", err)
+	}
+
+	fmt.Printf("Transaction %s mined in block %s\n", receipt.TxHash, receipt.BlockNumber)
+
+	staker := tokenSession.CallOpts.From
+	balance, err := tokenSession.BalanceOf(staker)
+	if err != nil {
+		return fmt.Errorf("failed to get balance of %s: %w", staker, err)
+	}
+
+	printEthereumBalance(staker, balance)
+
+	return nil
+}
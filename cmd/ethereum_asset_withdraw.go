@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	vgethereum "code.vegaprotocol.io/vegacapsule/libs/ethereum"
+	"code.vegaprotocol.io/vegacapsule/ports"
+	"code.vegaprotocol.io/vegacapsule/state"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+)
+
+var ethereumAssetWithdrawFlags = struct {
+	withdrawalID string
+	dataNodeURL  string
+	bridge       string
+}{}
+
+func init() {
+	ethereumAssetWithdrawCmd.Flags().StringVar(&ethereumAssetWithdrawFlags.withdrawalID, "withdrawal-id", "", "ID of the Vega withdrawal to be completed")
+	ethereumAssetWithdrawCmd.Flags().StringVar(&ethereumAssetWithdrawFlags.dataNodeURL, "data-node-url", "", "URL of the data node REST API the withdrawal bundle is fetched from, defaults to the first data node of the network")
+	ethereumAssetWithdrawCmd.Flags().StringVar(&ethereumAssetWithdrawFlags.bridge, "bridge", "primary", "bridge linked to the withdrawal")
+	ethereumAssetWithdrawCmd.MarkFlagRequired("withdrawal-id")
+}
+
+var ethereumAssetWithdrawCmd = &cobra.Command{
+	Use:   "withdraw",
+	Short: "Withdraw allows to complete a Vega withdrawal by submitting its bundle to the ERC20 bridge.",
+	Example: `# Fetch the bundle of the withdrawal from a data node and submit it
+vegacapsule ethereum asset withdraw --withdrawal-id 2ad4f31a...`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		withdrawArgs, err := newEthereumAssetWithdrawArgs(
+			ethereumAssetWithdrawFlags.dataNodeURL,
+			ethereumAssetWithdrawFlags.bridge,
+		)
+		if err != nil {
+			return err
+		}
+
+		return ethereumAssetWithdraw(cmd.Context(), ethereumAssetWithdrawFlags.withdrawalID, *withdrawArgs)
+	},
+}
+
+type ethereumAssetWithdrawArgs struct {
+	ownerPrivateKey string
+	bridgeAddress   string
+	networkAddress  string
+	dataNodeURL     string
+}
+
+// newEthereumAssetWithdrawArgs resolves the bridge and the data node of a running network.
+func newEthereumAssetWithdrawArgs(dataNodeURL, bridge string) (*ethereumAssetWithdrawArgs, error) {
+	netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if netState.Empty() {
+		return nil, networkNotBootstrappedErr("ethereum asset withdraw")
+	}
+
+	if !netState.Running() {
+		return nil, networkNotRunningErr("ethereum asset withdraw")
+	}
+
+	networkAddress, smartContracts, err := bridgeSmartContracts(*netState.Config, bridge)
+	if err != nil {
+		return nil, err
+	}
+
+	if dataNodeURL == "" {
+		dataNodeURL, err = ports.DataNodeRESTURL(netState.GeneratedServices.NodeSets.ToSlice())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &ethereumAssetWithdrawArgs{
+		ownerPrivateKey: smartContracts.EthereumOwner.Private,
+		bridgeAddress:   smartContracts.ERC20Bridge.EthereumAddress,
+		networkAddress:  networkAddress,
+		dataNodeURL:     dataNodeURL,
+	}, nil
+}
+
+func ethereumAssetWithdraw(ctx context.Context, withdrawalID string, args ethereumAssetWithdrawArgs) error {
+	approval, err := vgethereum.FetchWithdrawalApproval(ctx, args.dataNodeURL, withdrawalID)
+	if err != nil {
+		return err
+	}
+
+	client, err := vgethereum.NewClient(ctx, args.networkAddress)
+	if err != nil {
+		return fmt.Errorf("failed to create Ethereum client: %w", err)
+	}
+
+	syncTimeout := defeaultSyncTimeout()
+
+	bridgeSession, err := client.NewERC20BridgeSession(
+		ctx,
+		args.ownerPrivateKey,
+		common.HexToAddress(args.bridgeAddress),
+		&syncTimeout,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create erc20 bridge session for %s: %w", args.bridgeAddress, err)
+	}
+
+	tokenSession, err := client.NewBaseTokenSession(ctx, args.ownerPrivateKey, approval.AssetSource, &syncTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to create token session for %s: %w", approval.AssetSource, err)
+	}
+
+	receipt, err := bridgeSession.WithdrawAssetSync(
+		approval.AssetSource,
+		approval.Amount,
+		approval.TargetAddress,
+		approval.Creation,
+		approval.Nonce,
+		approval.Signatures,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to withdraw asset: %w", err)
+	}
+
+	fmt.Printf("Transaction %s mined in block %s\n", receipt.TxHash, receipt.BlockNumber)
+
+	balance, err := tokenSession.BalanceOf(approval.TargetAddress)
+	if err != nil {
+		return fmt.Errorf("failed to get balance of %s: %w", approval.TargetAddress, err)
+	}
+
+	printEthereumBalance(approval.TargetAddress, balance)
+
+	return nil
+}
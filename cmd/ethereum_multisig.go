@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/ethereum"
+	"code.vegaprotocol.io/vegacapsule/state"
+	"code.vegaprotocol.io/vegacapsule/types"
+	"code.vegaprotocol.io/vegacapsule/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	updateMultisig    bool
+	multisigThreshold int
+)
+
+var ethereumMultisigStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Shows signers of the multisig smart contract compared to the validators",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
+		if err != nil {
+			return err
+		}
+
+		if netState.Empty() {
+			return networkNotBootstrappedErr("ethereum multisig status")
+		}
+
+		if !netState.Running() {
+			return networkNotRunningErr("ethereum multisig status")
+		}
+
+		ctx := context.Background()
+
+		validators := netState.GeneratedServices.ListValidators()
+		sort.Slice(validators, func(i, j int) bool { return validators[i].NomadJobName < validators[j].NomadJobName })
+
+		bridges, err := newMultisigBridges(ctx, *netState)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		for _, b := range bridges {
+			status, err := b.client.MultisigStatus(ctx, getSigners(validators))
+			if err != nil {
+				return fmt.Errorf("failed to get %s multisig status: %w", b.name, err)
+			}
+
+			fmt.Fprintf(w, "%s bridge: %d signers, threshold %d\n", b.name, status.SignerCount, status.Threshold)
+			fmt.Fprintln(w, "NODE SET\tETHEREUM ADDRESS\tSIGNER")
+			for i, v := range status.Validators {
+				fmt.Fprintf(w, "%s\t%s\t%t\n", validators[i].NomadJobName, v.Address, v.ValidSigner)
+			}
+			if status.UnknownSigners > 0 {
+				fmt.Fprintf(w, "%d signers are not validators of the network\n", status.UnknownSigners)
+			}
+			fmt.Fprintln(w)
+		}
+
+		return w.Flush()
+	},
+}
+
+func init() {
+	ethereumMultisigCmd.AddCommand(ethereumMultisigStatusCmd)
+}
+
+// addMultisigFlags adds flags of the commands, that optionally update signers of the multisig smart contract.
+func addMultisigFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().BoolVar(&updateMultisig,
+		"update-multisig",
+		false,
+		"Add or remove validators as signers of the multisig smart contract of both Ethereum bridges",
+	)
+	cmd.PersistentFlags().IntVar(&multisigThreshold,
+		"multisig-threshold",
+		ethereum.DefaultMultisigThreshold,
+		"Threshold of the multisig smart contract in thousandths of the signers, set when --update-multisig is used",
+	)
+}
+
+type multisigBridge struct {
+	name           string
+	client         *ethereum.EthereumMultisigClient
+	smartContracts types.SmartContractsInfo
+}
+
+// newMultisigBridges returns multisig clients of the primary and the secondary Ethereum bridge.
+func newMultisigBridges(ctx context.Context, netState state.NetworkState) ([]multisigBridge, error) {
+	primarySmartContracts, err := netState.Config.PrimarySmartContractsInfo()
+	if err != nil {
+		return nil, fmt.Errorf("failed getting primary smart contract informations: %w", err)
+	}
+
+	secondarySmartContracts, err := netState.Config.SecondarySmartContractsInfo()
+	if err != nil {
+		return nil, fmt.Errorf("failed getting secondary smart contract informations: %w", err)
+	}
+
+	bridges := []struct {
+		name           string
+		ethereum       config.EthereumConfig
+		smartContracts *types.SmartContractsInfo
+	}{
+		{name: "primary", ethereum: netState.Config.Network.Ethereum, smartContracts: primarySmartContracts},
+		{name: "secondary", ethereum: netState.Config.Network.SecondaryEthereum, smartContracts: secondarySmartContracts},
+	}
+
+	out := make([]multisigBridge, 0, len(bridges))
+	for _, b := range bridges {
+		chainID, err := strconv.Atoi(b.ethereum.ChainID)
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := ethereum.NewEthereumMultisigClient(ctx, ethereum.EthereumMultisigClientParameters{
+			VegaBinary: *netState.Config.VegaBinary,
+			VegaHome:   utils.VegaNodeHomePath(homePath, 0),
+
+			ChainID:            chainID,
+			EthereumAddress:    b.ethereum.Endpoint,
+			SmartContractsInfo: *b.smartContracts,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s ethereum client: %w", b.name, err)
+		}
+
+		out = append(out, multisigBridge{name: b.name, client: client, smartContracts: *b.smartContracts})
+	}
+
+	return out, nil
+}
+
+// updateMultisigSigners adds validators of the added node sets as signers of the multisig smart contracts
+// and removes validators of the removed node sets. Both the added and the removed node sets have to be in the network state.
+func updateMultisigSigners(ctx context.Context, netState state.NetworkState, added, removed []string) error {
+	validators := netState.GeneratedServices.ListValidators()
+
+	isValidator := func(name string) (*types.VegaNodeOutput, bool) {
+		for _, v := range validators {
+			if v.NomadJobName == name {
+				return &v, true
+			}
+		}
+		return nil, false
+	}
+
+	var current, add []types.VegaNodeOutput
+	var remove []string
+	for _, v := range validators {
+		if contains(added, v.NomadJobName) {
+			add = append(add, v)
+			continue
+		}
+		current = append(current, v)
+	}
+
+	for _, name := range removed {
+		if v, ok := isValidator(name); ok {
+			remove = append(remove, v.VegaNode.NodeWalletInfo.EthereumAddress)
+		}
+	}
+
+	if len(add) == 0 && len(remove) == 0 {
+		return nil
+	}
+
+	bridges, err := newMultisigBridges(ctx, netState)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range bridges {
+		if err := b.client.UpdateSigners(ctx, b.smartContracts, getSigners(add), remove, getSigners(current), multisigThreshold); err != nil {
+			return fmt.Errorf("failed to update signers of %s multisig smart contract: %w", b.name, err)
+		}
+	}
+
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
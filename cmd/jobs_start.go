@@ -5,8 +5,9 @@ import (
 	"fmt"
 	"log"
 
+	"code.vegaprotocol.io/vegacapsule/capsule"
 	"code.vegaprotocol.io/vegacapsule/config"
-	"code.vegaprotocol.io/vegacapsule/nomad"
+	"code.vegaprotocol.io/vegacapsule/jobrunner"
 	"code.vegaprotocol.io/vegacapsule/state"
 
 	"github.com/spf13/cobra"
@@ -15,8 +16,8 @@ import (
 var jobsStartCmd = &cobra.Command{
 	Use:   "start",
 	Short: "Start a specific job",
-	RunE: func(cmd *cobra.Command, args []string) error {
-		networkState, err := state.LoadNetworkState(homePath)
+	RunE: withNetworkLock(func(cmd *cobra.Command, args []string) error {
+		networkState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
 		if err != nil {
 			return fmt.Errorf("failed load network state: %w", err)
 		}
@@ -28,19 +29,11 @@ var jobsStartCmd = &cobra.Command{
 		// try if job name refers to node set
 		nodeSet, err := networkState.GeneratedServices.GetNodeSet(jobName)
 		if nodeSet != nil && err == nil {
-			nomadJobID, err := nodesStartNode(
-				context.Background(),
-				nodeSet,
-				networkState.Config,
-				vegaBinary,
-			)
-			if err != nil {
+			if err := newNetwork(networkState).StartNodeSet(context.Background(), jobName, capsule.WithVegaBinary(vegaBinary)); err != nil {
 				return fmt.Errorf("failed start job: %w", err)
 			}
 
-			networkState.RunningJobs.NodesSetsJobIDs[nomadJobID] = true
-
-			return networkState.Persist()
+			return nil
 		}
 
 		networkState, err = startJob(
@@ -54,7 +47,7 @@ var jobsStartCmd = &cobra.Command{
 		}
 
 		return networkState.Persist()
-	},
+	}),
 }
 
 func init() {
@@ -69,19 +62,14 @@ func init() {
 func startJob(ctx context.Context, state state.NetworkState, conf *config.Config, name string) (*state.NetworkState, error) {
 	log.Printf("starting %s node set", name)
 
-	nomadClient, err := nomad.NewClient(nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create nomad client: %w", err)
-	}
-
-	nomadRunner, err := nomad.NewJobRunner(nomadClient, *conf.VegaCapsuleBinary, conf.LogsDir())
+	runner, err := jobrunner.New(conf)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create job runner: %w", err)
+		return nil, err
 	}
 
 	if wallet := state.GeneratedServices.Wallet; wallet != nil && wallet.Name == name {
 		log.Printf("starting wallet %s", name)
-		jobID, err := nomadRunner.StartWallet(ctx, conf.Network.Wallet, wallet)
+		jobID, err := runner.StartWallet(ctx, conf.Network.Wallet, wallet)
 		if err != nil {
 			return nil, err
 		}
@@ -91,7 +79,7 @@ func startJob(ctx context.Context, state state.NetworkState, conf *config.Config
 
 	if faucet := state.GeneratedServices.Faucet; faucet != nil && faucet.Name == name {
 		log.Printf("starting faucet %s", name)
-		jobID, err := nomadRunner.StartFaucet(ctx, conf.Network.Faucet, faucet)
+		jobID, err := runner.StartFaucet(ctx, conf.Network.Faucet, faucet)
 		if err != nil {
 			return nil, err
 		}
@@ -105,7 +93,7 @@ func startJob(ctx context.Context, state state.NetworkState, conf *config.Config
 		}
 
 		log.Printf("starting job %s", name)
-		jobID, err := nomadRunner.RunExecJob(ctx, e)
+		jobID, err := runner.RunExecJob(ctx, e)
 		if err != nil {
 			return nil, err
 		}
@@ -119,7 +107,7 @@ func startJob(ctx context.Context, state state.NetworkState, conf *config.Config
 		}
 
 		log.Printf("starting job %s", name)
-		jobID, err := nomadRunner.RunDockerJob(ctx, d)
+		jobID, err := runner.RunDockerJob(ctx, d)
 		if err != nil {
 			return nil, err
 		}
@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"log"
 
-	"code.vegaprotocol.io/vegacapsule/nomad"
+	"code.vegaprotocol.io/vegacapsule/jobrunner"
 	"code.vegaprotocol.io/vegacapsule/state"
 
 	"github.com/spf13/cobra"
@@ -16,8 +16,8 @@ var jobName string
 var jobsStopCmd = &cobra.Command{
 	Use:   "stop",
 	Short: "Stop a specific job",
-	RunE: func(cmd *cobra.Command, args []string) error {
-		networkState, err := state.LoadNetworkState(homePath)
+	RunE: withNetworkLock(func(cmd *cobra.Command, args []string) error {
+		networkState, err := state.LoadNetworkState(homePath, stateLoadOptsWithoutSecrets()...)
 		if err != nil {
 			return fmt.Errorf("failed load network state: %w", err)
 		}
@@ -32,7 +32,7 @@ var jobsStopCmd = &cobra.Command{
 		}
 
 		return updatedNetworkState.Persist()
-	},
+	}),
 }
 
 func init() {
@@ -47,18 +47,13 @@ func init() {
 func stopJob(ctx context.Context, state state.NetworkState, name string) (*state.NetworkState, error) {
 	log.Printf("stopping %s job", name)
 
-	nomadClient, err := nomad.NewClient(nil)
+	runner, err := jobrunner.New(state.Config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create nomad client: %w", err)
-	}
-
-	nomadRunner, err := nomad.NewJobRunner(nomadClient, *state.Config.VegaCapsuleBinary, state.Config.LogsDir())
-	if err != nil {
-		return nil, fmt.Errorf("failed to create job runner: %w", err)
+		return nil, err
 	}
 
 	toRemove := []string{name}
-	stoppedJobs, err := nomadRunner.StopJobs(ctx, toRemove)
+	stoppedJobs, err := runner.StopJobs(ctx, toRemove)
 	if err != nil {
 		return nil, fmt.Errorf("failed to stop nomad job %q: %w", name, err)
 	}
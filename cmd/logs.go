@@ -13,7 +13,7 @@ var logsCmd = &cobra.Command{
 	Use:   "logs",
 	Short: "Tool for logs extracting",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		netState, err := state.LoadNetworkState(homePath)
+		netState, err := state.LoadNetworkState(homePath, stateLoadOptsWithoutSecrets()...)
 		if err != nil {
 			return err
 		}
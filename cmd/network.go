@@ -1,17 +1,39 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 
+	"code.vegaprotocol.io/vegacapsule/capsule"
+	"code.vegaprotocol.io/vegacapsule/state"
+
 	"github.com/spf13/cobra"
 )
 
+var (
+	errNetworkNotBootstrapped = errors.New("network not bootstrapped")
+	errNetworkNotRunning      = errors.New("network is not running")
+)
+
 func networkNotBootstrappedErr(cmd string) error {
-	return fmt.Errorf("failed to %s network: network not bootstrapped. Use the 'bootstrap' subcommand or provide different network home with the `--home-path` flag", cmd)
+	return fmt.Errorf("failed to %s network: %w. Use the 'bootstrap' subcommand or provide different network home with the `--home-path` flag", cmd, errNetworkNotBootstrapped)
 }
 
 func networkNotRunningErr(cmd string) error {
-	return fmt.Errorf("failed to %s network: network is not running. Use the 'start' subcommand or provide different network home with the `--home-path` flag", cmd)
+	return fmt.Errorf("failed to %s network: %w. Use the 'start' subcommand or provide different network home with the `--home-path` flag", cmd, errNetworkNotRunning)
+}
+
+// newNetwork returns the network with given state controlled as set by the command flags.
+func newNetwork(netState *state.NetworkState) *capsule.Network {
+	opts := []capsule.Option{capsule.WithName(networkName)}
+	if doNotStopAllJobsOnFailure {
+		opts = append(opts, capsule.WithoutStopOnFailure())
+	}
+	if skipWaitReady {
+		opts = append(opts, capsule.WithoutWaitReady())
+	}
+
+	return capsule.FromState(homePath, netState, opts...)
 }
 
 var networkCmd = &cobra.Command{
@@ -47,4 +69,14 @@ func init() {
 	networkCmd.AddCommand(netLogsCmd)
 	networkCmd.AddCommand(keysCmd)
 	networkCmd.AddCommand(netPrintPortsCmd)
+	networkCmd.AddCommand(netListCmd)
+	networkCmd.AddCommand(netUseCmd)
+	networkCmd.AddCommand(netExportCmd)
+	networkCmd.AddCommand(netExportComposeCmd)
+	networkCmd.AddCommand(netExportK8sCmd)
+	networkCmd.AddCommand(netImportCmd)
+	networkCmd.AddCommand(netPlanCmd)
+	networkCmd.AddCommand(netApplyCmd)
+	networkCmd.AddCommand(netWaitReadyCmd)
+	networkCmd.AddCommand(netStatusCmd)
 }
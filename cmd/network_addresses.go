@@ -4,12 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"sort"
 
-	"code.vegaprotocol.io/vegacapsule/nomad"
-	"code.vegaprotocol.io/vegacapsule/ports"
+	"code.vegaprotocol.io/vegacapsule/capsule"
 	"code.vegaprotocol.io/vegacapsule/state"
-	"code.vegaprotocol.io/vegacapsule/types"
 
 	"github.com/spf13/cobra"
 )
@@ -18,7 +15,7 @@ var netPrintPortsCmd = &cobra.Command{
 	Use:   "addresses",
 	Short: "Print all exposed addresses and ports per running network job to stdout",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		networkState, err := state.LoadNetworkState(homePath)
+		networkState, err := state.LoadNetworkState(homePath, stateLoadOptsWithoutSecrets()...)
 		if err != nil {
 			return fmt.Errorf("failed load network state: %w", err)
 		}
@@ -31,55 +28,26 @@ var netPrintPortsCmd = &cobra.Command{
 			return networkNotRunningErr("network addresses")
 		}
 
-		nomadClient, err := nomad.NewClient(nil)
-		if err != nil {
-			return fmt.Errorf("failed to create nomad client: %w", err)
-		}
-
-		nomadRunner, err := nomad.NewJobRunner(nomadClient, "", "")
-		if err != nil {
-			return fmt.Errorf("failed to create job runner: %w", err)
-		}
-
-		return printNetworkAddresses(cmd.Context(), nomadRunner, networkState.GeneratedServices)
+		return printNetworkAddresses(cmd.Context(), newNetwork(networkState))
 	},
 }
 
-func printNetworkAddresses(ctx context.Context, nomadRunner *nomad.JobRunner, genServices *types.GeneratedServices) error {
+func printNetworkAddresses(ctx context.Context, n *capsule.Network) error {
 	log.Println("printing exposed network addresses")
 
-	nomadExposedPorts, err := nomadRunner.ListExposedPorts(ctx)
-	if err != nil {
-		return err
-	}
-
-	allOpenPorts, err := ports.OpenPortsPerJob(ctx, nomadExposedPorts, genServices)
+	jobsPorts, err := n.Addresses(ctx)
 	if err != nil {
 		return err
 	}
 
-	keys := []ports.JobWithTask{}
-	for v := range allOpenPorts {
-		keys = append(keys, v)
-	}
-
-	sort.Slice(keys, func(i, j int) bool {
-		if keys[i].Name == keys[j].Name {
-			return keys[i].TaskName < keys[j].TaskName
-		}
-		return keys[i].Name < keys[j].Name
-	})
-
-	for _, job := range keys {
-		ports := allOpenPorts[job]
-
+	for _, job := range jobsPorts {
 		if job.TaskName != "" {
 			fmt.Printf("Job %q - %q\n", job.Name, job.TaskName)
 		} else {
 			fmt.Printf("Job %q\n", job.Name)
 		}
 
-		for _, port := range ports {
+		for _, port := range job.Ports {
 			if port.Name != "" {
 				fmt.Printf("  - %s: localhost:%d\n", port.Name, port.Port)
 			} else {
@@ -88,5 +56,13 @@ func printNetworkAddresses(ctx context.Context, nomadRunner *nomad.JobRunner, ge
 		}
 	}
 
+	if allocatedPorts := n.State().Ports.AllocatedPorts(); len(allocatedPorts) != 0 {
+		fmt.Println("Allocated ports")
+
+		for _, port := range allocatedPorts {
+			fmt.Printf("  - %s: localhost:%d\n", port.Name, port.Port)
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"code.vegaprotocol.io/vegacapsule/capsule"
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/generator"
+	"code.vegaprotocol.io/vegacapsule/jobrunner"
+	"code.vegaprotocol.io/vegacapsule/nomad"
+	"code.vegaprotocol.io/vegacapsule/plan"
+	"code.vegaprotocol.io/vegacapsule/state"
+	"code.vegaprotocol.io/vegacapsule/types"
+
+	"github.com/spf13/cobra"
+)
+
+var netApplyConfigPath string
+
+var netApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Update the generated network to a new configuration",
+	Long: `Update the generated network to a new configuration without destroying it.
+
+Only the changes listed by the 'network plan' command are made: node sets are added and removed
+the same way as with the 'nodes add' and 'nodes remove' commands, configs of node sets with changed
+templates are overwritten and the affected running node sets and services are restarted.`,
+	Example: `# Show and apply changes for the new configuration
+vegacapsule network apply --config-path new.hcl`,
+	RunE: withNetworkLock(func(cmd *cobra.Command, args []string) error {
+		netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
+		if err != nil {
+			return err
+		}
+
+		if netState.Empty() {
+			return networkNotBootstrappedErr("apply")
+		}
+
+		p, desired, err := netPlan(netState, netApplyConfigPath)
+		if err != nil {
+			return fmt.Errorf("failed to plan network changes: %w", err)
+		}
+
+		printPlan(*p)
+
+		if p.Empty() {
+			return nil
+		}
+
+		updatedNetState, err := netApply(cmd.Context(), *netState, *p, desired)
+		if err != nil {
+			// changes made until the failure are kept
+			if updatedNetState != nil {
+				if err := updatedNetState.Persist(); err != nil {
+					log.Printf("failed to persist network state: %s", err)
+				}
+			}
+
+			return fmt.Errorf("failed to apply network changes: %w", err)
+		}
+
+		return updatedNetState.Persist()
+	}),
+}
+
+func init() {
+	netApplyCmd.PersistentFlags().StringVar(&netApplyConfigPath,
+		"config-path",
+		"",
+		"Path to the new config file",
+	)
+	netApplyCmd.MarkPersistentFlagRequired("config-path") // nolint:errcheck
+}
+
+func netApply(ctx context.Context, netState state.NetworkState, p plan.Plan, desired *config.Config) (*state.NetworkState, error) {
+	log.Println("applying network changes")
+
+	runner, err := jobrunner.New(netState.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := capsule.ProtectOtherNetworksJobs(runner, homePath); err != nil {
+		return nil, fmt.Errorf("failed to load network registry: %w", err)
+	}
+
+	n := newNetwork(&netState)
+	running := netState.Running()
+	if netState.RunningJobs == nil {
+		netState.RunningJobs = &types.NetworkJobs{}
+	}
+
+	if servicesToStop := append(append([]string{}, p.StopServices...), p.RestartServices...); len(servicesToStop) > 0 {
+		stoppedJobs, err := runner.StopJobs(ctx, servicesToStop)
+		if err != nil {
+			return &netState, fmt.Errorf("failed to stop services: %w", err)
+		}
+		netState.RunningJobs.RemoveRunningJobsIDs(stoppedJobs)
+	}
+
+	for _, name := range p.RemoveNodeSets {
+		if err := n.StopNodeSet(ctx, name); err != nil {
+			return &netState, fmt.Errorf("failed to stop node set %q: %w", name, err)
+		}
+
+		updatedNetState, err := nodesRemoveNode(netState, name)
+		if err != nil {
+			return &netState, fmt.Errorf("failed to remove node set %q: %w", name, err)
+		}
+		netState = *updatedNetState
+	}
+
+	netState.Config = desired
+
+	if err := applyTemplateChanges(ctx, n, p.UpdateTemplates); err != nil {
+		return &netState, err
+	}
+
+	for _, addition := range p.AddNodeSets {
+		for i := 0; i < addition.Count; i++ {
+			opts := capsule.AddNodeSetOptions{BaseOnGroup: addition.GroupName, Start: running}
+			if _, err := n.AddNodeSet(ctx, opts); err != nil {
+				return &netState, fmt.Errorf("failed to add node set to group %q: %w", addition.GroupName, err)
+			}
+		}
+	}
+
+	if running {
+		servicesToStart := append(append([]string{}, p.StartServices...), p.RestartServices...)
+
+		jobIDs, err := startServices(ctx, runner, desired, servicesToStart)
+		netState.RunningJobs.AddExtraJobIDs(jobIDs)
+		if err != nil {
+			return &netState, fmt.Errorf("failed to start services: %w", err)
+		}
+	}
+
+	if err := desired.Persist(); err != nil {
+		return &netState, fmt.Errorf("failed to persist config in output directory %s: %w", *desired.OutputDir, err)
+	}
+
+	if err := capsule.Register(netState, networkName); err != nil {
+		return &netState, fmt.Errorf("failed to register network: %w", err)
+	}
+
+	log.Println("applying network changes success")
+
+	return &netState, nil
+}
+
+func applyTemplateChanges(ctx context.Context, n *capsule.Network, changes []plan.TemplateChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	netState := n.State()
+
+	gen, err := generator.New(netState.Config, *netState.GeneratedServices, nomad.NewVoidJobRunner(), netState.VegaChainID)
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		ns, err := netState.GeneratedServices.GetNodeSet(change.NodeSetName)
+		if err != nil {
+			return err
+		}
+
+		nc, err := netState.Config.Network.GetNodeConfig(change.GroupName)
+		if err != nil {
+			return err
+		}
+
+		updatedNodeSet, err := gen.OverwriteNodeSet(*nc, *ns, netState.GeneratedServices.Faucet)
+		if err != nil {
+			return fmt.Errorf("failed to overwrite configs of node set %q: %w", ns.Name, err)
+		}
+		netState.GeneratedServices.NodeSets[updatedNodeSet.Name] = *updatedNodeSet
+
+		if !netState.RunningJobs.NodesSetsJobIDs[updatedNodeSet.Name] {
+			continue
+		}
+
+		if err := n.StopNodeSet(ctx, updatedNodeSet.Name, capsule.KeepPreGenerateJobs()); err != nil {
+			return fmt.Errorf("failed to stop node set %q: %w", updatedNodeSet.Name, err)
+		}
+
+		if err := n.StartNodeSet(ctx, updatedNodeSet.Name); err != nil {
+			return fmt.Errorf("failed to start node set %q: %w", updatedNodeSet.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func startServices(ctx context.Context, runner jobrunner.JobRunner, conf *config.Config, names []string) ([]string, error) {
+	toStart := map[string]bool{}
+	for _, name := range names {
+		toStart[name] = true
+	}
+
+	jobIDs := []string{}
+	for _, pstart := range []*config.PStartConfig{conf.Network.PreStart, conf.Network.PostStart} {
+		if pstart == nil {
+			continue
+		}
+
+		for _, dc := range pstart.Docker {
+			if !toStart[dc.Name] {
+				continue
+			}
+
+			jobID, err := runner.RunDockerJob(ctx, dc)
+			if err != nil {
+				return jobIDs, err
+			}
+			jobIDs = append(jobIDs, jobID)
+		}
+
+		for _, ec := range pstart.Exec {
+			if !toStart[ec.Name] {
+				continue
+			}
+
+			jobID, err := runner.RunExecJob(ctx, ec)
+			if err != nil {
+				return jobIDs, err
+			}
+			jobIDs = append(jobIDs, jobID)
+		}
+	}
+
+	return jobIDs, nil
+}
@@ -4,10 +4,9 @@ import (
 	"context"
 	"fmt"
 
-	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/capsule"
 	"code.vegaprotocol.io/vegacapsule/installer"
 	"code.vegaprotocol.io/vegacapsule/state"
-	"code.vegaprotocol.io/vegacapsule/types"
 
 	"github.com/spf13/cobra"
 )
@@ -17,52 +16,16 @@ var installBinaries bool
 var netBootstrapCmd = &cobra.Command{
 	Use:   "bootstrap",
 	Short: "Bootstrap generates and starts new network",
-	RunE: func(cmd *cobra.Command, args []string) error {
-		conf, err := config.ParseConfigFile(configFilePath, homePath, types.DefaultGeneratedServices())
-		if err != nil {
-			return fmt.Errorf("failed to parse config file: %w", err)
-		}
-
-		netState, err := state.LoadNetworkState(homePath)
-		if err != nil {
-			return err
-		}
-
-		defer func() {
-			cmd.SilenceUsage = true
-		}()
-
-		conf.OutputDir = &homePath
-
-		releaseTag := getReleaseTag(installBinaries)
-		if releaseTag != "" {
-			inst := installer.New(conf.BinariesDir(), installPath)
-
-			installedBinsPaths, err := inst.Install(cmd.Context(), releaseTag)
-			if err != nil {
-				return fmt.Errorf("failed to install dependencies: %w", err)
-			}
-
-			conf.SetBinaryPaths(installedBinsPaths)
-		}
-
-		netState.Config = conf
-		updatedNetState, err := netGenerate(*netState, forceGenerate)
-		if err != nil {
-			return fmt.Errorf("failed to generate network: %w", err)
-		}
-
-		if err := updatedNetState.Persist(); err != nil {
-			return fmt.Errorf("failed to persist network state after bootstrap/generate command: %w", err)
-		}
-
-		updatedNetState, err = netStart(context.Background(), *updatedNetState)
-		if err != nil {
-			return fmt.Errorf("failed to start network: %w", err)
-		}
-
-		return updatedNetState.Persist()
-	},
+	RunE: withNetworkLock(func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		return runNetBootstrap(cmd.Context(), netBootstrapArgs{
+			configPath: configFilePath,
+			seed:       generateSeed,
+			releaseTag: getReleaseTag(installBinaries),
+			force:      forceGenerate,
+		})
+	}),
 }
 
 func init() {
@@ -86,10 +49,66 @@ func init() {
 		"",
 		"Path to the config file to generate network from",
 	)
+	netBootstrapCmd.PersistentFlags().StringVar(&generateSeed,
+		"seed",
+		"",
+		"Seed to derive all keys of the network from, overwrites seed from the config file",
+	)
 	netBootstrapCmd.PersistentFlags().BoolVar(&doNotStopAllJobsOnFailure,
 		"do-not-stop-on-failure",
 		false,
 		"Do not stop partially running network when failed to start",
 	)
+	netBootstrapCmd.PersistentFlags().BoolVar(&skipWaitReady,
+		"skip-wait-ready",
+		false,
+		"Do not wait for readiness probes of the network to pass",
+	)
 	netBootstrapCmd.MarkFlagRequired("config-path")
 }
+
+type netBootstrapArgs struct {
+	configPath string
+	seed       string
+	releaseTag string
+	force      bool
+}
+
+// runNetBootstrap generates the network from the config file, starts it and persists its state.
+// The network state lock has to be held by the caller.
+func runNetBootstrap(ctx context.Context, args netBootstrapArgs) error {
+	conf, err := capsule.ParseConfigFile(args.configPath, homePath)
+	if err != nil {
+		return err
+	}
+
+	if args.seed != "" {
+		conf.Seed = &args.seed
+	}
+
+	netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
+	if err != nil {
+		return err
+	}
+
+	conf.OutputDir = &homePath
+
+	if args.releaseTag != "" {
+		inst := installer.New(conf.BinariesDir(), installPath)
+
+		installedBinsPaths, err := inst.Install(ctx, args.releaseTag)
+		if err != nil {
+			return fmt.Errorf("failed to install dependencies: %w", err)
+		}
+
+		conf.SetBinaryPaths(installedBinsPaths)
+	}
+
+	netState.Config = conf
+	netState.Ports = conf.PortAllocator()
+	if err := netGenerate(netState, args.force); err != nil {
+		return fmt.Errorf("failed to generate network: %w", err)
+	}
+
+	return startNetwork(ctx, netState)
+}
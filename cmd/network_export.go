@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"code.vegaprotocol.io/vegacapsule/archive"
+	"code.vegaprotocol.io/vegacapsule/state"
+
+	"github.com/spf13/cobra"
+)
+
+// emptyPrivValidatorState is the Tendermint validator signing state of a node without chain data.
+const emptyPrivValidatorState = `{
+  "height": "0",
+  "round": 0,
+  "step": 0
+}`
+
+var netExportFlags = struct {
+	out              string
+	includeChainData bool
+}{}
+
+var netExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the network into an archive that can be imported on another machine or into another home",
+	Long: `Export the network home into zstd compressed tar archive.
+
+The archive contains network state, generated node homes, genesis and wallets, so the same network
+with the same keys can be imported with the 'network import' command. Logs are never exported.
+Chain data of the nodes are exported only with the --include-chain-data flag.`,
+	Example: `# Export the network without chain data
+vegacapsule network export --out net.tar.zst`,
+	RunE: withNetworkLock(func(cmd *cobra.Command, args []string) error {
+		netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
+		if err != nil {
+			return err
+		}
+
+		if netState.Empty() {
+			return networkNotBootstrappedErr("export")
+		}
+
+		if netState.Running() {
+			log.Println("network is running, exported data might be inconsistent - consider stopping the network first")
+		}
+
+		return netExport(*netState, netExportFlags.out, netExportFlags.includeChainData)
+	}),
+}
+
+func init() {
+	netExportCmd.PersistentFlags().StringVar(&netExportFlags.out,
+		"out",
+		"",
+		"Path to the output archive",
+	)
+	netExportCmd.PersistentFlags().BoolVar(&netExportFlags.includeChainData,
+		"include-chain-data",
+		false,
+		"Export chain data (blocks, snapshots, checkpoints) of the nodes",
+	)
+	netExportCmd.MarkPersistentFlagRequired("out") // nolint:errcheck
+}
+
+func netExport(netState state.NetworkState, out string, includeChainData bool) error {
+	networkHome := *netState.Config.OutputDir
+
+	outPath, err := filepath.Abs(out)
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path of %q: %w", out, err)
+	}
+
+	opts := archive.ExportOptions{
+		Exclude:  []string{netState.Config.LogsDir(), outPath},
+		Override: map[string][]byte{},
+	}
+
+	if !includeChainData {
+		for _, ns := range netState.GeneratedServices.NodeSets.ToSlice() {
+			opts.Exclude = append(opts.Exclude, filepath.Join(ns.Vega.HomeDir, "state"))
+
+			if ns.DataNode != nil {
+				opts.Exclude = append(opts.Exclude, filepath.Join(ns.DataNode.HomeDir, "state"))
+			}
+
+			// Tendermint refuses to start without the signing state, so it is reset instead of being removed
+			tmDataDir := filepath.Join(ns.Tendermint.HomeDir, "data")
+			privValidatorState := filepath.Join(tmDataDir, "priv_validator_state.json")
+
+			entries, err := os.ReadDir(tmDataDir)
+			if err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to read Tendermint data of %q: %w", ns.Name, err)
+			}
+
+			for _, e := range entries {
+				if p := filepath.Join(tmDataDir, e.Name()); p != privValidatorState {
+					opts.Exclude = append(opts.Exclude, p)
+				}
+			}
+			opts.Override[privValidatorState] = []byte(emptyPrivValidatorState)
+		}
+	}
+
+	manifest := archive.Manifest{
+		NetworkName:  netState.Config.Network.Name,
+		HomePath:     networkHome,
+		StateVersion: state.CurrentVersion(),
+		ChainData:    includeChainData,
+	}
+
+	f, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("failed to create archive file: %w", err)
+	}
+	defer f.Close()
+
+	log.Printf("exporting network from %q", networkHome)
+
+	if err := archive.Export(f, networkHome, manifest, opts); err != nil {
+		os.Remove(outPath)
+		return fmt.Errorf("failed to export network: %w", err)
+	}
+
+	log.Printf("network exported to %q", outPath)
+
+	return nil
+}
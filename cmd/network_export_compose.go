@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"code.vegaprotocol.io/vegacapsule/compose"
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/state"
+
+	"github.com/spf13/cobra"
+)
+
+var netExportComposeFlags = struct {
+	out   string
+	image string
+}{}
+
+var netExportComposeCmd = &cobra.Command{
+	Use:   "export-compose",
+	Short: "Export the generated network as Docker Compose project",
+	Long: `Export the generated network as Docker Compose project running the same tasks as the default Nomad jobs.
+
+Every node set task, the wallet, the faucet and pre/post start Docker services become Compose services.
+Vega binaries run in the given image with the network home and the binaries bind-mounted to the same paths
+as they have on the host, and they use host network, so configs of the nodes are used as they are.
+Binaries must be built for Linux. Exec services and pre generate Nomad jobs are left out.`,
+	Example: `# Export the network and run it with Docker Compose
+vegacapsule network export-compose --out docker-compose.yml
+docker compose -f docker-compose.yml up`,
+	// export only reads the network, so it doesn't take the network state lock
+	RunE: func(cmd *cobra.Command, args []string) error {
+		netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
+		if err != nil {
+			return err
+		}
+
+		if netState.Empty() {
+			return networkNotBootstrappedErr("export-compose")
+		}
+
+		return netExportCompose(*netState, netExportComposeFlags.out, netExportComposeFlags.image)
+	},
+}
+
+func init() {
+	netExportComposeCmd.PersistentFlags().StringVar(&netExportComposeFlags.out,
+		"out",
+		"docker-compose.yml",
+		"Path to the output Docker Compose file",
+	)
+	netExportComposeCmd.PersistentFlags().StringVar(&netExportComposeFlags.image,
+		"image",
+		compose.DefaultImage,
+		"Docker image used to run Vega binaries",
+	)
+}
+
+func netExportCompose(netState state.NetworkState, out, image string) error {
+	conf, err := config.ApplyConfigContext(netState.Config, netState.GeneratedServices)
+	if err != nil {
+		return fmt.Errorf("failed to apply config context: %w", err)
+	}
+
+	project, err := compose.New(conf, *netState.GeneratedServices, compose.Options{
+		Image: image,
+		User:  fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid()),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create Docker Compose project: %w", err)
+	}
+
+	b, err := project.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal Docker Compose project: %w", err)
+	}
+
+	if err := os.WriteFile(out, b, 0o644); err != nil {
+		return fmt.Errorf("failed to write Docker Compose file %q: %w", out, err)
+	}
+
+	log.Printf("Docker Compose project with %d services exported to %q", len(project.Services), out)
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/k8s"
+	"code.vegaprotocol.io/vegacapsule/state"
+
+	"github.com/spf13/cobra"
+)
+
+var netExportK8sFlags = struct {
+	out       string
+	image     string
+	initImage string
+}{}
+
+var netExportK8sCmd = &cobra.Command{
+	Use:   "export-k8s",
+	Short: "Export the generated network as Kubernetes manifests",
+	Long: `Export the generated network as Kubernetes manifests running the same tasks as the default Nomad jobs.
+
+Every node set, the wallet, the faucet and pre/post start Docker and exec services become a StatefulSet
+with a Service exposing its ports. Files generated into the homes of the services are packaged into
+ConfigMaps (configs and genesis) and Secrets (keys, wallets and passphrases) and copied into a persistent
+volume mounted to the same path as the network home has on the host. Chain data of the nodes are left out.
+
+Vega binaries run in the given image and must be present in it at the same paths as on the host.
+Vega services use host network, so configs of the nodes are used as they are. Pre generate Nomad jobs are left out.`,
+	Example: `# Export the network and apply it to the cluster
+vegacapsule network export-k8s --image my-registry/vega:latest --out network.yaml
+kubectl apply -f network.yaml`,
+	// export only reads the network, so it doesn't take the network state lock
+	RunE: func(cmd *cobra.Command, args []string) error {
+		netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
+		if err != nil {
+			return err
+		}
+
+		if netState.Empty() {
+			return networkNotBootstrappedErr("export-k8s")
+		}
+
+		return netExportK8s(*netState, netExportK8sFlags.out, k8s.Options{
+			Image:     netExportK8sFlags.image,
+			InitImage: netExportK8sFlags.initImage,
+		})
+	},
+}
+
+func init() {
+	netExportK8sCmd.PersistentFlags().StringVar(&netExportK8sFlags.out,
+		"out",
+		"network.yaml",
+		"Path to the output file with Kubernetes manifests",
+	)
+	netExportK8sCmd.PersistentFlags().StringVar(&netExportK8sFlags.image,
+		"image",
+		"",
+		"Container image with Vega binaries",
+	)
+	netExportK8sCmd.PersistentFlags().StringVar(&netExportK8sFlags.initImage,
+		"init-image",
+		k8s.DefaultInitImage,
+		"Container image copying the generated files into the home volume",
+	)
+	netExportK8sCmd.MarkPersistentFlagRequired("image") // nolint:errcheck
+}
+
+func netExportK8s(netState state.NetworkState, out string, opts k8s.Options) error {
+	conf, err := config.ApplyConfigContext(netState.Config, netState.GeneratedServices)
+	if err != nil {
+		return fmt.Errorf("failed to apply config context: %w", err)
+	}
+
+	manifests, err := k8s.New(conf, *netState.GeneratedServices, opts)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes manifests: %w", err)
+	}
+
+	b, err := manifests.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal Kubernetes manifests: %w", err)
+	}
+
+	if err := os.WriteFile(out, b, 0o600); err != nil {
+		return fmt.Errorf("failed to write Kubernetes manifests %q: %w", out, err)
+	}
+
+	log.Printf("Kubernetes manifests of %d StatefulSets exported to %q", len(manifests.StatefulSets), out)
+
+	return nil
+}
@@ -2,16 +2,10 @@ package cmd
 
 import (
 	"fmt"
-	"log"
 	"os"
-	"path/filepath"
 
-	"code.vegaprotocol.io/vegacapsule/config"
-	"code.vegaprotocol.io/vegacapsule/generator"
-	"code.vegaprotocol.io/vegacapsule/nomad"
+	"code.vegaprotocol.io/vegacapsule/capsule"
 	"code.vegaprotocol.io/vegacapsule/state"
-	"code.vegaprotocol.io/vegacapsule/types"
-	"code.vegaprotocol.io/vegacapsule/utils"
 
 	"github.com/spf13/cobra"
 )
@@ -19,30 +13,35 @@ import (
 var (
 	forceGenerate  bool
 	configFilePath string
+	generateSeed   string
 )
 
 var netGenerateCmd = &cobra.Command{
 	Use:   "generate",
 	Short: "Generate new network from configuration file",
-	RunE: func(cmd *cobra.Command, args []string) error {
-		conf, err := config.ParseConfigFile(configFilePath, homePath, types.DefaultGeneratedServices())
+	RunE: withNetworkLock(func(cmd *cobra.Command, args []string) error {
+		conf, err := capsule.ParseConfigFile(configFilePath, homePath)
 		if err != nil {
-			return fmt.Errorf("failed to parse config file: %w", err)
+			return err
+		}
+
+		if generateSeed != "" {
+			conf.Seed = &generateSeed
 		}
 
-		netState, err := state.LoadNetworkState(homePath)
+		netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
 		if err != nil {
 			return err
 		}
 		netState.Config = conf
+		netState.Ports = conf.PortAllocator()
 
-		updatedNetState, err := netGenerate(*netState, forceGenerate)
-		if err != nil {
+		if err := netGenerate(netState, forceGenerate); err != nil {
 			return fmt.Errorf("failed to generate network: %w", err)
 		}
 
-		return updatedNetState.Persist()
-	},
+		return nil
+	}),
 }
 
 func init() {
@@ -56,55 +55,24 @@ func init() {
 		"",
 		"Path to the config file to generate network from",
 	)
+	netGenerateCmd.PersistentFlags().StringVar(&generateSeed,
+		"seed",
+		"",
+		"Seed to derive all keys of the network from, overwrites seed from the config file",
+	)
 	netGenerateCmd.MarkFlagRequired("config-path")
 }
 
-func netGenerate(state state.NetworkState, force bool) (*state.NetworkState, error) {
+// netGenerate generates the network and persists its state, with force the network home is removed first.
+func netGenerate(netState *state.NetworkState, force bool) error {
 	if force {
-		if err := os.RemoveAll(*state.Config.OutputDir); err != nil {
-			return nil, fmt.Errorf("failed to remove output folder with --force flag: %w", err)
+		if err := os.RemoveAll(*netState.Config.OutputDir); err != nil {
+			return fmt.Errorf("failed to remove output folder with --force flag: %w", err)
 		}
-	} else if state.GeneratedServices != nil {
-		return nil, fmt.Errorf("failed to generate network: network is already generated")
-	}
-
-	if netDirEmpty, _ := utils.DirEmpty(*state.Config.OutputDir, filepath.Base(state.Config.BinariesDir())); !netDirEmpty {
-		return nil, fmt.Errorf("output directory %q already exists and it's not empty", *state.Config.OutputDir)
-	}
-
-	log.Println("generating network")
-
-	state.VegaChainID = state.Config.Network.Name + "-001"
 
-	nomadClient, err := nomad.NewClient(nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create nomad client: %w", err)
+		netState.GeneratedServices = nil
+		netState.RunningJobs = nil
 	}
 
-	nomadRunner, err := nomad.NewJobRunner(nomadClient, *state.Config.VegaCapsuleBinary, state.Config.LogsDir())
-	if err != nil {
-		return nil, fmt.Errorf("failed to create job runner: %w", err)
-	}
-
-	gen, err := generator.New(state.Config, types.GeneratedServices{}, nomadRunner, state.VegaChainID)
-	if err != nil {
-		return nil, err
-	}
-
-	generatedSvcs, err := gen.Generate()
-	if err != nil {
-		return nil, err
-	}
-
-	if err := state.Config.Persist(); err != nil {
-		return nil, fmt.Errorf("failed to persist config in output directory %s: %w", *state.Config.OutputDir, err)
-	}
-
-	log.Println("generating network success")
-
-	state.GeneratedServices = generatedSvcs
-
-	state.RunningJobs = &types.NetworkJobs{}
-	state.RunningJobs.AddExtraJobIDs(generatedSvcs.PreGenerateJobsIDs())
-	return &state, nil
+	return newNetwork(netState).Generate()
 }
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"code.vegaprotocol.io/vegacapsule/archive"
+	"code.vegaprotocol.io/vegacapsule/capsule"
+	"code.vegaprotocol.io/vegacapsule/state"
+	"code.vegaprotocol.io/vegacapsule/types"
+	"code.vegaprotocol.io/vegacapsule/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var netImportCmd = &cobra.Command{
+	Use:   "import <archive>",
+	Short: "Import the network from an archive created by the 'network export' command",
+	Long: `Import the network from an archive created by the 'network export' command.
+
+The archive is unpacked into the network home, absolute paths stored in the network state
+and in the configuration files are rewritten to the new home and the network is registered.`,
+	Example: `# Import the network as "upgrade" into $CAPSULE_HOME/upgrade
+vegacapsule network import net.tar.zst --network upgrade
+
+# Import the network into a specific home directory
+vegacapsule network import net.tar.zst --home-path /var/tmp/veganetwork/imported`,
+	Args: cobra.ExactArgs(1),
+	RunE: withNetworkLock(func(cmd *cobra.Command, args []string) error {
+		if empty, _ := utils.DirEmpty(homePath); !empty {
+			return fmt.Errorf("network home %q already exists and it's not empty", homePath)
+		}
+
+		if err := netImport(args[0]); err != nil {
+			if err := os.RemoveAll(homePath); err != nil {
+				log.Printf("failed to clean up network home %q: %s", homePath, err)
+			}
+
+			return fmt.Errorf("failed to import network: %w", err)
+		}
+
+		return nil
+	}),
+}
+
+func netImport(archivePath string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer f.Close()
+
+	log.Printf("importing network into %q", homePath)
+
+	manifest, err := archive.Import(f, homePath)
+	if err != nil {
+		return err
+	}
+
+	if manifest.StateVersion > state.CurrentVersion() {
+		return fmt.Errorf(
+			"network state version %d is newer than the latest supported version %d, please upgrade Capsule",
+			manifest.StateVersion, state.CurrentVersion(),
+		)
+	}
+
+	if err := state.RelocateNetworkState(homePath, manifest.HomePath); err != nil {
+		return fmt.Errorf("failed to rewrite paths in network state: %w", err)
+	}
+
+	netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
+	if err != nil {
+		return err
+	}
+
+	// nothing of the imported network is running yet
+	netState.RunningJobs = &types.NetworkJobs{}
+	netState.RunningJobs.AddExtraJobIDs(netState.GeneratedServices.PreGenerateJobsIDs())
+
+	if err := capsule.Register(*netState, networkName); err != nil {
+		return fmt.Errorf("failed to register network: %w", err)
+	}
+
+	if err := netState.Persist(); err != nil {
+		return err
+	}
+
+	if !manifest.ChainData {
+		log.Println("the archive doesn't contain chain data, the network will start from genesis")
+	}
+
+	log.Printf("network %q imported successfully", manifest.NetworkName)
+
+	return nil
+}
@@ -56,8 +56,8 @@ var netKeysImportCmd = &cobra.Command{
 	Short:        "Import pre-generated keys into the network",
 	Long:         networkKeysImportDescription,
 	SilenceUsage: true,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		netState, err := state.LoadNetworkState(homePath)
+	RunE: withNetworkLock(func(cmd *cobra.Command, args []string) error {
+		netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
 		if err != nil {
 			return err
 		}
@@ -76,7 +76,7 @@ var netKeysImportCmd = &cobra.Command{
 		}
 
 		return updatedNetState.Persist()
-	},
+	}),
 }
 
 func init() {
@@ -25,7 +25,7 @@ var netLogsCmd = &cobra.Command{
 	Use:   "logs",
 	Short: "Print logs from running jobs in network. By default prints logs across all jobs",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		netState, err := state.LoadNetworkState(homePath)
+		netState, err := state.LoadNetworkState(homePath, stateLoadOptsWithoutSecrets()...)
 		if err != nil {
 			return err
 		}
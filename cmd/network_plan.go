@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"code.vegaprotocol.io/vegacapsule/capsule"
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/generator"
+	"code.vegaprotocol.io/vegacapsule/nomad"
+	"code.vegaprotocol.io/vegacapsule/plan"
+	"code.vegaprotocol.io/vegacapsule/state"
+
+	"github.com/spf13/cobra"
+)
+
+var netPlanFlags = struct {
+	configPath string
+	output     string
+}{}
+
+var netPlanCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show changes needed to update the generated network to a new configuration",
+	Long: `Compare the new configuration with the configuration the network has been generated from.
+
+The plan lists node sets to be added or removed, node sets whose rendered config templates change
+and pre/post start services to be started, stopped or restarted. Node sets whose mode, data node
+or visor setup changes are replaced. Use the 'network apply' command to apply the changes.`,
+	Example: `# Show changes for the new configuration
+vegacapsule network plan --config-path new.hcl
+
+# Show changes in JSON format
+vegacapsule network plan --config-path new.hcl --output json`,
+	// plan doesn't change the network, so it doesn't take the network state lock
+	RunE: func(cmd *cobra.Command, args []string) error {
+		netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
+		if err != nil {
+			return err
+		}
+
+		if netState.Empty() {
+			return networkNotBootstrappedErr("plan")
+		}
+
+		// ports the new configuration would allocate are only planned
+		netState.Ports = netState.Ports.Clone()
+		netState.Config.SetPortAllocator(netState.Ports)
+
+		p, _, err := netPlan(netState, netPlanFlags.configPath)
+		if err != nil {
+			return fmt.Errorf("failed to plan network changes: %w", err)
+		}
+
+		if netPlanFlags.output != "" {
+			return printStructured(p, netPlanFlags.output)
+		}
+
+		printPlan(*p)
+
+		return nil
+	},
+}
+
+func init() {
+	netPlanCmd.PersistentFlags().StringVar(&netPlanFlags.configPath,
+		"config-path",
+		"",
+		"Path to the new config file",
+	)
+	netPlanCmd.PersistentFlags().StringVar(&netPlanFlags.output,
+		"output",
+		"",
+		"Output format: json or yaml, human readable list is printed when empty",
+	)
+	netPlanCmd.MarkPersistentFlagRequired("config-path") // nolint:errcheck
+}
+
+// netPlan compares the new configuration from configPath with the network state.
+// Parsed new configuration is returned with the plan.
+func netPlan(netState *state.NetworkState, configPath string) (*plan.Plan, *config.Config, error) {
+	if err := capsule.ReserveOtherNetworksPorts(netState, homePath); err != nil {
+		return nil, nil, err
+	}
+
+	desired, err := config.ParseConfigFile(configPath, *netState.Config.OutputDir, *netState.GeneratedServices, netState.Ports)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	if desired.Network.Name != netState.Config.Network.Name {
+		return nil, nil, fmt.Errorf("network name can't be changed from %q to %q, generate a new network instead",
+			netState.Config.Network.Name, desired.Network.Name)
+	}
+
+	// the configuration in state is stored as it was parsed before the network has been generated
+	current, err := config.ApplyConfigContext(netState.Config, netState.GeneratedServices)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to apply config context: %w", err)
+	}
+
+	currentGen, err := generator.New(current, *netState.GeneratedServices, nomad.NewVoidJobRunner(), netState.VegaChainID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	desiredGen, err := generator.New(desired, *netState.GeneratedServices, nomad.NewVoidJobRunner(), netState.VegaChainID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p, err := plan.New(current, desired, *netState.GeneratedServices, currentGen, desiredGen)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return p, desired, nil
+}
+
+func printPlan(p plan.Plan) {
+	if p.Empty() {
+		fmt.Println("No changes, the network matches the configuration.")
+		return
+	}
+
+	printPlanSection := func(title string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+
+		fmt.Printf("%s:\n", title)
+		for _, l := range lines {
+			fmt.Printf("  %s\n", l)
+		}
+		fmt.Println()
+	}
+
+	additions := make([]string, 0, len(p.AddNodeSets))
+	for _, a := range p.AddNodeSets {
+		additions = append(additions, fmt.Sprintf("+ %d node set(s) in group %q", a.Count, a.GroupName))
+	}
+
+	removals := make([]string, 0, len(p.RemoveNodeSets))
+	for _, name := range p.RemoveNodeSets {
+		removals = append(removals, fmt.Sprintf("- %s", name))
+	}
+
+	templates := make([]string, 0, len(p.UpdateTemplates))
+	for _, c := range p.UpdateTemplates {
+		templates = append(templates, fmt.Sprintf("~ %s: %s", c.NodeSetName, strings.Join(c.Templates, ", ")))
+	}
+
+	prefixed := func(prefix string, names []string) []string {
+		out := make([]string, 0, len(names))
+		for _, name := range names {
+			out = append(out, prefix+" "+name)
+		}
+		return out
+	}
+
+	printPlanSection("Node sets to add", additions)
+	printPlanSection("Node sets to remove", removals)
+	printPlanSection("Node sets with changed templates", templates)
+	printPlanSection("Services to start", prefixed("+", p.StartServices))
+	printPlanSection("Services to stop", prefixed("-", p.StopServices))
+	printPlanSection("Services to restart", prefixed("~", p.RestartServices))
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"code.vegaprotocol.io/vegacapsule/registry"
+	"code.vegaprotocol.io/vegacapsule/utils"
+
+	"github.com/spf13/cobra"
+)
+
+var networkName string
+
+var netListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all registered networks",
+	Long:  "List all networks registered in Capsule home. The network used by default is marked with *.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reg, err := registry.LoadDefault()
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "CURRENT\tNAME\tHOME PATH")
+		for _, n := range reg.List() {
+			current := ""
+			if n.Name == reg.Current {
+				current = "*"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", current, n.Name, n.HomePath)
+		}
+
+		return w.Flush()
+	},
+}
+
+var netUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Set network used by default by all commands",
+	Example: `# Use the upgrade network when no --network or --home-path flag is provided
+vegacapsule network use upgrade`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := registry.UpdateDefault(func(reg *registry.NetworkRegistry) error {
+			return reg.Use(args[0])
+		})
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Using network %q", args[0])
+
+		return nil
+	},
+}
+
+// resolveHomePath sets network home path from the --network flag or from the current network in the registry.
+// An explicitly provided --home-path always takes precedence over the current network.
+func resolveHomePath(cmd *cobra.Command) error {
+	homePathSet := cmd.Flags().Changed("home-path")
+
+	if networkName == "" && homePathSet {
+		return nil
+	}
+
+	reg, err := registry.LoadDefault()
+	if err != nil {
+		return err
+	}
+
+	if networkName == "" {
+		if reg.Current == "" {
+			return nil
+		}
+
+		n, err := reg.Get(reg.Current)
+		if err != nil {
+			return err
+		}
+		homePath = n.HomePath
+
+		return nil
+	}
+
+	if n, err := reg.Get(networkName); err == nil {
+		if homePathSet && filepath.Clean(homePath) != filepath.Clean(n.HomePath) {
+			return fmt.Errorf("network %q is registered with home path %q, but %q was provided", networkName, n.HomePath, homePath)
+		}
+		homePath = n.HomePath
+
+		return nil
+	}
+
+	// not registered yet - the network is going to be generated
+	if !homePathSet {
+		capsuleHome, err := utils.CapsuleHome()
+		if err != nil {
+			return err
+		}
+		homePath = filepath.Join(capsuleHome, networkName)
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&networkName,
+		"network",
+		"",
+		"Name of the registered network to use. Resolves the network home directory, see 'network list'",
+	)
+}
@@ -5,80 +5,65 @@ import (
 	"fmt"
 	"log"
 
-	"code.vegaprotocol.io/vegacapsule/config"
-	"code.vegaprotocol.io/vegacapsule/nomad"
 	"code.vegaprotocol.io/vegacapsule/state"
 
 	"github.com/spf13/cobra"
 )
 
-var doNotStopAllJobsOnFailure bool
+var (
+	doNotStopAllJobsOnFailure bool
+	skipWaitReady             bool
+)
 
 var netStartCmd = &cobra.Command{
 	Use:   "start",
 	Short: "Starts existing network",
-	RunE: func(cmd *cobra.Command, args []string) error {
-		netState, err := state.LoadNetworkState(homePath)
-		if err != nil {
-			return err
-		}
-
-		if netState.Empty() {
-			return networkNotBootstrappedErr("start")
-		}
-
-		defer func() {
-			cmd.SilenceUsage = true
-		}()
-
-		updatedNetState, err := netStart(context.Background(), *netState)
-		if err != nil {
-			return fmt.Errorf("failed to start network: %w", err)
-		}
+	RunE: withNetworkLock(func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
 
-		return updatedNetState.Persist()
-	},
+		return runNetStart(context.Background())
+	}),
 }
 
 func init() {
 	netStartCmd.PersistentFlags().BoolVar(&doNotStopAllJobsOnFailure,
 		"do-not-stop-on-failure",
 		false,
-		"Do not stop partially running network when failed to start",
+		"Do not stop partially running network when failed to start or to become ready",
+	)
+	netStartCmd.PersistentFlags().BoolVar(&skipWaitReady,
+		"skip-wait-ready",
+		false,
+		"Do not wait for readiness probes of the network to pass",
 	)
 }
 
-func netStart(ctx context.Context, state state.NetworkState) (*state.NetworkState, error) {
-	log.Println("starting network")
-
-	nomadClient, err := nomad.NewClient(nil)
+// runNetStart starts existing network and persists its state.
+// The network state lock has to be held by the caller.
+func runNetStart(ctx context.Context) error {
+	netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize nomad client: %w", err)
+		return err
 	}
 
-	nomadRunner, err := nomad.NewJobRunner(nomadClient, *state.Config.VegaCapsuleBinary, state.Config.LogsDir())
-	if err != nil {
-		return nil, fmt.Errorf("failed to create job runner: %w", err)
+	if netState.Empty() {
+		return networkNotBootstrappedErr("start")
 	}
 
-	conf, err := config.ApplyConfigContext(state.Config, state.GeneratedServices)
-	if err != nil {
-		return nil, fmt.Errorf("failed to apply config context: %w", err)
-	}
-	state.Config = conf
+	return startNetwork(ctx, netState)
+}
 
-	res, err := nomadRunner.StartNetwork(ctx, state.Config, state.GeneratedServices, !doNotStopAllJobsOnFailure)
-	if err != nil {
-		return nil, fmt.Errorf("failed to start network: %s", err)
+// startNetwork starts the network and prints its addresses. When the network fails to become ready
+// it is stopped, unless --do-not-stop-on-failure is set and its jobs keep running.
+func startNetwork(ctx context.Context, netState *state.NetworkState) error {
+	n := newNetwork(netState)
+	if err := n.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start network: %w", err)
 	}
 
-	state.RunningJobs.MergeNetworkJobs(*res)
-
-	log.Println("Network successfully started.")
-
-	if err := printNetworkAddresses(ctx, nomadRunner, state.GeneratedServices); err != nil {
+	if err := printNetworkAddresses(ctx, n); err != nil {
 		log.Printf("failed to print network addresses - please try to run 'network print-ports' instead: %s", err)
 	}
 
-	return &state, nil
+	return nil
 }
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/jobrunner"
+	"code.vegaprotocol.io/vegacapsule/state"
+	"code.vegaprotocol.io/vegacapsule/status"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+var (
+	netStatusOutput         string
+	netStatusSampleInterval time.Duration
+	netStatusProbeTimeout   time.Duration
+	netStatusMaxDataNodeLag int64
+)
+
+var netStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Prints health overview of all components of the network",
+	Long: `Prints state of the jobs, results of the readiness probes, block height, chain ID and validator status of the Vega nodes,
+lag of the data nodes, state of Visor and reachability of the Ethereum endpoints.
+Nodes with block height not advancing are flagged as stalled. The command fails when any component has issues.`,
+	Example: `# Print the status as a table
+vegacapsule network status
+
+# Print the status as JSON, e.g. in CI
+vegacapsule network status --output json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if netStatusOutput != outputTable && netStatusOutput != outputJSON {
+			return fmt.Errorf("unknown output %q, use %q or %q", netStatusOutput, outputTable, outputJSON)
+		}
+
+		netState, err := state.LoadNetworkState(homePath, stateLoadOptsWithoutSecrets()...)
+		if err != nil {
+			return err
+		}
+
+		if netState.Empty() {
+			return networkNotBootstrappedErr("status")
+		}
+
+		conf, err := config.ApplyConfigContext(netState.Config, netState.GeneratedServices)
+		if err != nil {
+			return fmt.Errorf("failed to apply config context: %w", err)
+		}
+
+		runner, err := jobrunner.New(netState.Config)
+		if err != nil {
+			return err
+		}
+
+		netStatus := status.Collect(cmd.Context(), runner, conf, netState.GeneratedServices, status.Options{
+			SampleInterval: netStatusSampleInterval,
+			ProbeTimeout:   netStatusProbeTimeout,
+			MaxDataNodeLag: netStatusMaxDataNodeLag,
+		})
+
+		if netStatusOutput == outputJSON {
+			b, err := json.MarshalIndent(netStatus, "", "\t")
+			if err != nil {
+				return fmt.Errorf("failed to marshal network status: %w", err)
+			}
+			fmt.Println(string(b))
+		} else if err := netStatus.WriteTable(os.Stdout); err != nil {
+			return fmt.Errorf("failed to print network status: %w", err)
+		}
+
+		if n := netStatus.Unhealthy(); n != 0 {
+			return fmt.Errorf("%d components of the network have issues", n)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	netStatusCmd.PersistentFlags().StringVar(&netStatusOutput,
+		"output",
+		outputTable,
+		"Output format, table or json",
+	)
+	netStatusCmd.PersistentFlags().DurationVar(&netStatusSampleInterval,
+		"sample-interval",
+		time.Second*3,
+		"Time between two block height samples, nodes with block height not advancing are flagged as stalled",
+	)
+	netStatusCmd.PersistentFlags().DurationVar(&netStatusProbeTimeout,
+		"probe-timeout",
+		time.Second*10,
+		"Maximum time for readiness probes of a job to pass",
+	)
+	netStatusCmd.PersistentFlags().Int64Var(&netStatusMaxDataNodeLag,
+		"max-data-node-lag",
+		10,
+		"Number of blocks a data node can be behind its Vega node without being flagged",
+	)
+}
@@ -3,9 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"log"
 
-	"code.vegaprotocol.io/vegacapsule/nomad"
 	"code.vegaprotocol.io/vegacapsule/state"
 
 	"github.com/spf13/cobra"
@@ -16,23 +14,9 @@ var stopNodesOnly bool
 var netStopCmd = &cobra.Command{
 	Use:   "stop",
 	Short: "Stop existing network",
-	RunE: func(cmd *cobra.Command, args []string) error {
-		netState, err := state.LoadNetworkState(homePath)
-		if err != nil {
-			return err
-		}
-
-		if netState.Empty() {
-			return networkNotBootstrappedErr("stop")
-		}
-
-		updatedState, err := netStop(context.Background(), *netState)
-		if err != nil {
-			return fmt.Errorf("failed to stop network: %w", err)
-		}
-
-		return updatedState.Persist()
-	},
+	RunE: withNetworkLock(func(cmd *cobra.Command, args []string) error {
+		return runNetStop(context.Background(), stopNodesOnly)
+	}),
 }
 
 func init() {
@@ -43,37 +27,28 @@ func init() {
 	)
 }
 
-func netStop(ctx context.Context, state state.NetworkState) (*state.NetworkState, error) {
-	log.Println("Stopping network...")
-
-	nomadClient, err := nomad.NewClient(nil)
+// runNetStop stops running network and persists its state.
+// The network state lock has to be held by the caller.
+func runNetStop(ctx context.Context, nodesOnly bool) error {
+	netState, err := state.LoadNetworkState(homePath, stateLoadOptsWithoutSecrets()...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize nomad client: %w", err)
+		return err
 	}
 
-	var logsDir, vegaCapsuleBinary string
-	if state.Config != nil {
-		logsDir = state.Config.LogsDir()
-
-		if state.Config.VegaCapsuleBinary != nil {
-			vegaCapsuleBinary = *state.Config.VegaCapsuleBinary
-		}
+	if netState.Empty() {
+		return networkNotBootstrappedErr("stop")
 	}
 
-	nomadRunner, err := nomad.NewJobRunner(nomadClient, vegaCapsuleBinary, logsDir)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create job runner: %w", err)
-	}
+	n := newNetwork(netState)
 
-	stoppedJobs, err := nomadRunner.StopNetwork(ctx, state.RunningJobs, stopNodesOnly)
-	if err != nil {
-		return nil, fmt.Errorf("failed to stop network: %w", err)
+	stop := n.Stop
+	if nodesOnly {
+		stop = n.StopNodeSets
 	}
 
-	if state.RunningJobs != nil {
-		state.RunningJobs.RemoveRunningJobsIDs(stoppedJobs)
+	if err := stop(ctx); err != nil {
+		return fmt.Errorf("failed to stop network: %w", err)
 	}
 
-	log.Println("Network successfully stopped.")
-	return &state, nil
+	return nil
 }
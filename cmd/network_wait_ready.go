@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"code.vegaprotocol.io/vegacapsule/state"
+
+	"github.com/spf13/cobra"
+)
+
+var netWaitReadyTimeout time.Duration
+
+var netWaitReadyCmd = &cobra.Command{
+	Use:   "wait-ready",
+	Short: "Waits until all services of the running network are ready",
+	Long: `Waits until readiness probes of all node sets, the wallet, the faucet and pre/post start services pass.
+Node sets without readiness probe are ready when block height reported by their Vega node REST API advances.`,
+	Example: `# Start the network in background and wait for it in a script
+vegacapsule network start
+vegacapsule network wait-ready --timeout 5m`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		netState, err := state.LoadNetworkState(homePath, stateLoadOptsWithoutSecrets()...)
+		if err != nil {
+			return err
+		}
+
+		if netState.Empty() {
+			return networkNotBootstrappedErr("wait-ready")
+		}
+
+		if !netState.Running() {
+			return networkNotRunningErr("wait-ready")
+		}
+
+		ctx := context.Background()
+		if netWaitReadyTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, netWaitReadyTimeout)
+			defer cancel()
+		}
+
+		return newNetwork(netState).WaitReady(ctx)
+	},
+}
+
+func init() {
+	netWaitReadyCmd.PersistentFlags().DurationVar(&netWaitReadyTimeout,
+		"timeout",
+		0,
+		"Maximum time to wait, probes without timeout wait this long instead of their default timeout",
+	)
+}
@@ -1,18 +1,16 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
-	"sync"
 
-	"code.vegaprotocol.io/vegacapsule/generator"
-	"code.vegaprotocol.io/vegacapsule/nomad"
+	"code.vegaprotocol.io/vegacapsule/capsule"
 	"code.vegaprotocol.io/vegacapsule/state"
 	"code.vegaprotocol.io/vegacapsule/types"
 
 	"github.com/spf13/cobra"
-	"golang.org/x/sync/errgroup"
 )
 
 var (
@@ -26,61 +24,21 @@ var (
 var nodesAddCmd = &cobra.Command{
 	Use:   "add",
 	Short: "Add new node set",
-	RunE: func(cmd *cobra.Command, args []string) error {
-		networkState, err := state.LoadNetworkState(homePath)
+	RunE: withNetworkLock(func(cmd *cobra.Command, args []string) error {
+		newNodeSets, err := runNodesAdd(cmd.Context(), nodesAddArgs{
+			count:       count,
+			baseOnNode:  baseOnNode,
+			baseOnGroup: baseOnGroup,
+			start:       startNode,
+		})
 		if err != nil {
-			return fmt.Errorf("failed to load network state: %w", err)
-		}
-
-		if networkState.Empty() {
-			return networkNotBootstrappedErr("nodes add")
-		}
-
-		if count < 1 {
-			return fmt.Errorf("count has to be > 0")
-		}
-
-		var eg errgroup.Group
-		var m sync.Mutex
-		newNodeSets := make([]*types.NodeSet, 0, count)
-
-		for i := 0; i < count; i++ {
-			i := i + 1
-			eg.Go(func() error {
-				newNodeSet, err := nodesAddNode(*networkState, i, baseOnNode, baseOnGroup)
-				if err != nil {
-					return fmt.Errorf("failed to add new node: %w", err)
-				}
-
-				m.Lock()
-				newNodeSets = append(newNodeSets, newNodeSet)
-				m.Unlock()
-
-				if startNode {
-					nomadJobID, err := nodesStartNode(cmd.Context(), newNodeSet, networkState.Config, newNodeSet.Vega.BinaryPath)
-					if err != nil {
-						return fmt.Errorf("failed start node: %w", err)
-					}
-
-					m.Lock()
-					networkState.RunningJobs.NodesSetsJobIDs[nomadJobID] = true
-					m.Unlock()
-				}
-
-				return nil
-			})
-		}
-
-		if err := eg.Wait(); err != nil {
 			return err
 		}
 
-		for _, ns := range newNodeSets {
-			networkState.GeneratedServices.NodeSets[ns.Name] = *ns
-		}
-
-		if err := networkState.Persist(); err != nil {
-			return fmt.Errorf("failed to persist network: %w", err)
+		if updateMultisig {
+			if err := nodesAddUpdateMultisig(cmd.Context(), newNodeSets); err != nil {
+				return err
+			}
 		}
 
 		outputStringJSON, err := json.MarshalIndent(newNodeSets, "", "\t")
@@ -96,7 +54,7 @@ var nodesAddCmd = &cobra.Command{
 		}
 
 		return nil
-	},
+	}),
 }
 
 func init() {
@@ -126,94 +84,50 @@ func init() {
 		"",
 		"If not empty, details about added nodes are saved in the given file",
 	)
+	addMultisigFlags(nodesAddCmd)
 }
 
-func nodesAddNode(state state.NetworkState, index int, baseOnNode, baseOnGroup string) (*types.NodeSet, error) {
-	if baseOnNode != "" && baseOnGroup != "" {
-		return nil, fmt.Errorf("provide either value for --base-on or --base-on-group, not both values")
-	}
-
-	if baseOnNode == "" && baseOnGroup == "" {
-		return nil, fmt.Errorf("value for --base-on-node or --base-on-group must be provided")
-	}
-
-	nomadClient, err := nomad.NewClient(nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create nomad client: %w", err)
-	}
-
-	capsuleBinary := ""
-	if state.Config.VegaCapsuleBinary != nil {
-		capsuleBinary = *state.Config.VegaCapsuleBinary
-	}
+type nodesAddArgs struct {
+	count       int
+	baseOnNode  string
+	baseOnGroup string
+	start       bool
+}
 
-	nomadRunner, err := nomad.NewJobRunner(nomadClient, capsuleBinary, state.Config.LogsDir())
+// runNodesAdd adds new node sets to the network, optionally starts them and persists the network state.
+// The network state lock has to be held by the caller.
+func runNodesAdd(ctx context.Context, args nodesAddArgs) ([]*types.NodeSet, error) {
+	networkState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create job runner: %w", err)
+		return nil, fmt.Errorf("failed to load network state: %w", err)
 	}
 
-	gen, err := generator.New(state.Config, *state.GeneratedServices, nomadRunner, state.VegaChainID)
-	if err != nil {
-		return nil, err
+	if networkState.Empty() {
+		return nil, networkNotBootstrappedErr("nodes add")
 	}
 
-	var (
-		nodeSet    *types.NodeSet
-		groupName  string
-		groupIndex int = -1
-	)
-	if baseOnNode != "" {
-		nodeSet, err = state.GeneratedServices.GetNodeSet(baseOnNode)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get node set by name: %w", err)
-		}
-
-		groupName = nodeSet.GroupName
-		groupIndex = nodeSet.GroupIndex
-	} else {
-		for groupIdx, group := range state.Config.Network.Nodes {
-			if group.Name == baseOnGroup {
-				groupIndex = groupIdx
-				break
-			}
-		}
-
-		if groupIndex < 0 {
-			return nil, fmt.Errorf("the %s nodes group not found", baseOnGroup)
-		}
-
-		nodes := state.GeneratedServices.GetNodeSetsByGroupName(baseOnGroup)
-		if len(nodes) < 1 {
-			// Nodes within given group does not exists, fallback to the first available node
-			nodes = state.GeneratedServices.NodeSets.ToSlice()
-		}
-
-		nodeSet = &(nodes[0])
-		groupName = baseOnGroup
-	}
+	return newNetwork(networkState).AddNodeSets(ctx, args.count, capsule.AddNodeSetOptions{
+		BaseOn:      args.baseOnNode,
+		BaseOnGroup: args.baseOnGroup,
+		Start:       args.start,
+	})
+}
 
+// nodesAddUpdateMultisig adds validators of the new node sets as signers of the multisig smart contracts.
+func nodesAddUpdateMultisig(ctx context.Context, nodeSets []*types.NodeSet) error {
+	networkState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("failed to load network state: %w", err)
 	}
 
-	nodeConfig, err := state.Config.Network.GetNodeConfig(groupName)
-	if err != nil {
-		return nil, err
+	names := make([]string, 0, len(nodeSets))
+	for _, ns := range nodeSets {
+		names = append(names, ns.Name)
 	}
 
-	groupNodeSets := state.GeneratedServices.GetNodeSetsByGroupName(groupName)
-
-	newNodeSet, err := gen.AddNodeSet(
-		len(state.GeneratedServices.NodeSets)-1+index,
-		len(groupNodeSets),
-		groupIndex,
-		*nodeConfig,
-		*nodeSet,
-		state.GeneratedServices.Faucet,
-	)
-	if err != nil {
-		return nil, err
+	if err := updateMultisigSigners(ctx, *networkState, names, nil); err != nil {
+		return fmt.Errorf("failed to add validators to multisig: %w", err)
 	}
 
-	return newNodeSet, nil
+	return nil
 }
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/state"
+	"code.vegaprotocol.io/vegacapsule/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodesAddEncryptedStateWithoutPassphrase(t *testing.T) {
+	networkDir := t.TempDir()
+
+	netState, err := state.LoadNetworkState(networkDir, state.WithPassphrase([]byte("p4ssphr4se")))
+	require.NoError(t, err)
+
+	conf, err := config.DefaultConfig()
+	require.NoError(t, err)
+	conf.OutputDir = &networkDir
+
+	genServices := types.DefaultGeneratedServices()
+	genServices.NodeSets["testnet-nodeset-validators-0-validator"] = types.NodeSet{
+		Name: "testnet-nodeset-validators-0-validator",
+		Mode: types.NodeModeValidator,
+		Vega: types.VegaNode{
+			NodeWalletInfo: &types.NodeWalletInfo{
+				EthereumAddress:          "0xEe7D375bcB50C26d52E1A4a472D8822A2A22d94F",
+				EthereumPrivateKey:       "a37f4c2a678aefb5037bf415a826df1540b330b7e471aa54184877ba901b9ef0",
+				VegaWalletRecoveryPhrase: "secret recovery phrase",
+			},
+		},
+	}
+
+	netState.Config = conf
+	netState.GeneratedServices = &genServices
+	netState.RunningJobs = &types.NetworkJobs{}
+	require.NoError(t, netState.Persist())
+
+	stateBefore, err := os.ReadFile(filepath.Join(networkDir, "network.dat"))
+	require.NoError(t, err)
+
+	prevHomePath, prevStateLoadOpts := homePath, stateLoadOpts
+	t.Cleanup(func() {
+		homePath, stateLoadOpts = prevHomePath, prevStateLoadOpts
+	})
+	homePath, stateLoadOpts = networkDir, nil
+
+	nodeSets, err := runNodesAdd(context.Background(), nodesAddArgs{count: 1})
+	assert.ErrorIs(t, err, state.ErrPassphraseRequired)
+	assert.Empty(t, nodeSets)
+
+	stateAfter, err := os.ReadFile(filepath.Join(networkDir, "network.dat"))
+	require.NoError(t, err)
+	assert.Equal(t, stateBefore, stateAfter)
+}
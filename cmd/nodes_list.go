@@ -13,7 +13,7 @@ var nodesLsCmd = &cobra.Command{
 	Use:   "ls",
 	Short: "Lists all node sets",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		networkState, err := state.LoadNetworkState(homePath)
+		networkState, err := state.LoadNetworkState(homePath, stateLoadOptsWithoutSecrets()...)
 		if err != nil {
 			return fmt.Errorf("failed load network state: %w", err)
 		}
@@ -13,7 +13,7 @@ var nodesLsValidatorsCmd = &cobra.Command{
 	Use:   "ls-validators",
 	Short: "Lists validators from node sets",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		networkState, err := state.LoadNetworkState(homePath)
+		networkState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
 		if err != nil {
 			return fmt.Errorf("failed list validators: %w", err)
 		}
@@ -27,81 +27,15 @@ var nodesProtocolUpgradeCmd = &cobra.Command{
 	Use:   "protocol-upgrade",
 	Short: "Prepares protocol upgrade for all running nodes and send transaction to network if allowed",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		netState, err := state.LoadNetworkState(homePath)
-		if err != nil {
-			return err
-		}
-
-		if netState.Empty() {
-			return networkNotBootstrappedErr("protocol-upgrade")
-		}
-
-		if upgradeBlockHeight == 0 {
-			return fmt.Errorf("parameter height can not be zero")
-		}
-
-		if len(upgradeInclude) != 0 && len(upgradeExclude) != 0 {
-			return fmt.Errorf("combining flags include-nodes and exclude-nodes is not allowed")
-		}
-
-		visorGen, err := visor.NewGenerator(netState.Config)
-		if err != nil {
-			return fmt.Errorf("failed to create new visor generator: %w", err)
-		}
-
-		runTemplateRaw, err := netState.Config.LoadConfigTemplateFile(upgradeRunConfigTemplateFile)
-		if err != nil {
-			return err
-		}
-
-		visorRunTmpl, err := visor.NewConfigTemplate(runTemplateRaw)
-		if err != nil {
-			return err
-		}
-
-		nodeSets, err := filtertUpgradeNodeSet(*netState.GeneratedServices, upgradeInclude, upgradeExclude)
-		if err != nil {
-			return err
-		}
-
-		for _, ns := range nodeSets {
-			if ns.Visor == nil {
-				continue
-			}
-
-			if err := visorGen.PrepareUpgrade(ns.Index, upgradeReleaseTag, ns, visorRunTmpl, upgradeForce); err != nil {
-				return err
-			}
-		}
-
-		if !upgradePropose {
-			return nil
-		}
-
-		if upgradeBlockHeight == 0 {
-			return fmt.Errorf("height has to be defined when sending proposals, please set with --height flag")
-		}
-
-		for _, ns := range nodeSets {
-			if !ns.IsValidator() {
-				continue
-			}
-
-			_, err := commands.VegaProtocolUpgradeProposal(
-				*netState.Config.VegaBinary,
-				ns.Vega.HomeDir,
-				upgradeReleaseTag,
-				strconv.FormatInt(upgradeBlockHeight, 10),
-				ns.Vega.NodeWalletPassFilePath,
-			)
-			if err != nil {
-				return fmt.Errorf("failed to submit protocol upgrade proposal to node %q: %w", ns.Name, err)
-			}
-
-			log.Printf("Applied protocol upgrade for node set %q \n", ns.Name)
-		}
-
-		return nil
+		return runNodesProtocolUpgrade(nodesProtocolUpgradeArgs{
+			height:       upgradeBlockHeight,
+			releaseTag:   upgradeReleaseTag,
+			templatePath: upgradeRunConfigTemplateFile,
+			propose:      upgradePropose,
+			force:        upgradeForce,
+			include:      upgradeInclude,
+			exclude:      upgradeExclude,
+		})
 	},
 }
 
@@ -146,6 +80,95 @@ func init() {
 	nodesProtocolUpgradeCmd.MarkFlagRequired("template-path")
 }
 
+type nodesProtocolUpgradeArgs struct {
+	height       int64
+	releaseTag   string
+	templatePath string
+	propose      bool
+	force        bool
+	include      []string
+	exclude      []string
+}
+
+// runNodesProtocolUpgrade prepares the protocol upgrade for Visor of the node sets and optionally proposes it to the network.
+func runNodesProtocolUpgrade(args nodesProtocolUpgradeArgs) error {
+	netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
+	if err != nil {
+		return err
+	}
+
+	if netState.Empty() {
+		return networkNotBootstrappedErr("protocol-upgrade")
+	}
+
+	if args.height == 0 {
+		return fmt.Errorf("parameter height can not be zero")
+	}
+
+	if len(args.include) != 0 && len(args.exclude) != 0 {
+		return fmt.Errorf("combining flags include-nodes and exclude-nodes is not allowed")
+	}
+
+	visorGen, err := visor.NewGenerator(netState.Config)
+	if err != nil {
+		return fmt.Errorf("failed to create new visor generator: %w", err)
+	}
+
+	runTemplateRaw, err := netState.Config.LoadConfigTemplateFile(args.templatePath)
+	if err != nil {
+		return err
+	}
+
+	visorRunTmpl, err := visor.NewConfigTemplate(runTemplateRaw, netState.Config.TemplateFuncs())
+	if err != nil {
+		return err
+	}
+
+	nodeSets, err := filtertUpgradeNodeSet(*netState.GeneratedServices, args.include, args.exclude)
+	if err != nil {
+		return err
+	}
+
+	for _, ns := range nodeSets {
+		if ns.Visor == nil {
+			continue
+		}
+
+		if err := visorGen.PrepareUpgrade(ns.Index, args.releaseTag, ns, visorRunTmpl, args.force); err != nil {
+			return err
+		}
+	}
+
+	if !args.propose {
+		return nil
+	}
+
+	if args.height == 0 {
+		return fmt.Errorf("height has to be defined when sending proposals, please set with --height flag")
+	}
+
+	for _, ns := range nodeSets {
+		if !ns.IsValidator() {
+			continue
+		}
+
+		_, err := commands.VegaProtocolUpgradeProposal(
+			*netState.Config.VegaBinary,
+			ns.Vega.HomeDir,
+			args.releaseTag,
+			strconv.FormatInt(args.height, 10),
+			ns.Vega.NodeWalletPassFilePath,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to submit protocol upgrade proposal to node %q: %w", ns.Name, err)
+		}
+
+		log.Printf("Applied protocol upgrade for node set %q \n", ns.Name)
+	}
+
+	return nil
+}
+
 func filtertUpgradeNodeSet(
 	genS types.GeneratedServices,
 	upgradeInclude,
@@ -16,28 +16,9 @@ var datanodeBackupDir string
 var nodesRemoveCmd = &cobra.Command{
 	Use:   "remove",
 	Short: "Remove existing node set",
-	RunE: func(cmd *cobra.Command, args []string) error {
-		networkState, err := state.LoadNetworkState(homePath)
-		if err != nil {
-			return fmt.Errorf("failed to load network state: %w", err)
-		}
-
-		if networkState.Empty() {
-			return networkNotBootstrappedErr("nodes remove")
-		}
-
-		updatedNetworkState, err := nodesStopNode(context.Background(), *networkState, nodeName, true)
-		if err != nil {
-			return fmt.Errorf("failed stop node: %w", err)
-		}
-
-		updatedNetworkState, err = nodesRemoveNode(*updatedNetworkState, nodeName)
-		if err != nil {
-			return fmt.Errorf("failed remove node: %w", err)
-		}
-
-		return updatedNetworkState.Persist()
-	},
+	RunE: withNetworkLock(func(cmd *cobra.Command, args []string) error {
+		return runNodesRemove(context.Background(), nodeName)
+	}),
 }
 
 func init() {
@@ -48,6 +29,38 @@ func init() {
 	)
 	nodesRemoveCmd.MarkFlagRequired("name")
 	nodesRemoveCmd.PersistentFlags().StringVar(&datanodeBackupDir, "datanode-backup-dir", "", "Directory where data node home directories should be backed up before removal")
+	addMultisigFlags(nodesRemoveCmd)
+}
+
+// runNodesRemove stops the node set, removes it from the network and persists the network state.
+// The network state lock has to be held by the caller.
+func runNodesRemove(ctx context.Context, name string) error {
+	networkState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to load network state: %w", err)
+	}
+
+	if networkState.Empty() {
+		return networkNotBootstrappedErr("nodes remove")
+	}
+
+	if updateMultisig {
+		// signers are removed while the node set still exists
+		if err := updateMultisigSigners(ctx, *networkState, nil, []string{name}); err != nil {
+			return fmt.Errorf("failed to remove validator from multisig: %w", err)
+		}
+	}
+
+	if err := newNetwork(networkState).StopNodeSet(ctx, name); err != nil {
+		return fmt.Errorf("failed stop node: %w", err)
+	}
+
+	updatedNetworkState, err := nodesRemoveNode(*networkState, name)
+	if err != nil {
+		return fmt.Errorf("failed remove node: %w", err)
+	}
+
+	return updatedNetworkState.Persist()
 }
 
 func nodesRemoveNode(state state.NetworkState, name string) (*state.NetworkState, error) {
@@ -14,8 +14,8 @@ import (
 var nodesUnsafeResetAllCmd = &cobra.Command{
 	Use:   "unsafe-reset-all",
 	Short: "(unsafe) Reset all nodes (Vega and Tendermint) state (checkpoints, snapshots etc..)",
-	RunE: func(cmd *cobra.Command, args []string) error {
-		netState, err := state.LoadNetworkState(homePath)
+	RunE: withNetworkLock(func(cmd *cobra.Command, args []string) error {
+		netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
 		if err != nil {
 			return err
 		}
@@ -37,5 +37,5 @@ var nodesUnsafeResetAllCmd = &cobra.Command{
 		}
 
 		return nil
-	},
+	}),
 }
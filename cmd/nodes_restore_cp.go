@@ -81,8 +81,8 @@ func updateDataNodeChainID(ns types.NodeSet, chainID string) error {
 var nodesRestoreCheckpointCmd = &cobra.Command{
 	Use:   "restore-checkpoint",
 	Short: "Restore all Vega nodes state from checkpoint",
-	RunE: func(cmd *cobra.Command, args []string) error {
-		netState, err := state.LoadNetworkState(homePath)
+	RunE: withNetworkLock(func(cmd *cobra.Command, args []string) error {
+		netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
 		if err != nil {
 			return err
 		}
@@ -128,7 +128,7 @@ var nodesRestoreCheckpointCmd = &cobra.Command{
 			fmt.Printf("applied transaction for node set %q: %s", ns.Name, r)
 		}
 		return nil
-	},
+	}),
 }
 
 func init() {
@@ -3,13 +3,9 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"log"
 
-	"code.vegaprotocol.io/vegacapsule/config"
-	"code.vegaprotocol.io/vegacapsule/nomad"
+	"code.vegaprotocol.io/vegacapsule/capsule"
 	"code.vegaprotocol.io/vegacapsule/state"
-	"code.vegaprotocol.io/vegacapsule/types"
-	"code.vegaprotocol.io/vegacapsule/utils"
 
 	"github.com/spf13/cobra"
 )
@@ -22,35 +18,9 @@ var (
 var nodesStartCmd = &cobra.Command{
 	Use:   "start",
 	Short: "Start running node set",
-	RunE: func(cmd *cobra.Command, args []string) error {
-		networkState, err := state.LoadNetworkState(homePath)
-		if err != nil {
-			return fmt.Errorf("failed load network state: %w", err)
-		}
-
-		if networkState.Empty() {
-			return networkNotBootstrappedErr("nodes start")
-		}
-
-		nodeSet, err := networkState.GeneratedServices.GetNodeSet(nodeName)
-		if err != nil {
-			return err
-		}
-
-		nomadJobID, err := nodesStartNode(
-			context.Background(),
-			nodeSet,
-			networkState.Config,
-			vegaBinary,
-		)
-		if err != nil {
-			return fmt.Errorf("failed start node: %w", err)
-		}
-
-		networkState.RunningJobs.NodesSetsJobIDs[nomadJobID] = true
-
-		return networkState.Persist()
-	},
+	RunE: withNetworkLock(func(cmd *cobra.Command, args []string) error {
+		return runNodesStart(context.Background(), nodeName, vegaBinary)
+	}),
 }
 
 func init() {
@@ -72,41 +42,17 @@ func init() {
 	nodesStartCmd.MarkFlagRequired("name")
 }
 
-func nodesStartNode(ctx context.Context, nodeSet *types.NodeSet, conf *config.Config, vegaBinary string) (string, error) {
-	log.Printf("starting %s node set", nodeSet.Name)
-
-	nomadClient, err := nomad.NewClient(nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create nomad client: %w", err)
-	}
-
-	nomadRunner, err := nomad.NewJobRunner(nomadClient, *conf.VegaCapsuleBinary, conf.LogsDir())
+// runNodesStart starts the node set and persists the network state.
+// The network state lock has to be held by the caller.
+func runNodesStart(ctx context.Context, name, vegaBinary string) error {
+	networkState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
 	if err != nil {
-		return "", fmt.Errorf("failed to create job runner: %w", err)
-	}
-
-	if _, err := nomadRunner.RunRawNomadJobs(ctx, nodeSet.PreGenerateRawJobs()); err != nil {
-		return "", fmt.Errorf("failed to start node set %q pre generate jobs: %w", nodeSet.Name, err)
-	}
-
-	if vegaBinary != "" {
-		vegaBinPath, err := utils.BinaryAbsPath(vegaBinary)
-		if err != nil {
-			return "", fmt.Errorf("failed to get absolute path for %q: %w", vegaBinary, err)
-		}
-
-		nodeSet.Vega.BinaryPath = vegaBinPath
-
-		if nodeSet.DataNode != nil {
-			nodeSet.DataNode.BinaryPath = vegaBinPath
-		}
+		return fmt.Errorf("failed load network state: %w", err)
 	}
 
-	res, err := nomadRunner.RunNodeSets(ctx, []types.NodeSet{*nodeSet}, !doNotStopAllJobsOnFailure)
-	if err != nil {
-		return "", fmt.Errorf("failed to start nomad node set %q : %w", nodeSet.Name, err)
+	if networkState.Empty() {
+		return networkNotBootstrappedErr("nodes start")
 	}
 
-	log.Printf("starting %s node set success", nodeSet.Name)
-	return *res[0].ID, nil
+	return newNetwork(networkState).StartNodeSet(ctx, name, capsule.WithVegaBinary(vegaBinary))
 }
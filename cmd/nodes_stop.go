@@ -3,9 +3,8 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"log"
 
-	"code.vegaprotocol.io/vegacapsule/nomad"
+	"code.vegaprotocol.io/vegacapsule/capsule"
 	"code.vegaprotocol.io/vegacapsule/state"
 
 	"github.com/spf13/cobra"
@@ -16,23 +15,9 @@ var stopWithPreGenenerate bool
 var nodesStopCmd = &cobra.Command{
 	Use:   "stop",
 	Short: "Stop running node set",
-	RunE: func(cmd *cobra.Command, args []string) error {
-		networkState, err := state.LoadNetworkState(homePath)
-		if err != nil {
-			return fmt.Errorf("failed load network state: %w", err)
-		}
-
-		if networkState.Empty() {
-			return networkNotBootstrappedErr("nodes stop")
-		}
-
-		updatedNetworkState, err := nodesStopNode(context.Background(), *networkState, nodeName, stopWithPreGenenerate)
-		if err != nil {
-			return fmt.Errorf("failed stop node: %w", err)
-		}
-
-		return updatedNetworkState.Persist()
-	},
+	RunE: withNetworkLock(func(cmd *cobra.Command, args []string) error {
+		return runNodesStop(context.Background(), nodeName, stopWithPreGenenerate)
+	}),
 }
 
 func init() {
@@ -49,38 +34,29 @@ func init() {
 	nodesStopCmd.MarkFlagRequired("name")
 }
 
-func nodesStopNode(ctx context.Context, state state.NetworkState, name string, stopPreGen bool) (*state.NetworkState, error) {
-	log.Printf("stopping %s node set", name)
-
-	ns, err := state.GeneratedServices.GetNodeSet(name)
+// runNodesStop stops the node set and persists the network state.
+// The network state lock has to be held by the caller.
+func runNodesStop(ctx context.Context, name string, stopPreGen bool) error {
+	networkState, err := state.LoadNetworkState(homePath, stateLoadOptsWithoutSecrets()...)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("failed load network state: %w", err)
 	}
 
-	nomadClient, err := nomad.NewClient(nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create nomad client: %w", err)
+	if networkState.Empty() {
+		return networkNotBootstrappedErr("nodes stop")
 	}
 
-	nomadRunner, err := nomad.NewJobRunner(nomadClient, *state.Config.VegaCapsuleBinary, state.Config.LogsDir())
-	if err != nil {
-		return nil, fmt.Errorf("failed to create job runner: %w", err)
+	if err := newNetwork(networkState).StopNodeSet(ctx, name, nodesStopOptions(stopPreGen)...); err != nil {
+		return fmt.Errorf("failed stop node: %w", err)
 	}
 
-	toRemove := []string{name}
-	if stopPreGen {
-		toRemove = append(toRemove, ns.PreGenerateJobsIDs()...)
-	}
-
-	stoppedJobs, err := nomadRunner.StopJobs(ctx, toRemove)
-	if err != nil {
-		return nil, fmt.Errorf("failed to stop nomad job %q: %w", name, err)
-	}
+	return nil
+}
 
-	if state.RunningJobs != nil {
-		state.RunningJobs.RemoveRunningJobsIDs(stoppedJobs)
+func nodesStopOptions(stopPreGen bool) []capsule.StopOption {
+	if stopPreGen {
+		return nil
 	}
 
-	log.Printf("stopping %s node set success", name)
-	return &state, nil
+	return []capsule.StopOption{capsule.KeepPreGenerateJobs()}
 }
@@ -1,20 +1,99 @@
 package cmd
 
 import (
+	"bytes"
+	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/state"
 
 	"github.com/spf13/cobra"
 )
 
-var homePath string
+var (
+	homePath            string
+	lockTimeout         time.Duration
+	statePassphraseFile string
+
+	// stateLoadOpts are options used to load network state by all commands
+	stateLoadOpts []state.LoadOption
+)
+
+const defaultLockTimeout = time.Second * 30
 
 var rootCmd = &cobra.Command{
 	Use:   os.Args[0],
 	Short: "Tool for generating and running vega network",
 	Long:  "Configuration based tool for bootstraping and managing vega network. Primary usages are local development of Vega, testing but also deploy new production network.",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := resolveHomePath(cmd); err != nil {
+			return err
+		}
+
+		passphrase, err := loadStatePassphrase(statePassphraseFile)
+		if err != nil {
+			return err
+		}
+
+		if passphrase != nil {
+			stateLoadOpts = append(stateLoadOpts, state.WithPassphrase(passphrase))
+		}
+
+		return nil
+	},
+}
+
+// stateLoadOptsWithoutSecrets returns options used to load network state by commands that don't use secrets,
+// so they can be run against encrypted state without passphrase.
+func stateLoadOptsWithoutSecrets() []state.LoadOption {
+	return append(append([]state.LoadOption{}, stateLoadOpts...), state.WithoutSecrets())
+}
+
+// loadStatePassphrase returns passphrase for encrypted network state from given file
+// or from the environment variable. Nil is returned when no passphrase is set.
+func loadStatePassphrase(passphraseFile string) ([]byte, error) {
+	if passphraseFile != "" {
+		passphrase, err := os.ReadFile(passphraseFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read network state passphrase file: %w", err)
+		}
+
+		return bytes.TrimSpace(passphrase), nil
+	}
+
+	if passphrase, ok := os.LookupEnv(state.PassphraseEnv); ok && passphrase != "" {
+		return []byte(passphrase), nil
+	}
+
+	return nil, nil
+}
+
+// withNetworkLock holds exclusive lock of the network state for the whole run of the command,
+// so concurrent Capsule commands using the same home path can't overwrite each other's changes.
+func withNetworkLock(runE func(cmd *cobra.Command, args []string) error) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		return lockNetwork(func() error {
+			return runE(cmd, args)
+		})
+	}
+}
+
+// lockNetwork holds exclusive lock of the network state while f runs.
+func lockNetwork(f func() error) error {
+	lock, err := state.LockNetworkState(homePath, lockTimeout)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := lock.Unlock(); err != nil {
+			log.Printf("failed to release network state lock: %s", err)
+		}
+	}()
+
+	return f()
 }
 
 // Execute executes the root command.
@@ -33,9 +112,20 @@ func init() {
 		defaultHomePath,
 		"Specify the location of network home directory",
 	)
+	rootCmd.PersistentFlags().DurationVar(&lockTimeout,
+		"lock-timeout",
+		defaultLockTimeout,
+		"How long to wait for other Capsule commands modifying the same network to finish",
+	)
+	rootCmd.PersistentFlags().StringVar(&statePassphraseFile,
+		"state-passphrase-file",
+		"",
+		fmt.Sprintf("Path to a file with passphrase used to encrypt secrets in network state. The %s environment variable can be used instead", state.PassphraseEnv),
+	)
 
 	rootCmd.AddCommand(networkCmd)
 	rootCmd.AddCommand(nomadCmd)
+	rootCmd.AddCommand(supervisorCmd)
 	rootCmd.AddCommand(nodesCmd)
 	rootCmd.AddCommand(stateCmd)
 	rootCmd.AddCommand(ethereumCmd)
@@ -44,4 +134,7 @@ func init() {
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(logsCmd)
 	rootCmd.AddCommand(jobsCmd)
+	rootCmd.AddCommand(dashboardCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(chaosCmd)
 }
@@ -0,0 +1,333 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"code.vegaprotocol.io/vegacapsule/api"
+	"code.vegaprotocol.io/vegacapsule/capsule"
+	"code.vegaprotocol.io/vegacapsule/ports"
+	"code.vegaprotocol.io/vegacapsule/state"
+	"code.vegaprotocol.io/vegacapsule/types"
+
+	vgtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cobra"
+)
+
+const serveShutdownTimeout = 10 * time.Second
+
+var (
+	serveAddress      string
+	servePrintOpenAPI bool
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serves local HTTP/JSON API controlling the network",
+	Long: `Serves local REST API for the main operations on the network - bootstrapping, starting and stopping the network,
+managing node sets, listing addresses, fetching logs, depositing, staking and minting assets and protocol upgrades.
+The API runs the same code as the commands and returns structured JSON errors. The OpenAPI document is served on /openapi.json.`,
+	Example: `# Serve the API on the default address
+vegacapsule serve
+
+# Print the OpenAPI document of the API
+vegacapsule serve --openapi`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if servePrintOpenAPI {
+			doc, err := api.OpenAPI()
+			if err != nil {
+				return fmt.Errorf("failed to generate OpenAPI document: %w", err)
+			}
+
+			fmt.Println(string(doc))
+			return nil
+		}
+
+		srv := &http.Server{
+			Addr:              serveAddress,
+			Handler:           api.NewHandler(capsuleAPI{}),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+
+		go func() {
+			sig := <-sigs
+			log.Printf("Received signal: %s", sig)
+
+			ctx, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+			defer cancel()
+
+			if err := srv.Shutdown(ctx); err != nil {
+				log.Printf("failed to shutdown server: %s", err)
+			}
+		}()
+
+		log.Printf("serving Capsule API on http://%s", serveAddress)
+
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("failed to serve API: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	serveCmd.PersistentFlags().StringVar(&serveAddress,
+		"address",
+		"127.0.0.1:6070",
+		"Address the API listens on",
+	)
+	serveCmd.PersistentFlags().BoolVar(&servePrintOpenAPI,
+		"openapi",
+		false,
+		"Print the OpenAPI document of the API and exit",
+	)
+}
+
+// capsuleAPI runs the API operations with the functions behind the commands,
+// operations changing the network hold the network state lock as the commands do.
+type capsuleAPI struct{}
+
+func (capsuleAPI) BootstrapNetwork(ctx context.Context, req api.BootstrapNetworkRequest) error {
+	return apiError(lockNetwork(func() error {
+		return runNetBootstrap(ctx, netBootstrapArgs{
+			configPath: req.ConfigPath,
+			seed:       req.Seed,
+			releaseTag: req.InstallReleaseTag,
+			force:      req.Force,
+		})
+	}))
+}
+
+func (capsuleAPI) StartNetwork(ctx context.Context) error {
+	return apiError(lockNetwork(func() error {
+		return runNetStart(ctx)
+	}))
+}
+
+func (capsuleAPI) StopNetwork(ctx context.Context, req api.StopNetworkRequest) error {
+	return apiError(lockNetwork(func() error {
+		return runNetStop(ctx, req.NodesOnly)
+	}))
+}
+
+func (capsuleAPI) NetworkAddresses(ctx context.Context) (*api.NetworkAddresses, error) {
+	netState, err := loadRunningNetworkState("list addresses of")
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	jobsPorts, err := newNetwork(netState).Addresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := &api.NetworkAddresses{
+		Jobs:           []api.JobAddresses{},
+		AllocatedPorts: toAPIAddresses(netState.Ports.AllocatedPorts()),
+	}
+	for _, job := range jobsPorts {
+		addresses.Jobs = append(addresses.Jobs, api.JobAddresses{
+			Job:       job.Name,
+			Task:      job.TaskName,
+			Addresses: toAPIAddresses(job.Ports),
+		})
+	}
+
+	return addresses, nil
+}
+
+func (capsuleAPI) ProtocolUpgrade(ctx context.Context, req api.ProtocolUpgradeRequest) error {
+	return apiError(runNodesProtocolUpgrade(nodesProtocolUpgradeArgs{
+		height:       req.Height,
+		releaseTag:   req.ReleaseTag,
+		templatePath: req.TemplatePath,
+		propose:      req.Propose,
+		force:        req.Force,
+		include:      req.IncludeNodeSets,
+		exclude:      req.ExcludeNodeSets,
+	}))
+}
+
+func (capsuleAPI) NodeSets(ctx context.Context) ([]api.NodeSet, error) {
+	netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if netState.Empty() {
+		return nil, apiError(networkNotBootstrappedErr("list node sets of"))
+	}
+
+	nodeSets := []api.NodeSet{}
+	for _, ns := range netState.GeneratedServices.NodeSets.ToSlice() {
+		running := netState.RunningJobs != nil && netState.RunningJobs.NodesSetsJobIDs[ns.Name]
+		nodeSets = append(nodeSets, toAPINodeSet(ns, running))
+	}
+
+	return nodeSets, nil
+}
+
+func (capsuleAPI) AddNodeSets(ctx context.Context, req api.AddNodeSetsRequest) ([]api.NodeSet, error) {
+	var newNodeSets []*types.NodeSet
+
+	err := lockNetwork(func() error {
+		var err error
+		newNodeSets, err = runNodesAdd(ctx, nodesAddArgs{
+			count:       req.Count,
+			baseOnNode:  req.BaseOn,
+			baseOnGroup: req.BaseOnGroup,
+			start:       *req.Start,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	nodeSets := []api.NodeSet{}
+	for _, ns := range newNodeSets {
+		nodeSets = append(nodeSets, toAPINodeSet(*ns, *req.Start))
+	}
+
+	return nodeSets, nil
+}
+
+func (capsuleAPI) RemoveNodeSet(ctx context.Context, name string) error {
+	return apiError(lockNetwork(func() error {
+		return runNodesRemove(ctx, name)
+	}))
+}
+
+func (capsuleAPI) StartNodeSet(ctx context.Context, name string) error {
+	return apiError(lockNetwork(func() error {
+		return runNodesStart(ctx, name, "")
+	}))
+}
+
+func (capsuleAPI) StopNodeSet(ctx context.Context, name string) error {
+	return apiError(lockNetwork(func() error {
+		return runNodesStop(ctx, name, true)
+	}))
+}
+
+func (capsuleAPI) Logs(ctx context.Context, jobID string, lines int) (*api.Logs, error) {
+	netState, err := state.LoadNetworkState(homePath, stateLoadOptsWithoutSecrets()...)
+	if err != nil {
+		return nil, err
+	}
+
+	if netState.Empty() {
+		return nil, apiError(networkNotBootstrappedErr("get logs of"))
+	}
+
+	logLines, err := newNetwork(netState).Logs(jobID, lines)
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	if logLines == nil {
+		logLines = []string{}
+	}
+
+	return &api.Logs{Job: jobID, Lines: logLines}, nil
+}
+
+func (capsuleAPI) DepositAsset(ctx context.Context, assetSymbol string, req api.DepositAssetRequest) (*api.Transaction, error) {
+	args, err := newEthereumAssetDepositArgs(assetSymbol, req.PubKey, req.Amount, req.Bridge)
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	return toAPITransaction(ethereumAssetDeposit(ctx, *args))
+}
+
+func (capsuleAPI) StakeAsset(ctx context.Context, assetSymbol string, req api.StakeAssetRequest) (*api.Transaction, error) {
+	args, err := newEthereumAssetStakeArgs(assetSymbol, req.PubKey, req.Amount)
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	return toAPITransaction(ethereumAssetStake(ctx, *args))
+}
+
+func (capsuleAPI) MintAsset(ctx context.Context, assetSymbol string, req api.MintAssetRequest) (*api.Transaction, error) {
+	args, err := newEthereumAssetMintArgs(assetSymbol, req.ToAddress, req.Amount, req.Bridge)
+	if err != nil {
+		return nil, apiError(err)
+	}
+
+	return toAPITransaction(ethereumAssetMint(ctx, *args))
+}
+
+func loadRunningNetworkState(action string) (*state.NetworkState, error) {
+	netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if netState.Empty() {
+		return nil, networkNotBootstrappedErr(action)
+	}
+
+	if !netState.Running() {
+		return nil, networkNotRunningErr(action)
+	}
+
+	return netState, nil
+}
+
+// apiError sets HTTP status of the known errors.
+func apiError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, types.ErrNodeSetNotFound), errors.Is(err, errAssetNotFound), errors.Is(err, capsule.ErrJobLogsNotFound):
+		return api.NotFound(err)
+	case errors.Is(err, errNetworkNotBootstrapped), errors.Is(err, errNetworkNotRunning), errors.Is(err, state.ErrLockTimeout):
+		return api.Conflict(err)
+	}
+
+	return err
+}
+
+func toAPINodeSet(ns types.NodeSet, running bool) api.NodeSet {
+	return api.NodeSet{
+		Name:        ns.Name,
+		GroupName:   ns.GroupName,
+		Index:       ns.Index,
+		Mode:        ns.Mode,
+		Running:     running,
+		HasDataNode: ns.DataNode != nil,
+		HasVisor:    ns.Visor != nil,
+	}
+}
+
+func toAPIAddresses(portsWithName []ports.PortWithName) []api.Address {
+	addresses := []api.Address{}
+	for _, p := range portsWithName {
+		addresses = append(addresses, api.Address{
+			Name:    p.Name,
+			Address: fmt.Sprintf("localhost:%d", p.Port),
+		})
+	}
+	return addresses
+}
+
+func toAPITransaction(tx *vgtypes.Transaction, err error) (*api.Transaction, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.Transaction{Hash: tx.Hash().Hex()}, nil
+}
@@ -16,13 +16,17 @@ var stateCmd = &cobra.Command{
 func init() {
 	stateCmd.AddCommand(stateGetSmartContractsAddressesCmd)
 	stateCmd.AddCommand(stateGetSecondarySmartContractsAddressesCmd)
+	stateCmd.AddCommand(stateMigrateCmd)
+	stateCmd.AddCommand(stateRekeyCmd)
+	stateCmd.AddCommand(stateShowCmd)
+	stateCmd.AddCommand(stateGetCmd)
 }
 
 var stateGetSmartContractsAddressesCmd = &cobra.Command{
 	Use:   "get-smartcontracts-addresses",
 	Short: "Print primary smartcontracts addresses and keys passed to vegacapsule as a config parameter",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		netState, err := state.LoadNetworkState(homePath)
+		netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
 		if err != nil {
 			return err
 		}
@@ -41,7 +45,7 @@ var stateGetSecondarySmartContractsAddressesCmd = &cobra.Command{
 	Use:   "get-secondary-smartcontracts-addresses",
 	Short: "Print secondary smartcontracts addresses and keys passed to vegacapsule as a config parameter",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		netState, err := state.LoadNetworkState(homePath)
+		netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
 		if err != nil {
 			return err
 		}
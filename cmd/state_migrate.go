@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+
+	"code.vegaprotocol.io/vegacapsule/state"
+
+	"github.com/spf13/cobra"
+)
+
+var stateMigrateDryRun bool
+
+var stateMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrates network state to the latest format supported by this Capsule version",
+	Long: `Network state is migrated automatically every time it is loaded by any command.
+This command allows to see what would change and to persist the migrated state explicitly.`,
+	Example: `# Show which migrations would be applied to the network state
+vegacapsule state migrate --dry-run --home-path=/var/tmp/veganetwork/testnetwork`,
+	RunE: withNetworkLock(func(cmd *cobra.Command, args []string) error {
+		result, err := state.MigrateNetworkState(homePath, stateMigrateDryRun)
+		if err != nil {
+			return fmt.Errorf("failed to migrate network state: %w", err)
+		}
+
+		if result.UpToDate() {
+			fmt.Printf("Network state is up to date (version %d)\n", result.ToVersion)
+			return nil
+		}
+
+		if stateMigrateDryRun {
+			fmt.Printf("Network state would be migrated from version %d to %d\n", result.FromVersion, result.ToVersion)
+		} else {
+			fmt.Printf("Network state has been migrated from version %d to %d\n", result.FromVersion, result.ToVersion)
+		}
+
+		fmt.Println("Migrations:")
+		for _, step := range result.Steps {
+			fmt.Printf("  - v%d -> v%d: %s\n", step.FromVersion, step.FromVersion+1, step.Description)
+		}
+
+		if len(result.Changes) == 0 {
+			fmt.Println("No changes in the state content.")
+			return nil
+		}
+
+		fmt.Println("Changes:")
+		for _, change := range result.Changes {
+			fmt.Printf("  %s\n", change)
+		}
+
+		return nil
+	}),
+}
+
+func init() {
+	stateMigrateCmd.PersistentFlags().BoolVar(&stateMigrateDryRun,
+		"dry-run",
+		false,
+		"Only print what would change without persisting the migrated state",
+	)
+}
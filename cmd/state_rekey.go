@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+
+	"code.vegaprotocol.io/vegacapsule/state"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	stateRekeyNewPassphraseFile string
+	stateRekeyRemoveEncryption  bool
+)
+
+var stateRekeyCmd = &cobra.Command{
+	Use:   "rekey",
+	Short: "Changes passphrase used to encrypt secrets in network state",
+	Long: `Re-encrypts secrets in network state with a new passphrase.
+The current passphrase is taken from the --state-passphrase-file flag or from the environment variable.
+It can also be used to encrypt plain network state or to remove the encryption completely.`,
+	Example: `# Change the passphrase of encrypted network state
+vegacapsule state rekey --state-passphrase-file old.txt --new-passphrase-file new.txt
+
+# Remove the encryption of the network state
+vegacapsule state rekey --state-passphrase-file old.txt --remove-encryption`,
+	RunE: withNetworkLock(func(cmd *cobra.Command, args []string) error {
+		if (stateRekeyNewPassphraseFile == "") == !stateRekeyRemoveEncryption {
+			return fmt.Errorf("either --new-passphrase-file or --remove-encryption has to be provided")
+		}
+
+		netState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
+		if err != nil {
+			return err
+		}
+
+		if netState.Empty() {
+			return networkNotBootstrappedErr("state rekey")
+		}
+
+		var newPassphrase []byte
+		if !stateRekeyRemoveEncryption {
+			newPassphrase, err = loadStatePassphrase(stateRekeyNewPassphraseFile)
+			if err != nil {
+				return err
+			}
+
+			if len(newPassphrase) == 0 {
+				return fmt.Errorf("new network state passphrase must not be empty")
+			}
+		}
+
+		if err := netState.SetPassphrase(newPassphrase); err != nil {
+			return err
+		}
+
+		if err := netState.Persist(); err != nil {
+			return err
+		}
+
+		if stateRekeyRemoveEncryption {
+			fmt.Println("Network state encryption has been removed")
+		} else {
+			fmt.Println("Network state has been encrypted with the new passphrase")
+		}
+
+		return nil
+	}),
+}
+
+func init() {
+	stateRekeyCmd.PersistentFlags().StringVar(&stateRekeyNewPassphraseFile,
+		"new-passphrase-file",
+		"",
+		"Path to a file with the new passphrase",
+	)
+	stateRekeyCmd.PersistentFlags().BoolVar(&stateRekeyRemoveEncryption,
+		"remove-encryption",
+		false,
+		"Store secrets in network state as a plain text",
+	)
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"code.vegaprotocol.io/vegacapsule/state"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputFormatJSON = "json"
+	outputFormatYAML = "yaml"
+)
+
+var stateShowFlags = struct {
+	output string
+	redact bool
+}{}
+
+var stateShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print decoded network state",
+	Example: `# Print network state as YAML without wallet secrets
+vegacapsule state show --output yaml --redact`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rawState, err := loadRawNetworkState("state show")
+		if err != nil {
+			return err
+		}
+
+		return printStructured(rawState, stateShowFlags.output)
+	},
+}
+
+var stateGetCmd = &cobra.Command{
+	Use:   "get <path>",
+	Short: "Print part of the network state selected by JSONPath-like selector",
+	Long: `Print part of the network state selected by JSONPath-like selector.
+
+Selector is a dot separated list of keys. List items are selected by index (nodes.0 or nodes[0]).
+Keys are matched case insensitively and underscores are ignored, so home_dir matches HomeDir.
+Generated services (node sets, wallet, faucet) can be selected directly.
+Scalar values are printed as they are so they can be easily used in scripts.`,
+	Example: `# Print home directory of a Vega node
+vegacapsule state get nodesets.testnet-nodeset-validators-0-validator.vega.home_dir
+
+# Print Ethereum endpoint of the network
+vegacapsule state get config.network.ethereum.endpoint`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rawState, err := loadRawNetworkState("state get")
+		if err != nil {
+			return err
+		}
+
+		value, err := state.Query(rawState, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get %q from network state: %w", args[0], err)
+		}
+
+		switch v := value.(type) {
+		case map[string]interface{}, []interface{}:
+			return printStructured(v, stateShowFlags.output)
+		case string:
+			fmt.Println(v)
+		default:
+			// numbers, booleans and null are printed in their JSON form
+			out, err := json.Marshal(v)
+			if err != nil {
+				return fmt.Errorf("failed to marshal value: %w", err)
+			}
+			fmt.Println(string(out))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{stateShowCmd, stateGetCmd} {
+		c.PersistentFlags().StringVar(&stateShowFlags.output,
+			"output",
+			outputFormatJSON,
+			"Output format: json or yaml",
+		)
+		c.PersistentFlags().BoolVar(&stateShowFlags.redact,
+			"redact",
+			false,
+			"Hide wallet secrets and passphrases",
+		)
+	}
+}
+
+func loadRawNetworkState(cmdName string) (map[string]interface{}, error) {
+	loadOpts := stateLoadOpts
+	if stateShowFlags.redact {
+		// redacted secrets are not printed, so they don't need to be decrypted
+		loadOpts = stateLoadOptsWithoutSecrets()
+	}
+
+	netState, err := state.LoadNetworkState(homePath, loadOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load network state: %w", err)
+	}
+
+	if netState.Empty() {
+		return nil, networkNotBootstrappedErr(cmdName)
+	}
+
+	return netState.ToRaw(stateShowFlags.redact)
+}
+
+func printStructured(v interface{}, format string) error {
+	var (
+		out []byte
+		err error
+	)
+
+	switch format {
+	case outputFormatJSON:
+		out, err = json.MarshalIndent(v, "", "\t")
+	case outputFormatYAML:
+		out, err = state.MarshalYAML(v)
+	default:
+		return fmt.Errorf("unsupported output format %q, supported formats: %s, %s", format, outputFormatJSON, outputFormatYAML)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal output to %s: %w", format, err)
+	}
+
+	fmt.Println(string(out))
+
+	return nil
+}
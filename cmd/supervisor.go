@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"code.vegaprotocol.io/vegacapsule/supervisor"
+
+	"github.com/spf13/cobra"
+)
+
+var supervisorStateFile string
+
+var supervisorCmd = &cobra.Command{
+	Use:   "supervisor",
+	Short: "Manages processes run by the native job runner",
+}
+
+var supervisorRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Runs tasks of a job as child processes and supervises them. It's started by the native job runner for every job.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s, err := supervisor.New(supervisorStateFile)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(cmd.Context())
+		defer cancel()
+
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+
+		go func() {
+			sig := <-sigs
+			log.Printf("Received signal: %s", sig)
+			cancel()
+		}()
+
+		return s.Run(ctx)
+	},
+}
+
+func init() {
+	supervisorRunCmd.PersistentFlags().StringVar(&supervisorStateFile,
+		"state-file",
+		"",
+		"Path to the state file of the job",
+	)
+	supervisorRunCmd.MarkPersistentFlagRequired("state-file") // nolint:errcheck
+
+	supervisorCmd.AddCommand(supervisorRunCmd)
+}
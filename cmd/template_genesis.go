@@ -22,7 +22,7 @@ var templateGenesisCmd = &cobra.Command{
 			return fmt.Errorf("failed to read template %q: %w", templatePath, err)
 		}
 
-		networkState, err := state.LoadNetworkState(homePath)
+		networkState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
 		if err != nil {
 			return fmt.Errorf("failed to load network state: %w", err)
 		}
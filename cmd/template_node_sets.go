@@ -39,7 +39,7 @@ var templateNodeSetsCmd = &cobra.Command{
 			return fmt.Errorf("failed to read template %q: %w", templatePath, err)
 		}
 
-		networkState, err := state.LoadNetworkState(homePath)
+		networkState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
 		if err != nil {
 			return fmt.Errorf("failed to load network state: %w", err)
 		}
@@ -112,7 +112,7 @@ func templateNodeSets(tmplType templateKindType, templateRaw string, netState *s
 
 	switch tmplType {
 	case tendermintNodeSetTemplateType:
-		tmpl, err := tendermint.NewConfigTemplate(templateRaw)
+		tmpl, err := tendermint.NewConfigTemplate(templateRaw, netState.Config.TemplateFuncs())
 		if err != nil {
 			return err
 		}
@@ -124,7 +124,7 @@ func templateNodeSets(tmplType templateKindType, templateRaw string, netState *s
 
 		return templateNodeSetConfig(gen.TemplateConfig, gen.TemplateAndMergeConfig, tmplType, tmpl, nodeSets)
 	case vegaNodeSetTemplateType:
-		tmpl, err := vega.NewConfigTemplate(templateRaw)
+		tmpl, err := vega.NewConfigTemplate(templateRaw, netState.Config.TemplateFuncs())
 		if err != nil {
 			return err
 		}
@@ -144,7 +144,7 @@ func templateNodeSets(tmplType templateKindType, templateRaw string, netState *s
 
 		return templateNodeSetConfig(templateF, templateAndMergeF, tmplType, tmpl, nodeSets)
 	case dataNodeNodeSetTemplateType:
-		tmpl, err := datanode.NewConfigTemplate(templateRaw)
+		tmpl, err := datanode.NewConfigTemplate(templateRaw, netState.Config.TemplateFuncs())
 		if err != nil {
 			return err
 		}
@@ -156,7 +156,7 @@ func templateNodeSets(tmplType templateKindType, templateRaw string, netState *s
 
 		return templateNodeSetConfig(gen.TemplateConfig, gen.TemplateAndMergeConfig, tmplType, tmpl, nodeSets)
 	case visorRunNodeSetTemplateType:
-		tmpl, err := visor.NewConfigTemplate(templateRaw)
+		tmpl, err := visor.NewConfigTemplate(templateRaw, netState.Config.TemplateFuncs())
 		if err != nil {
 			return err
 		}
@@ -18,13 +18,13 @@ import (
 var templateNomadCmd = &cobra.Command{
 	Use:   "nomad",
 	Short: "Template Nomad job configuration for specific node set",
-	RunE: func(cmd *cobra.Command, args []string) error {
+	RunE: withNetworkLock(func(cmd *cobra.Command, args []string) error {
 		template, err := os.ReadFile(templatePath)
 		if err != nil {
 			return fmt.Errorf("failed to read template %q: %w", templatePath, err)
 		}
 
-		networkState, err := state.LoadNetworkState(homePath)
+		networkState, err := state.LoadNetworkState(homePath, stateLoadOpts...)
 		if err != nil {
 			return fmt.Errorf("failed to load network state: %w", err)
 		}
@@ -46,7 +46,7 @@ var templateNomadCmd = &cobra.Command{
 		}
 
 		return nil
-	},
+	}),
 	Example: `
 # Generate the nomad configuration for multiple node sets #1
 vegacapsule template nomad --path .../node_set.tmpl --nodeset-group-name validators,full
@@ -77,7 +77,7 @@ func templateNomad(templateRaw string, netState *state.NetworkState, templateOut
 
 	newNetworkState := *netState
 	for _, ns := range nodeSets {
-		buff, err := nomad.GenerateNodeSetTemplate(templateRaw, ns)
+		buff, err := nomad.GenerateNodeSetTemplate(templateRaw, ns, netState.Config.TemplateFuncs())
 		if err != nil {
 			return nil, err
 		}
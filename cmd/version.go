@@ -41,7 +41,7 @@ var versionCmd = &cobra.Command{
 			return nil
 		}
 
-		netState, err := state.LoadNetworkState(homePath)
+		netState, err := state.LoadNetworkState(homePath, stateLoadOptsWithoutSecrets()...)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,226 @@
+package compose
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/supervisor"
+	"code.vegaprotocol.io/vegacapsule/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	DefaultImage = "debian:bookworm-slim"
+
+	// the same as kill timeout of the Nomad jobs
+	stopGracePeriod = "20s"
+	networkModeHost = "host"
+	restartNo       = "no"
+)
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// Service is a service of Docker Compose project. Only fields used by Capsule are defined.
+type Service struct {
+	Image           string            `yaml:"image"`
+	User            string            `yaml:"user,omitempty"`
+	Entrypoint      []string          `yaml:"entrypoint,omitempty"`
+	Command         []string          `yaml:"command,omitempty"`
+	Environment     map[string]string `yaml:"environment,omitempty"`
+	NetworkMode     string            `yaml:"network_mode,omitempty"`
+	Ports           []string          `yaml:"ports,omitempty"`
+	Volumes         []string          `yaml:"volumes,omitempty"`
+	DependsOn       []string          `yaml:"depends_on,omitempty"`
+	Restart         string            `yaml:"restart,omitempty"`
+	StopGracePeriod string            `yaml:"stop_grace_period,omitempty"`
+}
+
+type Project struct {
+	Name     string             `yaml:"name"`
+	Services map[string]Service `yaml:"services"`
+}
+
+type Options struct {
+	// Image runs the Vega binaries, the binaries and the network home are bind-mounted into it.
+	Image string
+	// User runs the Vega binaries, so files in the network home keep their owner.
+	User string
+}
+
+// New creates Docker Compose project running the same tasks as the default Nomad jobs of the network.
+// Network home and binaries are bind-mounted to the same paths as they have on the host and Vega services
+// use host network, so generated configs of the nodes don't need any change.
+func New(conf *config.Config, genServices types.GeneratedServices, opts Options) (*Project, error) {
+	if opts.Image == "" {
+		opts.Image = DefaultImage
+	}
+
+	p := &Project{
+		Name:     serviceName(conf.Network.Name),
+		Services: map[string]Service{},
+	}
+
+	if len(genServices.PreGenerateJobs) != 0 {
+		log.Println("pre generate Nomad jobs can't be exported and are left out")
+	}
+
+	tasks := []supervisor.Task{}
+	// visor runs the Vega binary from its run config
+	extraMounts := []string{*conf.VegaBinary}
+
+	for _, ns := range genServices.NodeSets.ToSlice() {
+		if ns.NomadJobRaw != nil {
+			log.Printf("node set %q has custom Nomad job definition, it's exported with the default one", ns.Name)
+		}
+
+		tasks = append(tasks, supervisor.NodeSetTasks(ns)...)
+	}
+
+	if w := genServices.Wallet; w != nil {
+		task := supervisor.WalletTask(w)
+		task.Name = w.Name
+		tasks = append(tasks, task)
+
+		if w.TokenPassphrasePath != "" {
+			extraMounts = append(extraMounts, w.TokenPassphrasePath)
+		}
+	}
+
+	if fc := genServices.Faucet; fc != nil && conf.Network.Faucet != nil {
+		task := supervisor.FaucetTask(conf.Network.Faucet, fc)
+		task.Name = fc.Name
+		tasks = append(tasks, task)
+	}
+
+	var preStart, postStart []config.DockerConfig
+	if conf.Network.PreStart != nil {
+		preStart = conf.Network.PreStart.Docker
+
+		for _, ec := range conf.Network.PreStart.Exec {
+			log.Printf("exec service %q can't run in Docker and is left out", ec.Name)
+		}
+	}
+	if conf.Network.PostStart != nil {
+		postStart = conf.Network.PostStart.Docker
+	}
+
+	preStartNames, err := p.addDockerServices(preStart, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	volumes := mounts(*conf.OutputDir, tasks, extraMounts)
+
+	vegaNames := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		name, err := p.add(t.Name, Service{
+			Image:           opts.Image,
+			User:            opts.User,
+			Entrypoint:      []string{t.Command},
+			Command:         t.Args,
+			Environment:     t.Env,
+			NetworkMode:     networkModeHost,
+			Volumes:         volumes,
+			DependsOn:       preStartNames,
+			Restart:         restartNo,
+			StopGracePeriod: stopGracePeriod,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		vegaNames = append(vegaNames, name)
+	}
+	sort.Strings(vegaNames)
+
+	if _, err := p.addDockerServices(postStart, vegaNames); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (p *Project) addDockerServices(dockerConfigs []config.DockerConfig, dependsOn []string) ([]string, error) {
+	names := make([]string, 0, len(dockerConfigs))
+
+	for _, dc := range dockerConfigs {
+		svc := Service{
+			Image:           dc.Image,
+			Environment:     dc.Env,
+			Volumes:         dc.VolumeMounts,
+			DependsOn:       dependsOn,
+			Restart:         restartNo,
+			StopGracePeriod: stopGracePeriod,
+		}
+
+		if dc.Command != "" {
+			svc.Command = append([]string{dc.Command}, dc.Args...)
+		} else if len(dc.Args) != 0 {
+			svc.Command = dc.Args
+		}
+
+		if dc.StaticPort != nil {
+			svc.Ports = []string{fmt.Sprintf("%d:%d", dc.StaticPort.Value, dc.StaticPort.To)}
+		}
+
+		name, err := p.add(dc.Name, svc)
+		if err != nil {
+			return nil, err
+		}
+
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (p *Project) add(name string, svc Service) (string, error) {
+	name = serviceName(name)
+
+	if _, ok := p.Services[name]; ok {
+		return "", fmt.Errorf("duplicate service name %q", name)
+	}
+	p.Services[name] = svc
+
+	return name, nil
+}
+
+func (p Project) Marshal() ([]byte, error) {
+	return yaml.Marshal(p)
+}
+
+// mounts returns bind mounts of the network home and all files outside of it used by the tasks.
+func mounts(networkHome string, tasks []supervisor.Task, extra []string) []string {
+	paths := map[string]bool{}
+	for _, t := range tasks {
+		if filepath.IsAbs(t.Command) {
+			paths[t.Command] = true
+		}
+	}
+	for _, e := range extra {
+		paths[e] = true
+	}
+
+	readOnly := make([]string, 0, len(paths))
+	for path := range paths {
+		if strings.HasPrefix(path, networkHome+string(filepath.Separator)) {
+			continue
+		}
+		readOnly = append(readOnly, fmt.Sprintf("%s:%s:ro", path, path))
+	}
+	sort.Strings(readOnly)
+
+	return append([]string{fmt.Sprintf("%s:%s", networkHome, networkHome)}, readOnly...)
+}
+
+// serviceName returns name valid for Docker Compose.
+func serviceName(name string) string {
+	return strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
@@ -0,0 +1,94 @@
+package compose_test
+
+import (
+	"testing"
+
+	"code.vegaprotocol.io/vegacapsule/compose"
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/types"
+	"code.vegaprotocol.io/vegacapsule/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	conf := &config.Config{
+		OutputDir:  utils.ToPoint("/home/capsule/testnet"),
+		VegaBinary: utils.ToPoint("/usr/local/bin/vega"),
+		Network: config.NetworkConfig{
+			Name:   "Testnet",
+			Faucet: &config.FaucetConfig{Name: "faucet-1"},
+			PreStart: &config.PStartConfig{
+				Docker: []config.DockerConfig{
+					{
+						Name:       "postgres-1",
+						Image:      "postgres:14",
+						Env:        map[string]string{"POSTGRES_USER": "vega"},
+						StaticPort: &config.StaticPort{Value: 5232, To: 5432},
+					},
+				},
+				Exec: []config.ExecConfig{{Name: "script", Command: "./script.sh"}},
+			},
+		},
+	}
+
+	genServices := types.GeneratedServices{
+		NodeSets: types.NodeSetMap{
+			"testnet-nodeset-full-0": types.NodeSet{
+				Name:       "testnet-nodeset-full-0",
+				Vega:       types.VegaNode{GeneratedService: types.GeneratedService{Name: "vega-full-0", HomeDir: "/home/capsule/testnet/vega/node0"}, BinaryPath: "/usr/local/bin/vega", NodeWalletPassFilePath: "/home/capsule/testnet/vega/node0/pass"},
+				Tendermint: types.TendermintNode{GeneratedService: types.GeneratedService{HomeDir: "/home/capsule/testnet/tendermint/node0"}},
+				DataNode:   &types.DataNode{GeneratedService: types.GeneratedService{Name: "data-node-0", HomeDir: "/home/capsule/testnet/data/node0"}, BinaryPath: "/usr/local/bin/vega"},
+			},
+		},
+		Faucet: &types.Faucet{
+			GeneratedService:   types.GeneratedService{Name: "testnet-faucet", HomeDir: "/home/capsule/testnet/faucet"},
+			WalletPassFilePath: "/home/capsule/testnet/faucet/pass",
+			BinaryPath:         "/home/capsule/testnet/bins/vega",
+		},
+	}
+
+	p, err := compose.New(conf, genServices, compose.Options{User: "1000:1000"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "testnet", p.Name)
+	assert.Len(t, p.Services, 4)
+
+	volumes := []string{
+		"/home/capsule/testnet:/home/capsule/testnet",
+		"/usr/local/bin/vega:/usr/local/bin/vega:ro",
+	}
+
+	assert.Equal(t, compose.Service{
+		Image:      compose.DefaultImage,
+		User:       "1000:1000",
+		Entrypoint: []string{"/usr/local/bin/vega"},
+		Command: []string{
+			"node",
+			"--home", "/home/capsule/testnet/vega/node0",
+			"--tendermint-home", "/home/capsule/testnet/tendermint/node0",
+			"--nodewallet-passphrase-file", "/home/capsule/testnet/vega/node0/pass",
+		},
+		NetworkMode:     "host",
+		Volumes:         volumes,
+		DependsOn:       []string{"postgres-1"},
+		Restart:         "no",
+		StopGracePeriod: "20s",
+	}, p.Services["vega-full-0"])
+
+	assert.Equal(t, []string{"datanode", "node", "--home", "/home/capsule/testnet/data/node0"}, p.Services["data-node-0"].Command)
+	assert.Equal(t, "/home/capsule/testnet/bins/vega", p.Services["testnet-faucet"].Entrypoint[0])
+
+	assert.Equal(t, compose.Service{
+		Image:           "postgres:14",
+		Environment:     map[string]string{"POSTGRES_USER": "vega"},
+		Ports:           []string{"5232:5432"},
+		Restart:         "no",
+		StopGracePeriod: "20s",
+	}, p.Services["postgres-1"])
+
+	out, err := p.Marshal()
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "network_mode: host")
+}
@@ -8,8 +8,10 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"text/template"
 
 	"code.vegaprotocol.io/vegacapsule/installer"
+	"code.vegaprotocol.io/vegacapsule/ports"
 	"code.vegaprotocol.io/vegacapsule/types"
 	"code.vegaprotocol.io/vegacapsule/utils"
 
@@ -62,6 +64,42 @@ type Config struct {
 		note: This optional parameter is used internally. There should never be any need to set it to anything other than default.
 	*/
 	VegaCapsuleBinary *string `hcl:"vega_capsule_binary_path,optional"`
+	/*
+		description: |
+			Seed used to derive all keys of the network - Tendermint node and validator keys, Vega wallet recovery phrases
+			and Ethereum keys of the nodes. The same configuration with the same seed generates the same keys, node IDs and genesis.
+			Genesis time is fixed for the seeded network, it can be changed in the genesis template.
+			Faucet key is not derived from the seed.
+		note: It can be overwritten by the `--seed` flag of the `network generate` and `network bootstrap` commands.
+		examples:
+			- type: hcl
+			  value: |
+						seed = "integration-tests"
+	*/
+	Seed *string `hcl:"seed,optional"`
+	/*
+		description: |
+			Routes Tendermint P2P connections between node sets through TCP proxies run by Capsule.
+			Persistent peers of the generated Tendermint configs point at the proxies, one per link between two node sets,
+			and the proxies run as a job of the network. It's required by the `chaos partition` and `chaos latency` commands.
+		note: Peer exchange (`pex`) should be disabled in Tendermint templates, otherwise nodes can connect to each other directly.
+		examples:
+			- type: hcl
+			  value: |
+						p2p_proxy = true
+	*/
+	P2PProxy bool `hcl:"p2p_proxy,optional"`
+	/*
+		description: Allows the user to choose how Capsule runs jobs of the network.
+		note: Jobs of a running network are always stopped by the backend they have been started with, so stop the network before changing the backend.
+		examples:
+			- type: hcl
+			  value: |
+						runner {
+							backend = "native"
+						}
+	*/
+	Runner *RunnerConfig `hcl:"runner,block"`
 
 	// Non configurable section - internal variables
 	NodeDirPrefix        string
@@ -73,10 +111,27 @@ type Config struct {
 	VisorPrefix          string
 
 	configDir string
+	// portAllocator allocates ports requested by the `port` function in the config and templates
+	portAllocator *ports.Allocator
 
 	HCLBodyRaw []byte
 }
 
+// SetPortAllocator sets allocator used by the `port` function in the config and all templates.
+func (c *Config) SetPortAllocator(a *ports.Allocator) {
+	c.portAllocator = a
+}
+
+// PortAllocator returns allocator used by the `port` function in the config and all templates.
+func (c Config) PortAllocator() *ports.Allocator {
+	return c.portAllocator
+}
+
+// TemplateFuncs returns functions available in all templates of the network.
+func (c Config) TemplateFuncs() template.FuncMap {
+	return ports.TemplateFuncs(c.portAllocator)
+}
+
 func (c *Config) setAbsolutePaths() error {
 	// Output directory
 	if !filepath.IsAbs(*c.OutputDir) {
@@ -195,6 +250,18 @@ func (c *Config) Validate(configDir string) error {
 		return fmt.Errorf("invalid configuration for wallet: %w", err)
 	}
 
+	if err := c.validateRunnerConfig(); err != nil {
+		return fmt.Errorf("invalid configuration for runner: %w", err)
+	}
+
+	if err := c.validateStartupGraph(); err != nil {
+		return fmt.Errorf("invalid dependencies between services: %w", err)
+	}
+
+	if err := c.validateProbes(); err != nil {
+		return fmt.Errorf("invalid configuration for probes: %w", err)
+	}
+
 	return nil
 }
 
@@ -241,6 +308,43 @@ func (c *Config) loadAndValidateNodeSets() error {
 	return nil
 }
 
+func (c *Config) validateProbes() error {
+	probes := map[string]*types.ProbesConfig{}
+
+	for _, nc := range c.Network.Nodes {
+		probes[fmt.Sprintf("pre_start_probe of node set %q", nc.Name)] = nc.PreStartProbe
+		probes[fmt.Sprintf("readiness_probe of node set %q", nc.Name)] = nc.ReadinessProbe
+	}
+	if c.Network.Wallet != nil {
+		probes["readiness_probe of wallet"] = c.Network.Wallet.ReadinessProbe
+	}
+	if c.Network.Faucet != nil {
+		probes["readiness_probe of faucet"] = c.Network.Faucet.ReadinessProbe
+	}
+	for _, sc := range []*PStartConfig{c.Network.PreStart, c.Network.PostStart} {
+		if sc == nil {
+			continue
+		}
+		for _, dc := range sc.Docker {
+			probes[fmt.Sprintf("readiness_probe of docker service %q", dc.Name)] = dc.ReadinessProbe
+		}
+		for _, ec := range sc.Exec {
+			probes[fmt.Sprintf("readiness_probe of exec service %q", ec.Name)] = ec.ReadinessProbe
+		}
+	}
+
+	for name, p := range probes {
+		if p == nil {
+			continue
+		}
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
 func (c *Config) validateWalletConfig() error {
 	wc := c.Network.Wallet
 
@@ -599,6 +703,16 @@ func (c Config) BinariesDir() string {
 	return path.Join(*c.OutputDir, "bins")
 }
 
+// SupervisorDir returns directory where the native runner keeps state of the supervised jobs.
+func (c Config) SupervisorDir() string {
+	return path.Join(*c.OutputDir, "supervisor")
+}
+
+// P2PProxyJobName returns name of the job running Tendermint P2P proxies, see P2PProxy.
+func (c Config) P2PProxyJobName() string {
+	return c.Network.Name + "-p2p-proxy"
+}
+
 func DefaultConfig() (*Config, error) {
 	outputDir, err := DefaultNetworkHome()
 	if err != nil {
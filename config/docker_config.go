@@ -1,5 +1,7 @@
 package config
 
+import "code.vegaprotocol.io/vegacapsule/types"
+
 /*
 description: |
 
@@ -116,6 +118,28 @@ type DockerConfig struct {
 	Resources *Resources `hcl:"resources,block"`
 
 	VolumeMounts []string `hcl:"volume_mounts,optional"`
+
+	/*
+		description: Names of the `docker_service`, `exec_service` or `node_set` blocks that have to be started before the service.
+		example:
+			type: hcl
+			value: |
+					depends_on = ["postgres-1"]
+	*/
+	DependsOn []string `hcl:"depends_on,optional"`
+
+	/*
+		description: Allows the user to define probes that have to pass before the service is considered ready.
+		example:
+			type: hcl
+			value: |
+					readiness_probe {
+						tcp {
+							address = "localhost:5232"
+						}
+					}
+	*/
+	ReadinessProbe *types.ProbesConfig `hcl:"readiness_probe,block"`
 }
 
 /*
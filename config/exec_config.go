@@ -1,5 +1,7 @@
 package config
 
+import "code.vegaprotocol.io/vegacapsule/types"
+
 type ExecConfig struct {
 	/*
 		description: Name of the service that is going to be used as an identifier when service runs.
@@ -44,4 +46,26 @@ type ExecConfig struct {
 					}
 	*/
 	Env map[string]string `hcl:"env,optional"`
+
+	/*
+		description: Names of the `docker_service`, `exec_service` or `node_set` blocks that have to be started before the service.
+		example:
+			type: hcl
+			value: |
+					depends_on = ["postgres-1"]
+	*/
+	DependsOn []string `hcl:"depends_on,optional"`
+
+	/*
+		description: Allows the user to define probes that have to pass before the service is considered ready.
+		example:
+			type: hcl
+			value: |
+					readiness_probe {
+						http {
+							url = "http://localhost:8545"
+						}
+					}
+	*/
+	ReadinessProbe *types.ProbesConfig `hcl:"readiness_probe,block"`
 }
@@ -1,5 +1,7 @@
 package config
 
+import "code.vegaprotocol.io/vegacapsule/types"
+
 /*
 description: |
 
@@ -51,4 +53,17 @@ type FaucetConfig struct {
 
 	*/
 	Template string `hcl:"template,optional"`
+
+	/*
+		description: Allows the user to define probes that have to pass before the faucet is considered ready.
+		example:
+			type: hcl
+			value: |
+					readiness_probe {
+						tcp {
+							address = "localhost:1790"
+						}
+					}
+	*/
+	ReadinessProbe *types.ProbesConfig `hcl:"readiness_probe,block"`
 }
@@ -147,6 +147,40 @@ type NodeConfig struct {
 	*/
 	PreStartProbe *types.ProbesConfig `hcl:"pre_start_probe,block" template:""`
 
+	/*
+		description: |
+					Names of the `docker_service`, `exec_service` or other `node_set` blocks that have to be started
+					before the node set. Capsule starts the services in order of their dependencies and waits for
+					each dependency to become healthy.
+		note: |
+				All node sets depend on the `pre_start` services and `post_start` services depend on all node sets implicitly.
+		examples:
+			- type: hcl
+			  value: |
+						depends_on = ["sentries"]
+	*/
+	DependsOn []string `hcl:"depends_on,optional"`
+
+	/*
+		description: |
+					Allows the user to define probes that have to pass before the node set is considered ready.
+					Probes are templated the same way as `pre_start_probe`.
+					Services depending on the node set start only after the probes pass
+					and `network start` waits for the probes of all services.
+		note: |
+				When not defined, Capsule waits for block height reported by the Vega node REST API to advance.
+		examples:
+			- type: hcl
+			  value: |
+						readiness_probe {
+							block_height {
+								url = "http://localhost:3{{ printf \"%02d\" .NodeNumber }}3"
+							}
+							timeout = "2m"
+						}
+	*/
+	ReadinessProbe *types.ProbesConfig `hcl:"readiness_probe,block" template:""`
+
 	/*
 		description: |
 					[Clef](https://geth.ethereum.org/docs/clef/introduction) is one of the
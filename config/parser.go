@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"code.vegaprotocol.io/vegacapsule/ports"
 	"code.vegaprotocol.io/vegacapsule/types"
 
 	"github.com/hashicorp/go-cty-funcs/crypto"
@@ -41,7 +42,37 @@ var envFunc = function.New(&function.Spec{
 	},
 })
 
-func newEvalContext(genServices cty.Value, homePath string) *hcl.EvalContext {
+// newPortFunc returns HCL function that allocates named port, e.g. port("ganache") or port("postgres", 1).
+func newPortFunc(a *ports.Allocator) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{
+				Name: "name",
+				Type: cty.String,
+			},
+		},
+		VarParam: &function.Parameter{
+			Name: "suffixes",
+			Type: cty.String,
+		},
+		Type: function.StaticReturnType(cty.Number),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			suffixes := make([]interface{}, 0, len(args)-1)
+			for _, arg := range args[1:] {
+				suffixes = append(suffixes, arg.AsString())
+			}
+
+			port, err := a.Port(ports.PortName(args[0].AsString(), suffixes...))
+			if err != nil {
+				return cty.NilVal, err
+			}
+
+			return cty.NumberIntVal(port), nil
+		},
+	})
+}
+
+func newEvalContext(genServices cty.Value, homePath string, portAllocator *ports.Allocator) *hcl.EvalContext {
 	return &hcl.EvalContext{
 		Variables: map[string]cty.Value{
 			"generated":         genServices,
@@ -116,6 +147,7 @@ func newEvalContext(genServices cty.Value, homePath string) *hcl.EvalContext {
 			"values":          stdlib.ValuesFunc,
 			"zipmap":          stdlib.ZipmapFunc,
 			"env":             envFunc,
+			"port":            newPortFunc(portAllocator),
 		},
 	}
 }
@@ -126,7 +158,7 @@ func ApplyConfigContext(conf *Config, genServices *types.GeneratedServices) (*Co
 		return nil, fmt.Errorf("failed to convert GeneratedServices to cty value: %w", err)
 	}
 
-	if err := hclsimple.Decode("config.hcl", conf.HCLBodyRaw, newEvalContext(*genServicesCtyVal, *conf.OutputDir), conf); err != nil {
+	if err := hclsimple.Decode("config.hcl", conf.HCLBodyRaw, newEvalContext(*genServicesCtyVal, *conf.OutputDir, conf.portAllocator), conf); err != nil {
 		return nil, err
 	}
 
@@ -148,11 +180,13 @@ func ParseHCLFile(filePath string) (*hcl.File, error) {
 	return f, nil
 }
 
-func ParseConfigFile(filePath, outputDir string, genServices types.GeneratedServices) (*Config, error) {
+// ParseConfigFile parses and validates config file. Ports requested in the config and templates are allocated by portAllocator.
+func ParseConfigFile(filePath, outputDir string, genServices types.GeneratedServices, portAllocator *ports.Allocator) (*Config, error) {
 	config, err := DefaultConfig()
 	if err != nil {
 		return nil, err
 	}
+	config.portAllocator = portAllocator
 
 	if outputDir != "" {
 		config.OutputDir = &outputDir
@@ -174,7 +208,7 @@ func ParseConfigFile(filePath, outputDir string, genServices types.GeneratedServ
 	}
 	config.HCLBodyRaw = configContent
 
-	decodeDiags := gohcl.DecodeBody(f.Body, newEvalContext(*genServicesCtyVal, *config.OutputDir), config)
+	decodeDiags := gohcl.DecodeBody(f.Body, newEvalContext(*genServicesCtyVal, *config.OutputDir, config.portAllocator), config)
 	if decodeDiags.HasErrors() {
 		return nil, fmt.Errorf("failed to decode config: %s", decodeDiags.Error())
 	}
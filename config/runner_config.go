@@ -0,0 +1,133 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	RunnerBackendNomad  = "nomad"
+	RunnerBackendNative = "native"
+)
+
+var (
+	defaultRunnerRestartDelay = time.Second
+	defaultRunnerKillTimeout  = time.Second * 20
+)
+
+/*
+description: |
+
+	Allows the user to choose how Capsule runs jobs of the network.
+	By default jobs are run by Nomad. The native backend runs the same tasks as child processes
+	supervised by Capsule itself, so no Nomad agent is needed.
+
+example:
+
+	type: hcl
+	value: |
+			runner {
+				backend          = "native"
+				restart_attempts = 2
+				restart_delay    = "5s"
+				kill_timeout     = "20s"
+			}
+*/
+type RunnerConfig struct {
+	/*
+		description: |
+			Backend running the jobs - `nomad` or `native`.
+			The native backend doesn't support `pre_generate` Nomad jobs and custom `nomad_job_template` of node sets,
+			Docker services are run with the `docker run` command.
+		default: nomad
+		example:
+			type: hcl
+			value: |
+					backend = "native"
+	*/
+	Backend string `hcl:"backend,optional"`
+
+	/*
+		description: How many times the native backend restarts a failed task before the whole job fails.
+		default: 0
+		example:
+			type: hcl
+			value: |
+					restart_attempts = 2
+	*/
+	RestartAttempts int `hcl:"restart_attempts,optional"`
+
+	/*
+		description: Time the native backend waits before a failed task is restarted.
+		default: 1s
+		example:
+			type: hcl
+			value: |
+					restart_delay = "5s"
+	*/
+	RestartDelay *string `hcl:"restart_delay,optional"`
+
+	/*
+		description: Time the native backend waits for a task to exit after it has been asked to stop, the task is killed afterwards.
+		default: 20s
+		example:
+			type: hcl
+			value: |
+					kill_timeout = "30s"
+	*/
+	KillTimeout *string `hcl:"kill_timeout,optional"`
+}
+
+// RunnerBackend returns backend running the jobs of the network.
+func (c Config) RunnerBackend() string {
+	if c.Runner == nil || c.Runner.Backend == "" {
+		return RunnerBackendNomad
+	}
+
+	return c.Runner.Backend
+}
+
+// GetRestartDelay returns restart delay of the native backend.
+func (rc RunnerConfig) GetRestartDelay() (time.Duration, error) {
+	return durationOrDefault(rc.RestartDelay, defaultRunnerRestartDelay)
+}
+
+// GetKillTimeout returns kill timeout of the native backend.
+func (rc RunnerConfig) GetKillTimeout() (time.Duration, error) {
+	return durationOrDefault(rc.KillTimeout, defaultRunnerKillTimeout)
+}
+
+func (c *Config) validateRunnerConfig() error {
+	rc := c.Runner
+	if rc == nil {
+		return nil
+	}
+
+	switch rc.Backend {
+	case "", RunnerBackendNomad, RunnerBackendNative:
+	default:
+		return fmt.Errorf("unknown backend %q, use %q or %q", rc.Backend, RunnerBackendNomad, RunnerBackendNative)
+	}
+
+	if rc.RestartAttempts < 0 {
+		return fmt.Errorf("restart_attempts must not be negative")
+	}
+
+	if _, err := rc.GetRestartDelay(); err != nil {
+		return fmt.Errorf("invalid restart_delay: %w", err)
+	}
+
+	if _, err := rc.GetKillTimeout(); err != nil {
+		return fmt.Errorf("invalid kill_timeout: %w", err)
+	}
+
+	return nil
+}
+
+func durationOrDefault(d *string, def time.Duration) (time.Duration, error) {
+	if d == nil {
+		return def, nil
+	}
+
+	return time.ParseDuration(*d)
+}
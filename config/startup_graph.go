@@ -0,0 +1,278 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"code.vegaprotocol.io/vegacapsule/types"
+)
+
+const (
+	startupNodeDocker  = "docker_service"
+	startupNodeExec    = "exec_service"
+	startupNodeNodeSet = "node_set"
+	startupNodeWallet  = "wallet"
+	startupNodeFaucet  = "faucet"
+)
+
+// StartupStage is a group of services started together, stages are started one after another.
+type StartupStage struct {
+	Exec     []ExecConfig
+	Docker   []DockerConfig
+	NodeSets []types.NodeSet
+	Wallet   bool
+	Faucet   bool
+}
+
+type startupNode struct {
+	kind      string
+	name      string
+	dependsOn map[string]bool
+	// id identifies the node in the graph, it differs from the key only for services of the same kind and name
+	id string
+
+	exec   ExecConfig
+	docker DockerConfig
+}
+
+// key identifies the service in the graph, the wallet and the faucet have no name.
+func (n startupNode) key() string {
+	if n.name == "" {
+		return n.kind
+	}
+	return fmt.Sprintf("%s.%s", n.kind, n.name)
+}
+
+type startupGraph map[string]*startupNode
+
+// startupGraph returns services of the network with their dependencies.
+// Without any `depends_on` the services start in the same order as they always did:
+// pre start exec services first, then pre start docker services, then node sets with the wallet and faucet
+// and post start services last.
+func (c Config) startupGraph() (startupGraph, error) {
+	g := startupGraph{}
+	names := map[string][]*startupNode{}
+
+	add := func(n *startupNode, dependsOn []string) *startupNode {
+		n.dependsOn = map[string]bool{}
+		for _, d := range dependsOn {
+			n.dependsOn[d] = true
+		}
+
+		n.id = n.key()
+		for i := 2; g[n.id] != nil; i++ {
+			n.id = fmt.Sprintf("%s#%d", n.key(), i)
+		}
+		g[n.id] = n
+
+		if n.name != "" {
+			names[n.name] = append(names[n.name], n)
+		}
+		return n
+	}
+
+	var preStartExec, preStartDocker, network, postStart []*startupNode
+	preStartDependsOn := false
+
+	if c.Network.PreStart != nil {
+		for _, ec := range c.Network.PreStart.Exec {
+			preStartExec = append(preStartExec, add(&startupNode{kind: startupNodeExec, name: ec.Name, exec: ec}, ec.DependsOn))
+			preStartDependsOn = preStartDependsOn || len(ec.DependsOn) != 0
+		}
+		for _, dc := range c.Network.PreStart.Docker {
+			preStartDocker = append(preStartDocker, add(&startupNode{kind: startupNodeDocker, name: dc.Name, docker: dc}, dc.DependsOn))
+			preStartDependsOn = preStartDependsOn || len(dc.DependsOn) != 0
+		}
+	}
+
+	for _, nc := range c.Network.Nodes {
+		network = append(network, add(&startupNode{kind: startupNodeNodeSet, name: nc.Name}, nc.DependsOn))
+	}
+	network = append(network, add(&startupNode{kind: startupNodeWallet}, nil), add(&startupNode{kind: startupNodeFaucet}, nil))
+
+	// post start exec services are not run
+	if c.Network.PostStart != nil {
+		for _, dc := range c.Network.PostStart.Docker {
+			postStart = append(postStart, add(&startupNode{kind: startupNodeDocker, name: dc.Name, docker: dc}, dc.DependsOn))
+		}
+	}
+
+	// depends_on refers to names of the services, they are replaced by the ids
+	for _, n := range g {
+		dependsOn := make(map[string]bool, len(n.dependsOn))
+		for name := range n.dependsOn {
+			deps := names[name]
+			if len(deps) == 0 {
+				return nil, fmt.Errorf("%s %q depends on unknown service %q", n.kind, n.name, name)
+			}
+			if len(deps) > 1 {
+				kinds := make([]string, 0, len(deps))
+				for _, d := range deps {
+					kinds = append(kinds, d.kind)
+				}
+				return nil, fmt.Errorf("%s %q depends on %q that is the name of multiple services (%s), names have to be unique to be used in depends_on",
+					n.kind, n.name, name, strings.Join(kinds, ", "))
+			}
+			dependsOn[deps[0].id] = true
+		}
+		n.dependsOn = dependsOn
+	}
+
+	// pre start services with any depends_on are ordered only by their dependencies
+	if !preStartDependsOn {
+		for _, n := range preStartDocker {
+			for _, e := range preStartExec {
+				n.dependsOn[e.id] = true
+			}
+		}
+	}
+
+	preStart := append(preStartExec, preStartDocker...)
+	for _, n := range network {
+		for _, p := range preStart {
+			n.dependsOn[p.id] = true
+		}
+	}
+	for _, n := range postStart {
+		for _, d := range network {
+			n.dependsOn[d.id] = true
+		}
+	}
+
+	return g, nil
+}
+
+// stages sorts the graph topologically, services of one stage depend only on services of the previous stages.
+func (g startupGraph) stages() ([][]*startupNode, error) {
+	started := map[string]bool{}
+	stages := [][]*startupNode{}
+
+	for len(started) != len(g) {
+		stage := []*startupNode{}
+
+		for id, n := range g {
+			if started[id] {
+				continue
+			}
+
+			ready := true
+			for d := range n.dependsOn {
+				if !started[d] {
+					ready = false
+					break
+				}
+			}
+
+			if ready {
+				stage = append(stage, n)
+			}
+		}
+
+		if len(stage) == 0 {
+			return nil, g.cycleErr(started)
+		}
+
+		sort.Slice(stage, func(i, j int) bool { return stage[i].id < stage[j].id })
+		for _, n := range stage {
+			started[n.id] = true
+		}
+
+		stages = append(stages, stage)
+	}
+
+	return stages, nil
+}
+
+// cycleErr returns error with one of the cycles, services that are not started all depend on some of the cycles.
+func (g startupGraph) cycleErr(started map[string]bool) error {
+	remaining := []string{}
+	for key := range g {
+		if !started[key] {
+			remaining = append(remaining, key)
+		}
+	}
+	sort.Strings(remaining)
+
+	path := []string{}
+	visited := map[string]int{}
+
+	for key := remaining[0]; ; {
+		if i, ok := visited[key]; ok {
+			path = append(path[i:], key)
+			break
+		}
+		visited[key] = len(path)
+		path = append(path, key)
+
+		deps := []string{}
+		for d := range g[key].dependsOn {
+			if !started[d] {
+				deps = append(deps, d)
+			}
+		}
+		sort.Strings(deps)
+		key = deps[0]
+	}
+
+	return fmt.Errorf("dependency cycle between services: %s", strings.Join(path, " -> "))
+}
+
+func (c Config) validateStartupGraph() error {
+	g, err := c.startupGraph()
+	if err != nil {
+		return err
+	}
+
+	_, err = g.stages()
+	return err
+}
+
+// StartupStages returns the services of the network in stages respecting their dependencies.
+// Generated node sets are part of the stage of their node set group.
+func (c Config) StartupStages(generatedSvcs *types.GeneratedServices) ([]StartupStage, error) {
+	g, err := c.startupGraph()
+	if err != nil {
+		return nil, err
+	}
+
+	graphStages, err := g.stages()
+	if err != nil {
+		return nil, err
+	}
+
+	nodeSetStage := map[string]int{}
+	walletStage := 0
+
+	stages := make([]StartupStage, len(graphStages))
+	for i, gs := range graphStages {
+		for _, n := range gs {
+			switch n.kind {
+			case startupNodeExec:
+				stages[i].Exec = append(stages[i].Exec, n.exec)
+			case startupNodeDocker:
+				stages[i].Docker = append(stages[i].Docker, n.docker)
+			case startupNodeNodeSet:
+				nodeSetStage[n.name] = i
+			case startupNodeWallet:
+				stages[i].Wallet = true
+				walletStage = i
+			case startupNodeFaucet:
+				stages[i].Faucet = true
+			}
+		}
+	}
+
+	if generatedSvcs != nil {
+		for _, ns := range generatedSvcs.NodeSets.ToSlice() {
+			// node sets without a group in the config start with the wallet, as they did before
+			i, ok := nodeSetStage[ns.GroupName]
+			if !ok {
+				i = walletStage
+			}
+			stages[i].NodeSets = append(stages[i].NodeSets, ns)
+		}
+	}
+
+	return stages, nil
+}
@@ -0,0 +1,147 @@
+package config_test
+
+import (
+	"testing"
+
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartupStages(t *testing.T) {
+	conf := config.Config{
+		Network: config.NetworkConfig{
+			PreStart: &config.PStartConfig{
+				Docker: []config.DockerConfig{{Name: "postgres"}, {Name: "ganache"}},
+				Exec:   []config.ExecConfig{{Name: "migrations", DependsOn: []string{"postgres"}}},
+			},
+			Nodes: []config.NodeConfig{
+				{Name: "validators", DependsOn: []string{"sentries"}},
+				{Name: "sentries"},
+			},
+			PostStart: &config.PStartConfig{
+				Docker: []config.DockerConfig{{Name: "explorer"}},
+			},
+		},
+	}
+
+	genServices := &types.GeneratedServices{
+		NodeSets: types.NodeSetMap{
+			"validators-0": {Name: "validators-0", GroupName: "validators"},
+			"sentries-0":   {Name: "sentries-0", GroupName: "sentries", Index: 1},
+		},
+	}
+
+	stages, err := conf.StartupStages(genServices)
+	require.NoError(t, err)
+	require.Len(t, stages, 5)
+
+	assert.Equal(t, []config.DockerConfig{{Name: "ganache"}, {Name: "postgres"}}, stages[0].Docker)
+	assert.Empty(t, stages[0].Exec)
+
+	assert.Equal(t, []config.ExecConfig{{Name: "migrations", DependsOn: []string{"postgres"}}}, stages[1].Exec)
+
+	assert.True(t, stages[2].Wallet)
+	assert.True(t, stages[2].Faucet)
+	require.Len(t, stages[2].NodeSets, 1)
+	assert.Equal(t, "sentries-0", stages[2].NodeSets[0].Name)
+
+	require.Len(t, stages[3].NodeSets, 1)
+	assert.Equal(t, "validators-0", stages[3].NodeSets[0].Name)
+
+	assert.Equal(t, []config.DockerConfig{{Name: "explorer"}}, stages[4].Docker)
+}
+
+func TestStartupStagesWithoutDependsOn(t *testing.T) {
+	conf := config.Config{
+		Network: config.NetworkConfig{
+			PreStart: &config.PStartConfig{
+				Docker: []config.DockerConfig{{Name: "ganache"}, {Name: "postgres"}},
+				Exec:   []config.ExecConfig{{Name: "migrations"}},
+			},
+			Nodes: []config.NodeConfig{{Name: "validators"}},
+			PostStart: &config.PStartConfig{
+				// same name as the node set is allowed when no depends_on refers to it
+				Docker: []config.DockerConfig{{Name: "validators"}},
+			},
+		},
+	}
+
+	genServices := &types.GeneratedServices{
+		NodeSets: types.NodeSetMap{
+			"validators-0": {Name: "validators-0", GroupName: "validators"},
+		},
+	}
+
+	stages, err := conf.StartupStages(genServices)
+	require.NoError(t, err)
+	require.Len(t, stages, 4)
+
+	// pre start exec services are started before pre start docker services
+	assert.Equal(t, []config.ExecConfig{{Name: "migrations"}}, stages[0].Exec)
+	assert.Empty(t, stages[0].Docker)
+
+	assert.Equal(t, []config.DockerConfig{{Name: "ganache"}, {Name: "postgres"}}, stages[1].Docker)
+	assert.Empty(t, stages[1].Exec)
+
+	assert.True(t, stages[2].Wallet)
+	require.Len(t, stages[2].NodeSets, 1)
+	assert.Equal(t, "validators-0", stages[2].NodeSets[0].Name)
+
+	assert.Equal(t, []config.DockerConfig{{Name: "validators"}}, stages[3].Docker)
+}
+
+func TestStartupStagesErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		conf config.Config
+		err  string
+	}{
+		{
+			name: "cycle",
+			conf: config.Config{Network: config.NetworkConfig{
+				Nodes: []config.NodeConfig{
+					{Name: "validators", DependsOn: []string{"sentries"}},
+					{Name: "sentries", DependsOn: []string{"validators"}},
+				},
+			}},
+			err: "dependency cycle between services: node_set.sentries -> node_set.validators -> node_set.sentries",
+		},
+		{
+			name: "pre start depends on node set",
+			conf: config.Config{Network: config.NetworkConfig{
+				PreStart: &config.PStartConfig{Docker: []config.DockerConfig{{Name: "postgres", DependsOn: []string{"validators"}}}},
+				Nodes:    []config.NodeConfig{{Name: "validators"}},
+			}},
+			err: "dependency cycle between services: docker_service.postgres -> node_set.validators -> docker_service.postgres",
+		},
+		{
+			name: "unknown dependency",
+			conf: config.Config{Network: config.NetworkConfig{
+				Nodes: []config.NodeConfig{{Name: "validators", DependsOn: []string{"postgres"}}},
+			}},
+			err: `node_set "validators" depends on unknown service "postgres"`,
+		},
+		{
+			name: "ambiguous dependency",
+			conf: config.Config{Network: config.NetworkConfig{
+				PreStart: &config.PStartConfig{Docker: []config.DockerConfig{{Name: "validators"}}},
+				Nodes: []config.NodeConfig{
+					{Name: "validators"},
+					{Name: "sentries", DependsOn: []string{"validators"}},
+				},
+			}},
+			err: `node_set "sentries" depends on "validators" that is the name of multiple services (docker_service, node_set)`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.conf.StartupStages(nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"bytes"
 	"reflect"
+	"text/template"
 )
 
 type NodeConfigTemplateContext struct {
@@ -10,9 +11,9 @@ type NodeConfigTemplateContext struct {
 	NodeNumber int
 }
 
-func TemplateNodeConfig(templateContext NodeConfigTemplateContext, n NodeConfig) (*NodeConfig, error) {
+func TemplateNodeConfig(templateContext NodeConfigTemplateContext, n NodeConfig, funcs template.FuncMap) (*NodeConfig, error) {
 	tmplFunc := func(templateRaw string) (*bytes.Buffer, error) {
-		return executeConfigTemplate(templateRaw, templateContext)
+		return executeConfigTemplate(templateRaw, templateContext, funcs)
 	}
 
 	if err := TemplateStruct(reflect.ValueOf(&n), tmplFunc); err != nil {
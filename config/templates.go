@@ -5,8 +5,6 @@ import (
 	"fmt"
 	"reflect"
 	"text/template"
-
-	"github.com/Masterminds/sprig"
 )
 
 func TemplateStruct(v reflect.Value, templateFunc func(templateRaw string) (*bytes.Buffer, error)) error {
@@ -53,8 +51,8 @@ func TemplateStruct(v reflect.Value, templateFunc func(templateRaw string) (*byt
 	return nil
 }
 
-func executeConfigTemplate(templateRaw string, tmplCtx any) (*bytes.Buffer, error) {
-	t, err := template.New("template").Funcs(sprig.TxtFuncMap()).Parse(templateRaw)
+func executeConfigTemplate(templateRaw string, tmplCtx any, funcs template.FuncMap) (*bytes.Buffer, error) {
+	t, err := template.New("template").Funcs(funcs).Parse(templateRaw)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse template config: %w", err)
 	}
@@ -1,5 +1,7 @@
 package config
 
+import "code.vegaprotocol.io/vegacapsule/types"
+
 /*
 description: |
 
@@ -68,4 +70,17 @@ type WalletConfig struct {
 
 	*/
 	Template string `hcl:"template,optional"`
+
+	/*
+		description: Allows the user to define probes that have to pass before the wallet is considered ready.
+		example:
+			type: hcl
+			value: |
+					readiness_probe {
+						http {
+							url = "http://localhost:1789/api/v2/health"
+						}
+					}
+	*/
+	ReadinessProbe *types.ProbesConfig `hcl:"readiness_probe,block"`
 }
@@ -0,0 +1,292 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"code.vegaprotocol.io/vegacapsule/types"
+
+	"golang.org/x/term"
+)
+
+const (
+	keyCtrlC = 0x03
+	keyTab   = '\t'
+	keyEnter = '\r'
+
+	keyUpSequence   = "\x1b[A"
+	keyDownSequence = "\x1b[B"
+
+	enterAltScreen = "\x1b[?1049h\x1b[?25l"
+	leaveAltScreen = "\x1b[?25h\x1b[?1049l"
+	clearScreen    = "\x1b[H\x1b[2J"
+)
+
+// Actions change state of the node sets, they are called from the dashboard key bindings.
+type Actions interface {
+	StartNodeSet(ctx context.Context, name string) error
+	StopNodeSet(ctx context.Context, name string) error
+}
+
+// Dashboard is a terminal UI showing node sets of a running network and logs of its jobs.
+type Dashboard struct {
+	runner   jobStatuser
+	actions  Actions
+	logsDir  string
+	nodeSets []nodeSet
+	// jobs with logs shown in the log pane
+	jobs []string
+
+	mu       sync.Mutex
+	rows     []nodeSetRow
+	selected int
+	logsJob  int
+	message  string
+	busy     bool
+	// redraw is notified when the dashboard changes in background
+	redraw chan struct{}
+}
+
+func New(runner jobStatuser, actions Actions, genServices *types.GeneratedServices, logsDir string) *Dashboard {
+	d := &Dashboard{
+		runner:  runner,
+		actions: actions,
+		logsDir: logsDir,
+		redraw:  make(chan struct{}, 1),
+	}
+
+	for _, ns := range genServices.NodeSets.ToSlice() {
+		d.nodeSets = append(d.nodeSets, newNodeSet(ns))
+		d.rows = append(d.rows, nodeSetRow{name: ns.Name, state: unknownValue, height: unknownValue, peers: unknownValue, restarts: unknownValue})
+		d.jobs = append(d.jobs, ns.Name)
+	}
+
+	if genServices.Wallet != nil {
+		d.jobs = append(d.jobs, genServices.Wallet.Name)
+	}
+	if genServices.Faucet != nil {
+		d.jobs = append(d.jobs, genServices.Faucet.Name)
+	}
+
+	return d
+}
+
+// AddJobs adds jobs to the jobs the log pane can switch between.
+func (d *Dashboard) AddJobs(jobIDs ...string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.jobs = append(d.jobs, jobIDs...)
+}
+
+// Refresh fetches current state of the node sets.
+func (d *Dashboard) Refresh(ctx context.Context) {
+	rows := make([]nodeSetRow, len(d.nodeSets))
+
+	var wg sync.WaitGroup
+	for i, n := range d.nodeSets {
+		i, n := i, n
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rows[i] = n.row(ctx, d.runner)
+		}()
+	}
+	wg.Wait()
+
+	d.mu.Lock()
+	d.rows = rows
+	d.mu.Unlock()
+
+	d.notify()
+}
+
+func (d *Dashboard) notify() {
+	select {
+	case d.redraw <- struct{}{}:
+	default:
+	}
+}
+
+// HandleInput handles keys pressed by the user, it returns true when the user quits the dashboard.
+func (d *Dashboard) HandleInput(ctx context.Context, input []byte) bool {
+	for len(input) > 0 {
+		switch {
+		case hasPrefix(input, keyUpSequence):
+			d.moveSelection(-1)
+			input = input[len(keyUpSequence):]
+			continue
+		case hasPrefix(input, keyDownSequence):
+			d.moveSelection(1)
+			input = input[len(keyDownSequence):]
+			continue
+		}
+
+		key := input[0]
+		input = input[1:]
+
+		switch key {
+		case 'q', keyCtrlC:
+			return true
+		case 'k':
+			d.moveSelection(-1)
+		case 'j':
+			d.moveSelection(1)
+		case keyTab:
+			d.mu.Lock()
+			if len(d.jobs) != 0 {
+				d.logsJob = (d.logsJob + 1) % len(d.jobs)
+			}
+			d.mu.Unlock()
+		case keyEnter:
+			d.mu.Lock()
+			// node sets are the first jobs
+			d.logsJob = d.selected
+			d.mu.Unlock()
+		case 's':
+			d.runAction(ctx, "starting", "started", d.actions.StartNodeSet)
+		case 'x':
+			d.runAction(ctx, "stopping", "stopped", d.actions.StopNodeSet)
+		case 'r':
+			d.runAction(ctx, "restarting", "restarted", func(ctx context.Context, name string) error {
+				if err := d.actions.StopNodeSet(ctx, name); err != nil {
+					return err
+				}
+				return d.actions.StartNodeSet(ctx, name)
+			})
+		}
+	}
+
+	return false
+}
+
+func hasPrefix(input []byte, prefix string) bool {
+	return len(input) >= len(prefix) && string(input[:len(prefix)]) == prefix
+}
+
+func (d *Dashboard) moveSelection(delta int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(d.rows) == 0 {
+		return
+	}
+
+	d.selected = (d.selected + delta + len(d.rows)) % len(d.rows)
+}
+
+// runAction runs the action on the selected node set in background, only one action runs at a time.
+func (d *Dashboard) runAction(ctx context.Context, progress, done string, action func(ctx context.Context, name string) error) {
+	d.mu.Lock()
+	if d.busy || len(d.rows) == 0 {
+		d.mu.Unlock()
+		return
+	}
+
+	name := d.rows[d.selected].name
+	d.busy = true
+	d.message = fmt.Sprintf("%s %s...", progress, name)
+	d.mu.Unlock()
+
+	go func() {
+		err := action(ctx, name)
+
+		d.mu.Lock()
+		d.busy = false
+		if err != nil {
+			d.message = fmt.Sprintf("failed %s %s: %s", progress, name, err)
+		} else {
+			d.message = fmt.Sprintf("%s %s", name, done)
+		}
+		d.mu.Unlock()
+
+		d.Refresh(ctx)
+	}()
+}
+
+// Run shows the dashboard in the terminal until the user quits or the context is done.
+func (d *Dashboard) Run(ctx context.Context, in, out *os.File, refreshInterval time.Duration) error {
+	if !term.IsTerminal(int(in.Fd())) || !term.IsTerminal(int(out.Fd())) {
+		return fmt.Errorf("dashboard can only run in a terminal")
+	}
+
+	oldState, err := term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		return fmt.Errorf("failed to switch terminal to raw mode: %w", err)
+	}
+	defer term.Restore(int(in.Fd()), oldState) //nolint:errcheck
+
+	fmt.Fprint(out, enterAltScreen)
+	defer fmt.Fprint(out, leaveAltScreen)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	input := make(chan []byte)
+	go func() {
+		buf := make([]byte, 64)
+		for {
+			n, err := in.Read(buf)
+			if err != nil {
+				close(input)
+				return
+			}
+
+			b := make([]byte, n)
+			copy(b, buf[:n])
+			select {
+			case input <- b:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		t := time.NewTicker(refreshInterval)
+		defer t.Stop()
+
+		for {
+			d.Refresh(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			}
+		}
+	}()
+
+	// logs are redrawn periodically even without any change of the node sets
+	t := time.NewTicker(time.Second)
+	defer t.Stop()
+
+	for {
+		width, height, err := term.GetSize(int(out.Fd()))
+		if err != nil {
+			return fmt.Errorf("failed to get terminal size: %w", err)
+		}
+
+		fmt.Fprint(out, clearScreen+d.Render(width, height))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case b, ok := <-input:
+			if !ok || d.HandleInput(ctx, b) {
+				return nil
+			}
+		case <-d.redraw:
+		case <-t.C:
+		}
+	}
+}
+
+func (d *Dashboard) jobLogsDir(jobID string) string {
+	return filepath.Join(d.logsDir, jobID)
+}
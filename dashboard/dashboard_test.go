@@ -0,0 +1,106 @@
+package dashboard_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"code.vegaprotocol.io/vegacapsule/dashboard"
+	"code.vegaprotocol.io/vegacapsule/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRunner map[string]*types.JobStatus
+
+func (r fakeRunner) JobStatus(ctx context.Context, jobID string) (*types.JobStatus, error) {
+	return r[jobID], nil
+}
+
+type fakeActions struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (a *fakeActions) StartNodeSet(ctx context.Context, name string) error {
+	a.record("start " + name)
+	return nil
+}
+
+func (a *fakeActions) StopNodeSet(ctx context.Context, name string) error {
+	a.record("stop " + name)
+	return nil
+}
+
+func (a *fakeActions) record(call string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.calls = append(a.calls, call)
+}
+
+func (a *fakeActions) Calls() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]string(nil), a.calls...)
+}
+
+func newDashboard(t *testing.T, actions dashboard.Actions) *dashboard.Dashboard {
+	t.Helper()
+
+	logsDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(logsDir, "wallet"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(logsDir, "wallet", "wallet.stdout-2024-01-02T15:04:05Z.log"), []byte("wallet started\n"), 0o644))
+
+	runner := fakeRunner{
+		"testnode-0": {ID: "testnode-0", Status: types.JobStatusRunning, Tasks: []types.TaskStatus{{Restarts: 1}, {Restarts: 2}}},
+		"testnode-1": {ID: "testnode-1", Status: types.JobStatusStopped},
+	}
+
+	genServices := &types.GeneratedServices{
+		NodeSets: map[string]types.NodeSet{
+			"testnode-0": {Name: "testnode-0"},
+			"testnode-1": {Name: "testnode-1", Index: 1},
+		},
+		Wallet: &types.Wallet{GeneratedService: types.GeneratedService{Name: "wallet"}},
+	}
+
+	d := dashboard.New(runner, actions, genServices, logsDir)
+	d.Refresh(context.Background())
+
+	return d
+}
+
+func TestRender(t *testing.T) {
+	d := newDashboard(t, &fakeActions{})
+
+	frame := d.Render(120, 20)
+	assert.Contains(t, frame, "testnode-0  running  -       -      3")
+	assert.Contains(t, frame, "testnode-1  stopped  -       -      0")
+	assert.Contains(t, frame, "Logs: testnode-0")
+
+	// switch logs to the wallet
+	assert.False(t, d.HandleInput(context.Background(), []byte("\t\t")))
+
+	frame = d.Render(120, 20)
+	assert.Contains(t, frame, "Logs: wallet")
+	assert.Contains(t, frame, "wallet started")
+}
+
+func TestHandleInput(t *testing.T) {
+	actions := &fakeActions{}
+	d := newDashboard(t, actions)
+
+	// select the second node set and restart it
+	assert.False(t, d.HandleInput(context.Background(), []byte("\x1b[Br")))
+
+	require.Eventually(t, func() bool {
+		return len(actions.Calls()) == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"stop testnode-1", "start testnode-1"}, actions.Calls())
+
+	assert.True(t, d.HandleInput(context.Background(), []byte("q")))
+}
@@ -0,0 +1,96 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"code.vegaprotocol.io/vegacapsule/generator/tendermint"
+	"code.vegaprotocol.io/vegacapsule/types"
+
+	rpchttp "github.com/cometbft/cometbft/rpc/client/http"
+)
+
+const (
+	unknownValue = "-"
+	rpcTimeout   = 2 * time.Second
+)
+
+type jobStatuser interface {
+	JobStatus(ctx context.Context, jobID string) (*types.JobStatus, error)
+}
+
+// nodeSetRow is a row of the node sets table.
+type nodeSetRow struct {
+	name     string
+	state    string
+	height   string
+	peers    string
+	restarts string
+}
+
+type nodeSet struct {
+	name string
+	// rpc is nil when the Tendermint RPC address can't be found in the node set config
+	rpc *rpchttp.HTTP
+}
+
+func newNodeSet(ns types.NodeSet) nodeSet {
+	n := nodeSet{name: ns.Name}
+
+	conf, err := tendermint.ReadConfig(ns.Tendermint.ConfigFilePath)
+	if err != nil {
+		log.Printf("failed to read Tendermint config of node set %q: %s", ns.Name, err)
+		return n
+	}
+
+	rpc, err := rpchttp.New("tcp://"+tendermint.LocalAddress(conf.RPC.ListenAddress), "/websocket")
+	if err != nil {
+		log.Printf("failed to create Tendermint RPC client of node set %q: %s", ns.Name, err)
+		return n
+	}
+	n.rpc = rpc
+
+	return n
+}
+
+// row returns current state of the node set, values that can't be fetched are unknown.
+func (n nodeSet) row(ctx context.Context, runner jobStatuser) nodeSetRow {
+	row := nodeSetRow{
+		name:     n.name,
+		state:    unknownValue,
+		height:   unknownValue,
+		peers:    unknownValue,
+		restarts: unknownValue,
+	}
+
+	js, err := runner.JobStatus(ctx, n.name)
+	if err == nil {
+		row.state = js.Status
+
+		var restarts uint64
+		for _, t := range js.Tasks {
+			restarts += t.Restarts
+		}
+		row.restarts = strconv.FormatUint(restarts, 10)
+	}
+
+	if n.rpc == nil || row.state != types.JobStatusRunning {
+		return row
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, rpcTimeout)
+	defer cancel()
+
+	if status, err := n.rpc.Status(ctx); err == nil {
+		row.height = fmt.Sprint(status.SyncInfo.LatestBlockHeight)
+	}
+
+	if netInfo, err := n.rpc.NetInfo(ctx); err == nil {
+		row.peers = strconv.Itoa(netInfo.NPeers)
+	}
+
+	return row
+}
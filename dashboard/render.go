@@ -0,0 +1,91 @@
+package dashboard
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"code.vegaprotocol.io/vegacapsule/logscollector"
+)
+
+const (
+	reverseVideo = "\x1b[7m"
+	resetStyle   = "\x1b[0m"
+
+	helpLine = "↑/↓ select  enter node set logs  tab next job logs  s start  x stop  r restart  q quit"
+)
+
+// Render returns the dashboard frame fitting the terminal size, lines are separated by \r\n as the terminal is in raw mode.
+func (d *Dashboard) Render(width, height int) string {
+	d.mu.Lock()
+	rows := append([]nodeSetRow(nil), d.rows...)
+	selected := d.selected
+	message := d.message
+	var logsJob string
+	if len(d.jobs) != 0 {
+		logsJob = d.jobs[d.logsJob]
+	}
+	d.mu.Unlock()
+
+	var table bytes.Buffer
+	tw := tabwriter.NewWriter(&table, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  NODE SET\tSTATE\tHEIGHT\tPEERS\tRESTARTS")
+	for i, r := range rows {
+		marker := " "
+		if i == selected {
+			marker = ">"
+		}
+		fmt.Fprintf(tw, "%s %s\t%s\t%s\t%s\t%s\n", marker, r.name, r.state, r.height, r.peers, r.restarts)
+	}
+	tw.Flush()
+
+	lines := []string{}
+	for i, l := range strings.Split(strings.TrimRight(table.String(), "\n"), "\n") {
+		l = truncate(l, width)
+		// the first line is the header
+		if i-1 == selected {
+			l = reverseVideo + l + resetStyle
+		}
+		lines = append(lines, l)
+	}
+
+	lines = append(lines, "", truncate(fmt.Sprintf("── Logs: %s ", logsJob)+strings.Repeat("─", width), width))
+
+	// the message and the help lines are at the bottom
+	logsHeight := height - len(lines) - 2
+	if logsHeight > 0 && logsJob != "" {
+		logLines, err := logscollector.LastLines(d.jobLogsDir(logsJob), logsHeight)
+		if err != nil {
+			logLines = []string{fmt.Sprintf("failed to read logs: %s", err)}
+		}
+
+		for _, l := range logLines {
+			lines = append(lines, truncate(l, width))
+		}
+		for i := len(logLines); i < logsHeight; i++ {
+			lines = append(lines, "")
+		}
+	}
+
+	lines = append(lines, truncate(message, width), truncate(helpLine, width))
+
+	return strings.Join(lines, "\r\n")
+}
+
+// truncate cuts the line to the width, log lines can contain tabs and control characters which are replaced.
+func truncate(line string, width int) string {
+	line = strings.ReplaceAll(line, "\t", "    ")
+	line = strings.Map(func(r rune) rune {
+		if r < ' ' {
+			return -1
+		}
+		return r
+	}, line)
+
+	runes := []rune(line)
+	if width > 0 && len(runes) > width {
+		return string(runes[:width])
+	}
+	return line
+}
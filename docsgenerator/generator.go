@@ -20,8 +20,11 @@ type TypeDocGenerator struct {
 	tagName string
 }
 
-func NewTypeDocGenerator(dir, tagName string) (*TypeDocGenerator, error) {
-	filePaths := findFiles(dir, ".go")
+func NewTypeDocGenerator(dirs []string, tagName string) (*TypeDocGenerator, error) {
+	var filePaths []string
+	for _, dir := range dirs {
+		filePaths = append(filePaths, findFiles(dir, ".go")...)
+	}
 
 	types := map[string]docTypeWithFileContent{}
 
@@ -108,7 +108,8 @@ func getFieldInfo(
 
 	packageName := currentPackageName
 
-	typeSplit := strings.Split(fieldType, ".")
+	// pointers and slices of types from other packages, e.g. *types.ProbesConfig
+	typeSplit := strings.Split(strings.TrimLeft(fieldType, "[]*"), ".")
 	if len(typeSplit) > 1 {
 		packageName = typeSplit[0]
 		fi.lookupKey = typeSplit[1]
@@ -117,14 +118,11 @@ func getFieldInfo(
 	}
 
 	switch field.Type.(type) {
-	case *ast.ArrayType:
-		fi.lookupKey = strings.TrimLeft(fieldType, "[]")
 	case *ast.MapType:
 		fi.lookupKey = valueTypeFromMap(fieldType)
 	case *ast.Ident:
 		fi.lookupKey = fieldType
 	case *ast.StarExpr:
-		fi.lookupKey = strings.TrimLeft(fi.lookupKey, "*")
 		if comment.OptionalIf == "" {
 			fi.isOptional = true
 		}
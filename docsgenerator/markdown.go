@@ -179,7 +179,12 @@ var re = regexp.MustCompile(`[A-Za-z\.]+`)
 
 func (fd *FileDoc) encodeType(t string) string {
 	for _, s := range re.FindAllString(t, -1) {
-		if anchor, ok := fd.Anchors[s]; ok {
+		anchor, ok := fd.Anchors[s]
+		if !ok {
+			// types from other packages are documented without the package name, e.g. types.ProbesConfig
+			anchor, ok = fd.Anchors[s[strings.LastIndex(s, ".")+1:]]
+		}
+		if ok {
 			t = strings.ReplaceAll(t, s, formatLink(s, "#"+strings.ReplaceAll(anchor, ".", "")))
 		}
 	}
@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"log"
 	"math/big"
+	"time"
 
 	multisig "code.vegaprotocol.io/vega/core/contracts/multisig_control"
+	"code.vegaprotocol.io/vegacapsule/probes"
 	"code.vegaprotocol.io/vegacapsule/types"
+	"code.vegaprotocol.io/vegacapsule/utils"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -15,6 +18,14 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
+const (
+	waitForNetworkInterval = 500 * time.Millisecond
+
+	// DefaultMultisigThreshold is the threshold of the multisig control set up by Capsule.
+	// It's in thousandths of the signers, so more than 2/3 of the signers have to sign.
+	DefaultMultisigThreshold = 667
+)
+
 type EthereumMultisigClient struct {
 	client  *ethclient.Client
 	chainID int64
@@ -25,23 +36,18 @@ type EthereumMultisigClient struct {
 	vegaHome   string
 }
 
+// WaitForNetwork waits until the Ethereum network with the chain ID is available at the address or the context is done.
 func WaitForNetwork(ctx context.Context, chainID int, ethAddress string) error {
-	done := make(chan bool)
-	go func(chainID int, ethAddress string) {
-		for {
-			if client, err := ethclient.DialContext(ctx, ethAddress); err == nil {
-				client.Close()
-				done <- true
-			}
-		}
-	}(chainID, ethAddress)
+	probe := types.ProbesConfig{
+		EthereumRPC: &types.EthereumRPCProbe{URL: ethAddress, ChainID: chainID},
+		Interval:    utils.ToPoint(waitForNetworkInterval.String()),
+	}
 
-	select {
-	case <-done:
-		return nil
-	case <-ctx.Done():
-		return ctx.Err()
+	if deadline, ok := ctx.Deadline(); ok {
+		probe.Timeout = utils.ToPoint(time.Until(deadline).String())
 	}
+
+	return probes.Probe(ctx, "ethereum-network", probe)
 }
 
 type EthereumMultisigClientParameters struct {
@@ -119,13 +125,187 @@ func (ec EthereumMultisigClient) InitMultisig(ctx context.Context, smartcontract
 		return fmt.Errorf("failed to remove contract owner from muiltisig signer: %w", err)
 	}
 
-	if err := ec.multisigSetThreshold(ctx, session, 667, validators); err != nil {
-		return fmt.Errorf("failed to set multisig threshold to 667: %w", err)
+	if err := ec.multisigSetThreshold(ctx, session, DefaultMultisigThreshold, validators); err != nil {
+		return fmt.Errorf("failed to set multisig threshold to %d: %w", DefaultMultisigThreshold, err)
+	}
+
+	return nil
+}
+
+// UpdateSigners adds and removes signers of the multisig control and sets the threshold, zero threshold keeps the current one.
+// The changes are submitted by the smart contracts owner and signed by those of the validators, which are valid signers
+// at the time of the change, so validators being removed have to be included in the validators.
+func (ec EthereumMultisigClient) UpdateSigners(
+	ctx context.Context,
+	smartcontracts types.SmartContractsInfo,
+	add SignersList,
+	remove []string,
+	validators SignersList,
+	threshold int,
+) error {
+	if smartcontracts.EthereumOwner.Private == "" || smartcontracts.EthereumOwner.Public == "" {
+		return fmt.Errorf("missing private or public key of the smart contract owner in the network configuration")
+	}
+
+	submitter := Signer{
+		KeyPair: KeyPair{
+			PrivateKey: smartcontracts.EthereumOwner.Private,
+			Address:    smartcontracts.EthereumOwner.Public,
+		},
+	}
+
+	session, err := ec.createMultiSigControlSession(ctx, submitter)
+	if err != nil {
+		return fmt.Errorf("failed to create multisig smart contract session: %w", err)
+	}
+
+	all := append(append(SignersList{}, validators...), add...)
+
+	// signers are looked up before every change, as every change changes the signers
+	for _, newSigner := range add {
+		signers, err := validSigners(session, all)
+		if err != nil {
+			return err
+		}
+
+		if err := ec.multisigAddSigners(ctx, session, SignersList{newSigner}, signers); err != nil {
+			return fmt.Errorf("failed to add signer: %w", err)
+		}
+	}
+
+	for _, oldSigner := range remove {
+		isSigner, err := session.IsValidSigner(common.HexToAddress(oldSigner))
+		if err != nil {
+			return fmt.Errorf("failed to check signer: %w", err)
+		}
+
+		if !isSigner {
+			log.Printf("%s is not a valid signer. No need to remove it", oldSigner)
+			continue
+		}
+
+		signers, err := validSigners(session, all)
+		if err != nil {
+			return err
+		}
+
+		if err := ec.multisigRemoveSigner(ctx, session, oldSigner, signers); err != nil {
+			return fmt.Errorf("failed to remove signer: %w", err)
+		}
+	}
+
+	if threshold == 0 {
+		return nil
+	}
+
+	currentThreshold, err := session.GetCurrentThreshold()
+	if err != nil {
+		return fmt.Errorf("failed to get current multisig threshold: %w", err)
+	}
+
+	if int(currentThreshold) == threshold {
+		return nil
+	}
+
+	signers, err := validSigners(session, all)
+	if err != nil {
+		return err
+	}
+
+	if err := ec.multisigSetThreshold(ctx, session, threshold, signers); err != nil {
+		return fmt.Errorf("failed to set multisig threshold to %d: %w", threshold, err)
 	}
 
 	return nil
 }
 
+// validSigners returns signers from the list, which are valid signers of the multisig control.
+func validSigners(session *multisig.MultisigControlSession, signers SignersList) (SignersList, error) {
+	valid := SignersList{}
+	seen := map[string]bool{}
+
+	for _, s := range signers {
+		if seen[s.KeyPair.Address] {
+			continue
+		}
+		seen[s.KeyPair.Address] = true
+
+		ok, err := session.IsValidSigner(common.HexToAddress(s.KeyPair.Address))
+		if err != nil {
+			return nil, fmt.Errorf("failed to check signer: %w", err)
+		}
+
+		if ok {
+			valid = append(valid, s)
+		}
+	}
+
+	if len(valid) == 0 {
+		return nil, fmt.Errorf("none of the validators is a valid signer of the multisig control")
+	}
+
+	return valid, nil
+}
+
+// MultisigStatus compares signers of the multisig control with the validators.
+type MultisigStatus struct {
+	Threshold   uint16
+	SignerCount uint8
+	Validators  []ValidatorSignerStatus
+	// UnknownSigners is the number of signers which are not any of the validators.
+	UnknownSigners int
+}
+
+type ValidatorSignerStatus struct {
+	Address     string
+	ValidSigner bool
+}
+
+// MultisigStatus returns signers status of the multisig control.
+func (ec EthereumMultisigClient) MultisigStatus(ctx context.Context, validators SignersList) (*MultisigStatus, error) {
+	session := &multisig.MultisigControlSession{
+		Contract: ec.multisig,
+		CallOpts: bind.CallOpts{Context: ctx},
+	}
+
+	threshold, err := session.GetCurrentThreshold()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current multisig threshold: %w", err)
+	}
+
+	signerCount, err := session.GetValidSignerCount()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get number of signers for multisig: %w", err)
+	}
+
+	status := &MultisigStatus{
+		Threshold:   threshold,
+		SignerCount: signerCount,
+		Validators:  make([]ValidatorSignerStatus, 0, len(validators)),
+	}
+
+	validSigners := 0
+	for _, v := range validators {
+		ok, err := session.IsValidSigner(common.HexToAddress(v.KeyPair.Address))
+		if err != nil {
+			return nil, fmt.Errorf("failed to check signer: %w", err)
+		}
+
+		if ok {
+			validSigners++
+		}
+
+		status.Validators = append(status.Validators, ValidatorSignerStatus{
+			Address:     v.KeyPair.Address,
+			ValidSigner: ok,
+		})
+	}
+
+	status.UnknownSigners = int(signerCount) - validSigners
+
+	return status, nil
+}
+
 func (ec EthereumMultisigClient) createMultiSigControlSession(ctx context.Context, ownerKeyPair Signer) (*multisig.MultisigControlSession, error) {
 	privateKey, err := crypto.HexToECDSA(ownerKeyPair.KeyPair.PrivateKey)
 	if err != nil {
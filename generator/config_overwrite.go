@@ -13,6 +13,16 @@ import (
 	"code.vegaprotocol.io/vegacapsule/types"
 )
 
+// Kinds of node set templates, see TemplateNodeSet.
+const (
+	TemplateKindTendermint = "tendermint"
+	TemplateKindVega       = "vega"
+	TemplateKindDataNode   = "data-node"
+	TemplateKindVisorConf  = "visor-conf"
+	TemplateKindVisorRun   = "visor-run"
+	TemplateKindNomadJob   = "nomad-job"
+)
+
 type configOverride struct {
 	tendermintTmpl *template.Template
 	vegaTmpl       *template.Template
@@ -27,7 +37,7 @@ func newConfigOverride(gen *Generator, n config.NodeConfig) (*configOverride, er
 
 	var tendermintTmpl *template.Template
 	if n.ConfigTemplates.Tendermint != nil {
-		tendermintTmpl, err = tendermint.NewConfigTemplate(*n.ConfigTemplates.Tendermint)
+		tendermintTmpl, err = tendermint.NewConfigTemplate(*n.ConfigTemplates.Tendermint, gen.conf.TemplateFuncs())
 		if err != nil {
 			return nil, err
 		}
@@ -35,7 +45,7 @@ func newConfigOverride(gen *Generator, n config.NodeConfig) (*configOverride, er
 
 	var vegaTmpl *template.Template
 	if n.ConfigTemplates.Vega != nil {
-		vegaTmpl, err = vega.NewConfigTemplate(*n.ConfigTemplates.Vega)
+		vegaTmpl, err = vega.NewConfigTemplate(*n.ConfigTemplates.Vega, gen.conf.TemplateFuncs())
 		if err != nil {
 			return nil, err
 		}
@@ -43,7 +53,7 @@ func newConfigOverride(gen *Generator, n config.NodeConfig) (*configOverride, er
 
 	var dataNodeTmpl *template.Template
 	if n.UseDataNode && n.ConfigTemplates.DataNode != nil {
-		dataNodeTmpl, err = datanode.NewConfigTemplate(*n.ConfigTemplates.DataNode)
+		dataNodeTmpl, err = datanode.NewConfigTemplate(*n.ConfigTemplates.DataNode, gen.conf.TemplateFuncs())
 		if err != nil {
 			return nil, err
 		}
@@ -51,7 +61,7 @@ func newConfigOverride(gen *Generator, n config.NodeConfig) (*configOverride, er
 
 	var visorRunTmpl *template.Template
 	if n.VisorBinary != "" && n.ConfigTemplates.VisorRunConf != nil {
-		visorRunTmpl, err = visor.NewConfigTemplate(*n.ConfigTemplates.VisorRunConf)
+		visorRunTmpl, err = visor.NewConfigTemplate(*n.ConfigTemplates.VisorRunConf, gen.conf.TemplateFuncs())
 		if err != nil {
 			return nil, err
 		}
@@ -59,7 +69,7 @@ func newConfigOverride(gen *Generator, n config.NodeConfig) (*configOverride, er
 
 	var visorConfTmpl *template.Template
 	if n.VisorBinary != "" && n.ConfigTemplates.VisorConf != nil {
-		visorConfTmpl, err = visor.NewConfigTemplate(*n.ConfigTemplates.VisorConf)
+		visorConfTmpl, err = visor.NewConfigTemplate(*n.ConfigTemplates.VisorConf, gen.conf.TemplateFuncs())
 		if err != nil {
 			return nil, err
 		}
@@ -109,3 +119,46 @@ func (co *configOverride) Overwrite(nc config.NodeConfig, ns types.NodeSet, fc *
 
 	return nil
 }
+
+// Template renders the templates without saving them, the result is keyed by the template kind.
+func (co *configOverride) Template(ns types.NodeSet, fc *types.Faucet) (map[string][]byte, error) {
+	out := map[string][]byte{}
+
+	if co.tendermintTmpl != nil {
+		buff, err := co.gen.tendermintGen.TemplateConfig(ns, co.tendermintTmpl)
+		if err != nil {
+			return nil, fmt.Errorf("failed to template Tendermint config for id %d: %w", ns.Index, err)
+		}
+		out[TemplateKindTendermint] = buff.Bytes()
+	}
+	if co.vegaTmpl != nil {
+		buff, err := co.gen.vegaGen.TemplateConfig(ns, fc, co.vegaTmpl)
+		if err != nil {
+			return nil, fmt.Errorf("failed to template Vega config for id %d: %w", ns.Index, err)
+		}
+		out[TemplateKindVega] = buff.Bytes()
+	}
+	if ns.DataNode != nil && co.dataNodeTmpl != nil {
+		buff, err := co.gen.dataNodeGen.TemplateConfig(ns, co.dataNodeTmpl)
+		if err != nil {
+			return nil, fmt.Errorf("failed to template Data Node config for id %d: %w", ns.Index, err)
+		}
+		out[TemplateKindDataNode] = buff.Bytes()
+	}
+	if ns.Visor != nil && co.visorConfTmpl != nil {
+		buff, err := co.gen.visorGen.TemplateConfig(ns, co.visorConfTmpl)
+		if err != nil {
+			return nil, fmt.Errorf("failed to template Visor config for id %d: %w", ns.Index, err)
+		}
+		out[TemplateKindVisorConf] = buff.Bytes()
+	}
+	if ns.Visor != nil && co.visorRunTmpl != nil {
+		buff, err := co.gen.visorGen.TemplateConfig(ns, co.visorRunTmpl)
+		if err != nil {
+			return nil, fmt.Errorf("failed to template Visor genesis run config for id %d: %w", ns.Index, err)
+		}
+		out[TemplateKindVisorRun] = buff.Bytes()
+	}
+
+	return out, nil
+}
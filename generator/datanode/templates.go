@@ -12,7 +12,6 @@ import (
 	"code.vegaprotocol.io/vegacapsule/types"
 
 	"github.com/BurntSushi/toml"
-	"github.com/Masterminds/sprig"
 	"github.com/imdario/mergo"
 )
 
@@ -73,8 +72,8 @@ func (tc ConfigTemplateContext) IPSFPeers() []IPSFPeer {
 	return peersIDs
 }
 
-func NewConfigTemplate(templateRaw string) (*template.Template, error) {
-	t, err := template.New("config.toml").Funcs(sprig.TxtFuncMap()).Parse(templateRaw)
+func NewConfigTemplate(templateRaw string, funcs template.FuncMap) (*template.Template, error) {
+	t, err := template.New("config.toml").Funcs(funcs).Parse(templateRaw)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse template config for data node: %w", err)
 	}
@@ -3,6 +3,7 @@ package faucet
 import (
 	"log"
 
+	"code.vegaprotocol.io/vegacapsule/config"
 	"code.vegaprotocol.io/vegacapsule/utils"
 )
 
@@ -30,3 +31,33 @@ func (cg ConfigGenerator) initiateFaucet(homePath string, phraseFile string) (*i
 
 	return out, nil
 }
+
+type importWalletOutput struct {
+	Wallet struct {
+		FilePath string `json:"filePath"`
+	} `json:"wallet"`
+	Key struct {
+		Public string `json:"publicKey"`
+	} `json:"key"`
+}
+
+func (cg ConfigGenerator) importWallet(homePath, name, phraseFile, recoveryPhraseFile string) (*importWalletOutput, error) {
+	args := []string{
+		config.WalletSubCmd,
+		"--home", homePath,
+		"import",
+		"--output", "json",
+		"--passphrase-file", phraseFile,
+		"--recovery-phrase-file", recoveryPhraseFile,
+		"--wallet", name,
+	}
+
+	log.Printf("Importing faucet wallet with: %s %v", *cg.conf.VegaBinary, args)
+
+	out := &importWalletOutput{}
+	if _, err := utils.ExecuteBinary(*cg.conf.VegaBinary, args, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
@@ -11,10 +11,10 @@ import (
 	"code.vegaprotocol.io/vega/core/faucet"
 	"code.vegaprotocol.io/vega/paths"
 	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/generator/keys"
 	"code.vegaprotocol.io/vegacapsule/types"
 
 	"github.com/BurntSushi/toml"
-	"github.com/Masterminds/sprig"
 	"github.com/imdario/mergo"
 )
 
@@ -25,8 +25,8 @@ type ConfigTemplateContext struct {
 	PublicKey string
 }
 
-func NewConfigTemplate(templateRaw string) (*template.Template, error) {
-	t, err := template.New("config.toml").Funcs(sprig.TxtFuncMap()).Parse(templateRaw)
+func NewConfigTemplate(templateRaw string, funcs template.FuncMap) (*template.Template, error) {
+	t, err := template.New("config.toml").Funcs(funcs).Parse(templateRaw)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse the faucet's template config: %w", err)
 	}
@@ -34,6 +34,8 @@ func NewConfigTemplate(templateRaw string) (*template.Template, error) {
 	return t, nil
 }
 
+const defaultWalletName = "faucet"
+
 type ConfigGenerator struct {
 	conf    *config.Config
 	homeDir string
@@ -61,7 +63,7 @@ func (cg *ConfigGenerator) InitiateAndConfigure(conf *config.FaucetConfig) (*typ
 		return initFaucet, nil
 	}
 
-	configTemplate, err := NewConfigTemplate(conf.Template)
+	configTemplate, err := NewConfigTemplate(conf.Template, cg.conf.TemplateFuncs())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new template config for %q: %w", conf.Name, err)
 	}
@@ -88,6 +90,12 @@ func (cg *ConfigGenerator) Initiate(conf *config.FaucetConfig) (*types.Faucet, e
 		return nil, fmt.Errorf("failed to initiated faucet %q: %w", conf.Name, err)
 	}
 
+	if cg.conf.Seed != nil {
+		if err := cg.importDerivedWallet(keys.NewDeriver(*cg.conf.Seed), walletPassFilePath, initOut); err != nil {
+			return nil, fmt.Errorf("failed to import derived wallet for faucet %q: %w", conf.Name, err)
+		}
+	}
+
 	return &types.Faucet{
 		GeneratedService: types.GeneratedService{
 			Name:           fmt.Sprintf("%s-faucet", cg.conf.Network.Name),
@@ -101,6 +109,50 @@ func (cg *ConfigGenerator) Initiate(conf *config.FaucetConfig) (*types.Faucet, e
 	}, nil
 }
 
+// importDerivedWallet replaces the random faucet wallet with wallet imported from recovery phrase derived from seed.
+func (cg ConfigGenerator) importDerivedWallet(deriver *keys.Deriver, walletPassFilePath string, initOut *initFaucetOutput) error {
+	recoveryPhrase, err := deriver.FaucetRecoveryPhrase()
+	if err != nil {
+		return err
+	}
+
+	recoveryPhraseFile, err := os.CreateTemp("", "vegacapsule-recovery-phrase")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file for recovery phrase: %w", err)
+	}
+	defer os.Remove(recoveryPhraseFile.Name())
+
+	_, err = recoveryPhraseFile.WriteString(recoveryPhrase)
+	recoveryPhraseFile.Close()
+	if err != nil {
+		return fmt.Errorf("failed to write recovery phrase to file: %w", err)
+	}
+
+	walletHome, err := os.MkdirTemp("", "vegacapsule-faucet-wallet")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary wallet home: %w", err)
+	}
+	defer os.RemoveAll(walletHome)
+
+	importOut, err := cg.importWallet(walletHome, defaultWalletName, walletPassFilePath, recoveryPhraseFile.Name())
+	if err != nil {
+		return err
+	}
+
+	wallet, err := os.ReadFile(importOut.Wallet.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read imported wallet: %w", err)
+	}
+
+	if err := os.WriteFile(initOut.FaucetWalletFilePath, wallet, 0o600); err != nil {
+		return fmt.Errorf("failed to overwrite faucet wallet: %w", err)
+	}
+
+	initOut.PublicKey = importOut.Key.Public
+
+	return nil
+}
+
 func (cg ConfigGenerator) OverwriteConfig(fc *types.Faucet, configTemplate *template.Template) error {
 	templateCtx := ConfigTemplateContext{
 		HomeDir:   cg.homeDir,
@@ -11,6 +11,7 @@ import (
 	"code.vegaprotocol.io/vegacapsule/generator/datanode"
 	"code.vegaprotocol.io/vegacapsule/generator/faucet"
 	"code.vegaprotocol.io/vegacapsule/generator/genesis"
+	"code.vegaprotocol.io/vegacapsule/generator/nomad"
 	"code.vegaprotocol.io/vegacapsule/generator/tendermint"
 	"code.vegaprotocol.io/vegacapsule/generator/vega"
 	"code.vegaprotocol.io/vegacapsule/generator/visor"
@@ -212,6 +213,70 @@ func (g *Generator) AddNodeSet(absoluteIndex, relativeIndex, groupIndex int, nc
 	return initNodeSet, nil
 }
 
+// TemplateNodeSet renders templates of the nc node set group for the existing node set ns without saving them.
+// The result is keyed by the template kind and it includes the Nomad job template of the node set.
+func (g *Generator) TemplateNodeSet(nc config.NodeConfig, ns types.NodeSet, fc *types.Faucet) (map[string][]byte, error) {
+	co, err := newConfigOverride(g, nc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new config override: %w", err)
+	}
+
+	out, err := co.Template(ns, fc)
+	if err != nil {
+		return nil, err
+	}
+
+	nomadJob, err := g.templateNomadJob(nc, ns)
+	if err != nil {
+		return nil, err
+	}
+	if nomadJob != nil {
+		out[TemplateKindNomadJob] = []byte(*nomadJob)
+	}
+
+	return out, nil
+}
+
+// OverwriteNodeSet overwrites configs of the existing node set ns with templates of the nc node set group.
+// Node set with the updated Nomad job is returned.
+func (g *Generator) OverwriteNodeSet(nc config.NodeConfig, ns types.NodeSet, fc *types.Faucet) (*types.NodeSet, error) {
+	co, err := newConfigOverride(g, nc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new config override: %w", err)
+	}
+
+	if err := co.Overwrite(nc, ns, fc); err != nil {
+		return nil, fmt.Errorf("failed to overwrite config: %w", err)
+	}
+
+	nomadJob, err := g.templateNomadJob(nc, ns)
+	if err != nil {
+		return nil, err
+	}
+	ns.NomadJobRaw = nomadJob
+
+	return &ns, nil
+}
+
+func (g *Generator) templateNomadJob(nc config.NodeConfig, ns types.NodeSet) (*string, error) {
+	n, err := config.TemplateNodeConfig(config.NodeConfigTemplateContext{NodeNumber: ns.Index}, nc, g.conf.TemplateFuncs())
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute node config templates for %s: %w", nc.Name, err)
+	}
+
+	if n.NomadJobTemplate == nil {
+		return nil, nil
+	}
+
+	nodeJob, err := nomad.GenerateNodeSetTemplate(*n.NomadJobTemplate, ns, g.conf.TemplateFuncs())
+	if err != nil {
+		return nil, err
+	}
+
+	rawJob := nodeJob.String()
+	return &rawJob, nil
+}
+
 func (g *Generator) BackupDataNode(ns types.NodeSet, backupDir string) error {
 	absBackupDir := filepath.Join(*g.conf.OutputDir, backupDir)
 
@@ -9,10 +9,12 @@ import (
 	"os"
 	"path/filepath"
 	"text/template"
+	"time"
 
 	"code.vegaprotocol.io/vega/core/genesis"
 	vgtm "code.vegaprotocol.io/vega/core/tendermint"
 	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/generator/keys"
 	"code.vegaprotocol.io/vegacapsule/types"
 	"code.vegaprotocol.io/vegacapsule/utils"
 
@@ -30,6 +32,8 @@ type Generator struct {
 	vegaBinary  string
 	template    *template.Template
 	templateCtx *TemplateContext
+	// genesisTime is set for networks generated from seed
+	genesisTime *time.Time
 }
 
 func NewGenerator(conf *config.Config, templateRaw string) (*Generator, error) {
@@ -43,10 +47,16 @@ func NewGenerator(conf *config.Config, templateRaw string) (*Generator, error) {
 		return nil, err
 	}
 
+	var genesisTime *time.Time
+	if conf.Seed != nil {
+		genesisTime = &keys.GenesisTime
+	}
+
 	return &Generator{
 		vegaBinary:  *conf.VegaBinary,
 		template:    tpl,
 		templateCtx: templateContext,
+		genesisTime: genesisTime,
 	}, nil
 }
 
@@ -158,6 +168,10 @@ func (g *Generator) generate(nodeSets []types.NodeSet, genValidators []tmtypes.G
 	// TODO should this be inside of template???
 	genDoc.Validators = genValidators
 
+	if g.genesisTime != nil {
+		genDoc.GenesisTime = *g.genesisTime
+	}
+
 	// TODO clean up this genesis merging mess...
 	if err := vgtm.AddAppStateToGenesis(genDoc, genState); err != nil {
 		return nil, err
@@ -13,7 +13,7 @@ import (
 )
 
 func (g *Generator) initiateNodeSet(absoluteIndex, relativeIndex, groupIndex int, nc config.NodeConfig) (*types.NodeSet, error) {
-	n, err := config.TemplateNodeConfig(config.NodeConfigTemplateContext{NodeNumber: absoluteIndex}, nc)
+	n, err := config.TemplateNodeConfig(config.NodeConfigTemplateContext{NodeNumber: absoluteIndex}, nc, g.conf.TemplateFuncs())
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute node config templates for %s: %w", nc.Name, err)
 	}
@@ -59,21 +59,22 @@ func (g *Generator) initiateNodeSet(absoluteIndex, relativeIndex, groupIndex int
 	}
 
 	nodeSet := &types.NodeSet{
-		GroupName:     n.Name,
-		Index:         absoluteIndex,
-		RelativeIndex: relativeIndex,
-		GroupIndex:    groupIndex,
-		Name:          fmt.Sprintf("%s-nodeset-%s-%d-%s", g.conf.Network.Name, n.Name, absoluteIndex, n.Mode),
-		Mode:          n.Mode,
-		Vega:          *initVNode,
-		Tendermint:    *initTNode,
-		DataNode:      initDNode,
-		Visor:         initVisor,
-		PreStartProbe: n.PreStartProbe,
+		GroupName:      n.Name,
+		Index:          absoluteIndex,
+		RelativeIndex:  relativeIndex,
+		GroupIndex:     groupIndex,
+		Name:           fmt.Sprintf("%s-nodeset-%s-%d-%s", g.conf.Network.Name, n.Name, absoluteIndex, n.Mode),
+		Mode:           n.Mode,
+		Vega:           *initVNode,
+		Tendermint:     *initTNode,
+		DataNode:       initDNode,
+		Visor:          initVisor,
+		PreStartProbe:  n.PreStartProbe,
+		ReadinessProbe: n.ReadinessProbe,
 	}
 
 	if n.NomadJobTemplate != nil {
-		nodeJob, err := nomad.GenerateNodeSetTemplate(*n.NomadJobTemplate, *nodeSet)
+		nodeJob, err := nomad.GenerateNodeSetTemplate(*n.NomadJobTemplate, *nodeSet, g.conf.TemplateFuncs())
 		if err != nil {
 			return nil, err
 		}
@@ -150,7 +151,7 @@ func (g *Generator) initAndConfigureFaucet(conf *config.FaucetConfig) (*types.Fa
 }
 
 func (g *Generator) initAndConfigureWallet(conf *config.WalletConfig, validatorsSet, nonValidatorSet []types.NodeSet) (*types.Wallet, error) {
-	walletConfTemplate, err := wallet.NewConfigTemplate(g.conf.Network.Wallet.Template)
+	walletConfTemplate, err := wallet.NewConfigTemplate(g.conf.Network.Wallet.Template, g.conf.TemplateFuncs())
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,102 @@
+package keys
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/crypto/hkdf"
+)
+
+// GenesisTime is the genesis time of networks generated from a seed,
+// the real time would make the genesis differ between generations.
+var GenesisTime = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Deriver derives keys of the network nodes from a seed.
+// Every key is derived from the seed and a purpose bound to the absolute index of the node,
+// so the same seed always gives the same keys to the same node.
+type Deriver struct {
+	seed []byte
+}
+
+func NewDeriver(seed string) *Deriver {
+	return &Deriver{seed: []byte(seed)}
+}
+
+// TendermintNodeKey returns the private key identifying the node in the Tendermint P2P network.
+func (d Deriver) TendermintNodeKey(index int) (ed25519.PrivKey, error) {
+	secret, err := d.derive(fmt.Sprintf("tendermint-node-key-%d", index), 0, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	return ed25519.GenPrivKeyFromSecret(secret), nil
+}
+
+// TendermintValidatorKey returns the private key the node signs blocks with.
+func (d Deriver) TendermintValidatorKey(index int) (ed25519.PrivKey, error) {
+	secret, err := d.derive(fmt.Sprintf("tendermint-validator-key-%d", index), 0, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	return ed25519.GenPrivKeyFromSecret(secret), nil
+}
+
+// VegaRecoveryPhrase returns the recovery phrase of the node Vega wallet.
+func (d Deriver) VegaRecoveryPhrase(index int) (string, error) {
+	entropy, err := d.derive(fmt.Sprintf("vega-wallet-%d", index), 0, 32)
+	if err != nil {
+		return "", err
+	}
+
+	return bip39.NewMnemonic(entropy)
+}
+
+// FaucetRecoveryPhrase returns the recovery phrase of the faucet wallet.
+func (d Deriver) FaucetRecoveryPhrase() (string, error) {
+	entropy, err := d.derive("faucet-wallet", 0, 32)
+	if err != nil {
+		return "", err
+	}
+
+	return bip39.NewMnemonic(entropy)
+}
+
+// EthereumPrivateKey returns hex encoded private key of the node Ethereum wallet.
+func (d Deriver) EthereumPrivateKey(index int) (string, error) {
+	purpose := fmt.Sprintf("ethereum-key-%d", index)
+
+	// derived bytes are not always a valid secp256k1 private key, the next ones are tried then
+	for attempt := 0; attempt < 16; attempt++ {
+		b, err := d.derive(purpose, attempt, 32)
+		if err != nil {
+			return "", err
+		}
+
+		key, err := crypto.ToECDSA(b)
+		if err != nil {
+			continue
+		}
+
+		return hex.EncodeToString(crypto.FromECDSA(key)), nil
+	}
+
+	return "", fmt.Errorf("failed to derive ethereum private key for node %d", index)
+}
+
+func (d Deriver) derive(purpose string, attempt, size int) ([]byte, error) {
+	info := []byte(fmt.Sprintf("vegacapsule/%s/%d", purpose, attempt))
+
+	out := make([]byte, size)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, d.seed, nil, info), out); err != nil {
+		return nil, fmt.Errorf("failed to derive %q key: %w", purpose, err)
+	}
+
+	return out, nil
+}
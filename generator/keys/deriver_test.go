@@ -0,0 +1,62 @@
+package keys_test
+
+import (
+	"testing"
+
+	"code.vegaprotocol.io/vegacapsule/generator/keys"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeriver(t *testing.T) {
+	d := keys.NewDeriver("integration-tests")
+
+	nodeKey, err := d.TendermintNodeKey(0)
+	require.NoError(t, err)
+	validatorKey, err := d.TendermintValidatorKey(0)
+	require.NoError(t, err)
+	phrase, err := d.VegaRecoveryPhrase(0)
+	require.NoError(t, err)
+	ethKey, err := d.EthereumPrivateKey(0)
+	require.NoError(t, err)
+
+	// same seed and index give the same keys
+	sameDeriver := keys.NewDeriver("integration-tests")
+
+	sameNodeKey, err := sameDeriver.TendermintNodeKey(0)
+	require.NoError(t, err)
+	assert.Equal(t, nodeKey, sameNodeKey)
+
+	sameValidatorKey, err := sameDeriver.TendermintValidatorKey(0)
+	require.NoError(t, err)
+	assert.Equal(t, validatorKey, sameValidatorKey)
+
+	samePhrase, err := sameDeriver.VegaRecoveryPhrase(0)
+	require.NoError(t, err)
+	assert.Equal(t, phrase, samePhrase)
+
+	sameEthKey, err := sameDeriver.EthereumPrivateKey(0)
+	require.NoError(t, err)
+	assert.Equal(t, ethKey, sameEthKey)
+
+	assert.NotEqual(t, nodeKey, validatorKey)
+	assert.Len(t, ethKey, 64)
+
+	// different index or seed give different keys
+	otherNodeKey, err := d.TendermintNodeKey(1)
+	require.NoError(t, err)
+	assert.NotEqual(t, nodeKey, otherNodeKey)
+
+	otherPhrase, err := keys.NewDeriver("other").VegaRecoveryPhrase(0)
+	require.NoError(t, err)
+	assert.NotEqual(t, phrase, otherPhrase)
+
+	// faucet wallet does not share the recovery phrase with nodes
+	faucetPhrase, err := d.FaucetRecoveryPhrase()
+	require.NoError(t, err)
+	sameFaucetPhrase, err := sameDeriver.FaucetRecoveryPhrase()
+	require.NoError(t, err)
+	assert.Equal(t, faucetPhrase, sameFaucetPhrase)
+	assert.NotEqual(t, phrase, faucetPhrase)
+}
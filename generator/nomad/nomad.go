@@ -6,12 +6,10 @@ import (
 	"text/template"
 
 	"code.vegaprotocol.io/vegacapsule/types"
-
-	"github.com/Masterminds/sprig"
 )
 
-func GenerateNodeSetTemplate(templateRaw string, ns types.NodeSet) (*bytes.Buffer, error) {
-	t, err := template.New("nomad_job.hcl").Funcs(sprig.TxtFuncMap()).Parse(templateRaw)
+func GenerateNodeSetTemplate(templateRaw string, ns types.NodeSet, funcs template.FuncMap) (*bytes.Buffer, error) {
+	t, err := template.New("nomad_job.hcl").Funcs(funcs).Parse(templateRaw)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse template config for nomad job: %w", err)
 	}
@@ -32,8 +30,8 @@ type PreGenerateTemplateCtx struct {
 	CapsuleBinary string
 }
 
-func GeneratePreGenerateTemplate(templateRaw string, ctx PreGenerateTemplateCtx) (*bytes.Buffer, error) {
-	t, err := template.New("nomad_job.hcl").Funcs(sprig.TxtFuncMap()).Parse(templateRaw)
+func GeneratePreGenerateTemplate(templateRaw string, ctx PreGenerateTemplateCtx, funcs template.FuncMap) (*bytes.Buffer, error) {
+	t, err := template.New("nomad_job.hcl").Funcs(funcs).Parse(templateRaw)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse template config for nomad job: %w", err)
 	}
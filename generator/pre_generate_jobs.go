@@ -39,7 +39,7 @@ func (g *Generator) templatePreGenerateJobs(preGenConf *config.PreGenerate, inde
 			Index:         index,
 			LogsDir:       g.conf.LogsDir(),
 			CapsuleBinary: *g.conf.VegaCapsuleBinary,
-		})
+		}, g.conf.TemplateFuncs())
 		if err != nil {
 			return nil, fmt.Errorf("failed to template nomad job for pre generate %q: %w", nc.Name, err)
 		}
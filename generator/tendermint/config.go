@@ -0,0 +1,47 @@
+package tendermint
+
+import (
+	"fmt"
+	"strings"
+
+	"code.vegaprotocol.io/vegacapsule/ports"
+
+	tmconfig "github.com/cometbft/cometbft/config"
+	"github.com/spf13/viper"
+)
+
+// p2pProxyPortName is the name of ports allocated for P2P proxies.
+const p2pProxyPortName = "tendermint-p2p-proxy"
+
+// P2PProxyPortName returns name of the port allocated for the proxy of P2P connections
+// from node set with index `from` to node set with index `to`.
+func P2PProxyPortName(from, to int) string {
+	return ports.PortName(p2pProxyPortName, from, to)
+}
+
+// ReadConfig reads generated Tendermint config, missing values are set to the defaults.
+func ReadConfig(configPath string) (*tmconfig.Config, error) {
+	v := viper.New()
+	v.SetConfigFile(configPath)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config file %q: %w", configPath, err)
+	}
+
+	conf := tmconfig.DefaultConfig()
+	if err := v.Unmarshal(conf); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config file %q: %w", configPath, err)
+	}
+
+	return conf, nil
+}
+
+// LocalAddress returns address a listen address can be reached at from the same host,
+// e.g. tcp://0.0.0.0:26657 is reachable at 127.0.0.1:26657.
+func LocalAddress(listenAddress string) string {
+	addr := listenAddress
+	if i := strings.Index(addr, "://"); i != -1 {
+		addr = addr[i+3:]
+	}
+
+	return strings.Replace(addr, "0.0.0.0:", "127.0.0.1:", 1)
+}
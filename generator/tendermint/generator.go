@@ -1,14 +1,17 @@
 package tendermint
 
 import (
+	"bytes"
 	"encoding/base64"
 	"fmt"
 	"log"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 
 	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/generator/keys"
 	"code.vegaprotocol.io/vegacapsule/types"
 	"code.vegaprotocol.io/vegacapsule/utils"
 
@@ -42,7 +45,7 @@ type ConfigGenerator struct {
 	nodes         []node
 }
 
-func newGenValidator(nodeDir string, config *tmconfig.Config) (*tmtypes.GenesisValidator, error) {
+func newGenValidator(nodeDir string, config *tmconfig.Config, seeded bool) (*tmtypes.GenesisValidator, error) {
 	pv := privval.LoadFilePV(config.BaseConfig.PrivValidatorKeyFile(), config.BaseConfig.PrivValidatorStateFile())
 
 	pubKey, err := pv.GetPubKey()
@@ -50,11 +53,17 @@ func newGenValidator(nodeDir string, config *tmconfig.Config) (*tmtypes.GenesisV
 		return nil, err
 	}
 
+	name := nodeDir
+	if seeded {
+		// node directory name doesn't depend on network home, so the same network generated elsewhere has the same genesis
+		name = filepath.Base(nodeDir)
+	}
+
 	return &tmtypes.GenesisValidator{
 		Address: pubKey.Address(),
 		PubKey:  pubKey,
 		Power:   1,
-		Name:    nodeDir,
+		Name:    name,
 	}, nil
 }
 
@@ -79,7 +88,7 @@ func NewConfigGenerator(conf *config.Config, generatedNodeSets []types.NodeSet)
 		}
 		config := tmconfig.DefaultConfig()
 		config.SetRoot(tn.Tendermint.HomeDir)
-		genValidator, err := newGenValidator(tn.Tendermint.HomeDir, config)
+		genValidator, err := newGenValidator(tn.Tendermint.HomeDir, config, conf.Seed != nil)
 		if err != nil {
 			return nil, err
 		}
@@ -136,6 +145,12 @@ func (tg *ConfigGenerator) Initiate(index int, mode, groupName string) (*types.T
 	config := tmconfig.DefaultConfig()
 	config.SetRoot(nodeDir)
 
+	if tg.conf.Seed != nil {
+		if err := saveDerivedKeys(keys.NewDeriver(*tg.conf.Seed), index, config); err != nil {
+			return nil, fmt.Errorf("failed to save keys derived from seed: %w", err)
+		}
+	}
+
 	nodeKey, err := tmp2p.LoadNodeKey(config.NodeKeyFile())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get node key: %w", err)
@@ -166,7 +181,7 @@ func (tg *ConfigGenerator) Initiate(index int, mode, groupName string) (*types.T
 		return initNode, nil
 	}
 
-	genValidator, err := newGenValidator(nodeDir, config)
+	genValidator, err := newGenValidator(nodeDir, config, tg.conf.Seed != nil)
 	if err != nil {
 		return nil, err
 	}
@@ -177,8 +192,43 @@ func (tg *ConfigGenerator) Initiate(index int, mode, groupName string) (*types.T
 	return initNode, nil
 }
 
+// saveDerivedKeys replaces random keys created by Tendermint init with keys derived from seed.
+func saveDerivedKeys(deriver *keys.Deriver, index int, config *tmconfig.Config) error {
+	nodePrivKey, err := deriver.TendermintNodeKey(index)
+	if err != nil {
+		return err
+	}
+
+	nodeKey := &tmp2p.NodeKey{PrivKey: nodePrivKey}
+	if err := nodeKey.SaveAs(config.NodeKeyFile()); err != nil {
+		return fmt.Errorf("failed to save node key: %w", err)
+	}
+
+	validatorPrivKey, err := deriver.TendermintValidatorKey(index)
+	if err != nil {
+		return err
+	}
+
+	privval.NewFilePV(validatorPrivKey, config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()).Save()
+
+	return nil
+}
+
+// GenesisValidators returns validators in order nodes were initiated in. Validators of network generated
+// from seed are sorted by address, so the genesis doesn't depend on the order.
 func (tg ConfigGenerator) GenesisValidators() []tmtypes.GenesisValidator {
-	return tg.genValidators
+	if tg.conf.Seed == nil {
+		return tg.genValidators
+	}
+
+	validators := make([]tmtypes.GenesisValidator, len(tg.genValidators))
+	copy(validators, tg.genValidators)
+
+	sort.Slice(validators, func(i, j int) bool {
+		return bytes.Compare(validators[i].Address, validators[j].Address) < 0
+	})
+
+	return validators
 }
 
 func (tg ConfigGenerator) nodeDir(i int) string {
@@ -0,0 +1,81 @@
+package tendermint_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/generator/tendermint"
+	"code.vegaprotocol.io/vegacapsule/types"
+	"code.vegaprotocol.io/vegacapsule/utils"
+
+	tmconfig "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/privval"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newValidatorNodeSets(t *testing.T, count int) []types.NodeSet {
+	homeDir := t.TempDir()
+
+	nodeSets := make([]types.NodeSet, 0, count)
+	for i := 0; i < count; i++ {
+		nodeDir := filepath.Join(homeDir, fmt.Sprintf("node%d", i))
+
+		conf := tmconfig.DefaultConfig()
+		conf.SetRoot(nodeDir)
+		require.NoError(t, os.MkdirAll(filepath.Dir(conf.PrivValidatorKeyFile()), 0o755))
+		require.NoError(t, os.MkdirAll(filepath.Dir(conf.PrivValidatorStateFile()), 0o755))
+		privval.GenFilePV(conf.PrivValidatorKeyFile(), conf.PrivValidatorStateFile()).Save()
+
+		nodeSets = append(nodeSets, types.NodeSet{
+			Name:  fmt.Sprintf("node-set-%d", i),
+			Mode:  types.NodeModeValidator,
+			Index: i,
+			Tendermint: types.TendermintNode{
+				GeneratedService: types.GeneratedService{HomeDir: nodeDir},
+			},
+		})
+	}
+
+	return nodeSets
+}
+
+func TestGenesisValidators(t *testing.T) {
+	nodeSets := newValidatorNodeSets(t, 4)
+
+	t.Run("without seed keep node order and directory", func(t *testing.T) {
+		conf, err := config.DefaultConfig()
+		require.NoError(t, err)
+
+		tg, err := tendermint.NewConfigGenerator(conf, nodeSets)
+		require.NoError(t, err)
+
+		validators := tg.GenesisValidators()
+		require.Len(t, validators, len(nodeSets))
+		for i, v := range validators {
+			assert.Equal(t, nodeSets[i].Tendermint.HomeDir, v.Name)
+		}
+	})
+
+	t.Run("with seed are sorted by address and named by directory name", func(t *testing.T) {
+		conf, err := config.DefaultConfig()
+		require.NoError(t, err)
+		conf.Seed = utils.ToPoint("integration-tests")
+
+		tg, err := tendermint.NewConfigGenerator(conf, nodeSets)
+		require.NoError(t, err)
+
+		validators := tg.GenesisValidators()
+		require.Len(t, validators, len(nodeSets))
+		for i, v := range validators {
+			assert.Equal(t, filepath.Base(v.Name), v.Name)
+			if i > 0 {
+				assert.Negative(t, bytes.Compare(validators[i-1].Address, v.Address))
+			}
+		}
+	})
+}
@@ -5,11 +5,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"text/template"
 
 	"code.vegaprotocol.io/vegacapsule/types"
 
-	"github.com/Masterminds/sprig"
 	tmconfig "github.com/cometbft/cometbft/config"
 	"github.com/spf13/viper"
 )
@@ -23,8 +23,8 @@ type ConfigTemplateContext struct {
 	nodes                []node
 }
 
-func NewConfigTemplate(templateRaw string) (*template.Template, error) {
-	t, err := template.New("config.toml").Funcs(sprig.TxtFuncMap()).Parse(templateRaw)
+func NewConfigTemplate(templateRaw string, funcs template.FuncMap) (*template.Template, error) {
+	t, err := template.New("config.toml").Funcs(funcs).Parse(templateRaw)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse template config: %w", err)
 	}
@@ -218,9 +218,52 @@ func (tg *ConfigGenerator) mergeAndSaveConfig(
 		return fmt.Errorf("failed to validated merged config file %q: %w", configPath, err)
 	}
 
+	if tg.conf.P2PProxy {
+		peers, err := tg.proxiedPeers(ns, conf.P2P.PersistentPeers)
+		if err != nil {
+			return fmt.Errorf("failed to proxy persistent peers of config file %q: %w", configPath, err)
+		}
+		conf.P2P.PersistentPeers = peers
+	}
+
 	// save
 	conf.SetRoot(ns.Tendermint.HomeDir)
 	tmconfig.WriteConfigFile(saveConfigPath, conf)
 
 	return nil
 }
+
+// proxiedPeers replaces addresses of the persistent peers generated by Capsule with addresses of proxies,
+// one for each link between the node sets. Other peers are kept as they are.
+func (tg *ConfigGenerator) proxiedPeers(ns types.NodeSet, persistentPeers string) (string, error) {
+	if persistentPeers == "" {
+		return "", nil
+	}
+
+	nodeIndexes := make(map[string]int, len(tg.nodes))
+	for _, n := range tg.nodes {
+		nodeIndexes[n.id] = n.index
+	}
+
+	peers := strings.Split(persistentPeers, ",")
+	for i, peer := range peers {
+		id, _, ok := strings.Cut(strings.TrimSpace(peer), "@")
+		if !ok {
+			continue
+		}
+
+		index, ok := nodeIndexes[id]
+		if !ok || index == ns.Index {
+			continue
+		}
+
+		port, err := tg.conf.PortAllocator().Port(P2PProxyPortName(ns.Index, index))
+		if err != nil {
+			return "", err
+		}
+
+		peers[i] = fmt.Sprintf("%s@127.0.0.1:%d", id, port)
+	}
+
+	return strings.Join(peers, ","), nil
+}
@@ -81,6 +81,27 @@ func (vg ConfigGenerator) createWallet(binaryPath, homePath, name, walletPhraseF
 	return out, nil
 }
 
+func (vg ConfigGenerator) importWallet(binaryPath, homePath, name, walletPhraseFilePath, recoveryPhraseFilePath string) (*createWalletOutput, error) {
+	args := []string{
+		config.WalletSubCmd,
+		"--home", homePath,
+		"import",
+		"--output", "json",
+		"--passphrase-file", walletPhraseFilePath,
+		"--recovery-phrase-file", recoveryPhraseFilePath,
+		"--wallet", name,
+	}
+
+	log.Printf("Importing vega wallet with: %s %v", binaryPath, args)
+
+	out := &createWalletOutput{}
+	if _, err := utils.ExecuteBinary(binaryPath, args, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
 func (vg ConfigGenerator) describeWallet(binaryPath, homePath, name, walletPhraseFilePath string) (*describeWalletOutput, error) {
 	args := []string{
 		config.WalletSubCmd,
@@ -168,6 +189,28 @@ func (vg ConfigGenerator) importTendermintNodeWallet(binaryPath, homePath string
 	return nwo, nil
 }
 
+func (vg ConfigGenerator) importEthereumNodeWallet(binaryPath, homePath, nodeWalletPhraseFile, walletPhraseFile, walletFilePath string) (*importNodeWalletOutput, error) {
+	args := []string{
+		"nodewallet",
+		"--home", homePath,
+		"--passphrase-file", nodeWalletPhraseFile,
+		"import",
+		"--output", "json",
+		"--chain", types.NodeWalletChainTypeEthereum,
+		"--wallet-passphrase-file", walletPhraseFile,
+		"--wallet-path", walletFilePath,
+	}
+
+	log.Printf("Importing node ethereum wallet with: %s %v", binaryPath, args)
+
+	out := &importNodeWalletOutput{}
+	if _, err := utils.ExecuteBinary(binaryPath, args, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
 func (vg ConfigGenerator) importVegaNodeWallet(binaryPath, homePath, nodeWalletPhraseFile, walletPhraseFile, walletFilePath string) (*importNodeWalletOutput, error) {
 	args := []string{
 		"nodewallet",
@@ -10,6 +10,7 @@ import (
 
 	"code.vegaprotocol.io/vegacapsule/config"
 	"code.vegaprotocol.io/vegacapsule/ethereum"
+	"code.vegaprotocol.io/vegacapsule/generator/keys"
 	"code.vegaprotocol.io/vegacapsule/types"
 	"code.vegaprotocol.io/vegacapsule/utils"
 )
@@ -122,7 +123,18 @@ func (vg ConfigGenerator) initiateValidatorWallets(
 		return nil, fmt.Errorf("failed to write ethereum wallet passphrase to file: %w", err)
 	}
 
-	vegaOut, err := vg.createWallet(vegaBinary, nodeDir, defaultIsolatedWalletName, walletPassFilePath)
+	var deriver *keys.Deriver
+	if vg.conf.Seed != nil {
+		deriver = keys.NewDeriver(*vg.conf.Seed)
+	}
+
+	var vegaOut *createWalletOutput
+	var err error
+	if deriver != nil {
+		vegaOut, err = vg.importDerivedWallet(deriver, index, vegaBinary, nodeDir, defaultIsolatedWalletName, walletPassFilePath)
+	} else {
+		vegaOut, err = vg.createWallet(vegaBinary, nodeDir, defaultIsolatedWalletName, walletPassFilePath)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create vega wallet: %w", err)
 	}
@@ -164,13 +176,18 @@ func (vg ConfigGenerator) initiateValidatorWallets(
 		nwi.EthereumAddress = clefAccountAddr
 		nwi.EthereumClefRPCAddress = clefConf.ClefRPCAddr
 	} else {
-		ethOut, err := vg.generateNodeWallet(
-			vegaBinary,
-			nodeDir,
-			nodeWalletPassFilePath,
-			ethereumPassFilePath,
-			types.NodeWalletChainTypeEthereum,
-		)
+		var ethOut *generateNodeWalletOutput
+		if deriver != nil {
+			ethOut, err = vg.importDerivedEthereumWallet(deriver, index, vegaBinary, nodeDir, nodeWalletPassFilePath, ethereumPassFilePath)
+		} else {
+			ethOut, err = vg.generateNodeWallet(
+				vegaBinary,
+				nodeDir,
+				nodeWalletPassFilePath,
+				ethereumPassFilePath,
+				types.NodeWalletChainTypeEthereum,
+			)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to generate %q wallet: %w", types.NodeWalletChainTypeEthereum, err)
 		}
@@ -196,6 +213,61 @@ func (vg ConfigGenerator) initiateValidatorWallets(
 	return nwi, nil
 }
 
+// importDerivedWallet creates the Vega wallet from recovery phrase derived from seed.
+func (vg ConfigGenerator) importDerivedWallet(deriver *keys.Deriver, index int, vegaBinary, nodeDir, name, walletPassFilePath string) (*createWalletOutput, error) {
+	recoveryPhrase, err := deriver.VegaRecoveryPhrase(index)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryPhraseFile, err := os.CreateTemp("", "vegacapsule-recovery-phrase")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file for recovery phrase: %w", err)
+	}
+	defer os.Remove(recoveryPhraseFile.Name())
+
+	_, err = recoveryPhraseFile.WriteString(recoveryPhrase)
+	recoveryPhraseFile.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to write recovery phrase to file: %w", err)
+	}
+
+	out, err := vg.importWallet(vegaBinary, nodeDir, name, walletPassFilePath, recoveryPhraseFile.Name())
+	if err != nil {
+		return nil, err
+	}
+	out.Wallet.RecoveryPhrase = recoveryPhrase
+
+	return out, nil
+}
+
+// importDerivedEthereumWallet imports Ethereum private key derived from seed to the node wallet.
+func (vg ConfigGenerator) importDerivedEthereumWallet(
+	deriver *keys.Deriver,
+	index int,
+	vegaBinary, nodeDir, nodeWalletPassFilePath, ethereumPassFilePath string,
+) (*generateNodeWalletOutput, error) {
+	privateKey, err := deriver.EthereumPrivateKey(index)
+	if err != nil {
+		return nil, err
+	}
+
+	ks, err := ethereum.ImportPrivateKeyIntoKeystore(privateKey, ethereumPassFilePath, EthereumWalletPath(nodeDir))
+	if err != nil {
+		return nil, fmt.Errorf("failed to import private key into keystore: %w", err)
+	}
+
+	out, err := vg.importEthereumNodeWallet(vegaBinary, nodeDir, nodeWalletPassFilePath, ethereumPassFilePath, ks.FilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &generateNodeWalletOutput{
+		RegistryFilePath: out.RegistryFilePath,
+		WalletFilePath:   out.WalletFilePath,
+	}, nil
+}
+
 func (vg ConfigGenerator) nodeDir(i int) string {
 	nodeDirName := fmt.Sprintf("%s%d", vg.conf.NodeDirPrefix, i)
 	return filepath.Join(vg.homeDir, nodeDirName)
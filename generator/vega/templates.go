@@ -12,7 +12,6 @@ import (
 	"code.vegaprotocol.io/vegacapsule/types"
 
 	"github.com/BurntSushi/toml"
-	"github.com/Masterminds/sprig"
 	"github.com/imdario/mergo"
 )
 
@@ -29,8 +28,8 @@ type ConfigTemplateContext struct {
 	NodeHomeDir          string
 }
 
-func NewConfigTemplate(templateRaw string) (*template.Template, error) {
-	t, err := template.New("config.toml").Funcs(sprig.TxtFuncMap()).Parse(templateRaw)
+func NewConfigTemplate(templateRaw string, funcs template.FuncMap) (*template.Template, error) {
+	t, err := template.New("config.toml").Funcs(funcs).Parse(templateRaw)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse template config: %w", err)
 	}
@@ -12,7 +12,6 @@ import (
 	"code.vegaprotocol.io/vegacapsule/types"
 
 	"github.com/BurntSushi/toml"
-	"github.com/Masterminds/sprig"
 	"github.com/imdario/mergo"
 )
 
@@ -20,8 +19,8 @@ type ConfigTemplateContext struct {
 	NodeSet types.NodeSet
 }
 
-func NewConfigTemplate(templateRaw string) (*template.Template, error) {
-	t, err := template.New("run-config.toml").Funcs(sprig.TxtFuncMap()).Parse(templateRaw)
+func NewConfigTemplate(templateRaw string, funcs template.FuncMap) (*template.Template, error) {
+	t, err := template.New("run-config.toml").Funcs(funcs).Parse(templateRaw)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse template config: %w", err)
 	}
@@ -169,7 +169,7 @@ func regenerateTendermintConfig(generatedNodeSets types.NodeSetMap, config *conf
 			continue
 		}
 
-		tmpl, err := tmgen.NewConfigTemplate(*nodeSetGroup.ConfigTemplates.Tendermint)
+		tmpl, err := tmgen.NewConfigTemplate(*nodeSetGroup.ConfigTemplates.Tendermint, config.TemplateFuncs())
 		if err != nil {
 			errs.Add(fmt.Errorf("failed to create tendermint template for the `%s` node set group: %w", nodeSetGroup.Name, err))
 			continue
@@ -0,0 +1,84 @@
+package jobrunner
+
+import (
+	"context"
+	"fmt"
+
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/nomad"
+	"code.vegaprotocol.io/vegacapsule/supervisor"
+	"code.vegaprotocol.io/vegacapsule/types"
+)
+
+// JobRunner runs and stops jobs of the network.
+// It's implemented by the Nomad runner and by the native runner supervising jobs as child processes.
+type JobRunner interface {
+	RunRawNomadJobs(ctx context.Context, rawJobs []string) ([]types.RawJobWithNomadJob, error)
+	RunNodeSets(ctx context.Context, nodeSets []types.NodeSet, stopOnFailure bool) ([]string, error)
+	RunDockerJob(ctx context.Context, dc config.DockerConfig) (string, error)
+	RunExecJob(ctx context.Context, ec config.ExecConfig) (string, error)
+	StartFaucet(ctx context.Context, faucetConfig *config.FaucetConfig, genFaucet *types.Faucet) (string, error)
+	StartWallet(ctx context.Context, walletConfig *config.WalletConfig, genWallet *types.Wallet) (string, error)
+	StartNetwork(ctx context.Context, conf *config.Config, generatedSvcs *types.GeneratedServices, stopAllJobsOnFailure bool) (*types.NetworkJobs, error)
+	StopNetwork(ctx context.Context, jobs *types.NetworkJobs, nodesOnly bool) ([]string, error)
+	StopJobs(ctx context.Context, jobIDs []string) ([]string, error)
+	ListExposedPorts(ctx context.Context) (map[string][]int64, error)
+	JobStatus(ctx context.Context, jobID string) (*types.JobStatus, error)
+	// SignalTask sends the signal, e.g. SIGKILL, to the task of the running job.
+	SignalTask(ctx context.Context, jobID, taskName, signal string) error
+	// RestartTask restarts the task of the running job.
+	RestartTask(ctx context.Context, jobID, taskName string) error
+	IgnoreJobsWithPrefixes(prefixes ...string)
+}
+
+// New returns runner of the backend selected in the config. The Nomad runner is returned for nil config.
+func New(conf *config.Config) (JobRunner, error) {
+	var logsDir, capsuleBinary string
+	if conf != nil {
+		logsDir = conf.LogsDir()
+
+		if conf.VegaCapsuleBinary != nil {
+			capsuleBinary = *conf.VegaCapsuleBinary
+		}
+	}
+
+	if conf == nil || conf.RunnerBackend() == config.RunnerBackendNomad {
+		nomadClient, err := nomad.NewClient(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create nomad client: %w", err)
+		}
+
+		nomadRunner, err := nomad.NewJobRunner(nomadClient, capsuleBinary, logsDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create job runner: %w", err)
+		}
+
+		return nomadRunner, nil
+	}
+
+	restartDelay, err := conf.Runner.GetRestartDelay()
+	if err != nil {
+		return nil, err
+	}
+
+	killTimeout, err := conf.Runner.GetKillTimeout()
+	if err != nil {
+		return nil, err
+	}
+
+	nativeRunner, err := supervisor.NewJobRunner(
+		capsuleBinary,
+		logsDir,
+		conf.SupervisorDir(),
+		supervisor.RestartPolicy{
+			Attempts: conf.Runner.RestartAttempts,
+			Delay:    restartDelay,
+		},
+		killTimeout,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create native job runner: %w", err)
+	}
+
+	return nativeRunner, nil
+}
@@ -0,0 +1,673 @@
+package k8s
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/ports"
+	"code.vegaprotocol.io/vegacapsule/supervisor"
+	"code.vegaprotocol.io/vegacapsule/types"
+	"code.vegaprotocol.io/vegacapsule/utils"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	DefaultInitImage = "busybox:1.36"
+
+	labelName   = "app.kubernetes.io/name"
+	labelPartOf = "app.kubernetes.io/part-of"
+
+	homeVolumeName = "home"
+	seedVolumeName = "seed"
+	seedMountPath  = "/seed"
+	seededMarker   = ".capsule-seeded"
+
+	// the same as ephemeral disk and kill timeout of the Nomad jobs
+	defaultHomeSizeMB             = 550
+	terminationGracePeriodSeconds = 20
+)
+
+const emptyPrivValidatorState = `{
+  "height": "0",
+  "round": 0,
+  "step": 0
+}
+`
+
+var (
+	invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+	invalidKeyChars  = regexp.MustCompile(`[^-._a-zA-Z0-9]+`)
+
+	// the same as resources of the default Nomad jobs
+	defaultResources = config.Resources{CPU: utils.ToPoint(500), MemoryMB: utils.ToPoint(512)}
+	visorResources   = config.Resources{CPU: utils.ToPoint(1000), MemoryMB: utils.ToPoint(512)}
+)
+
+type Options struct {
+	// Image runs the Vega binaries, the binaries must be present in it at the same paths as on the host.
+	Image string
+	// InitImage copies the generated files into the home volume before the first start.
+	InitImage string
+	// ConfigPorts returns ports defined in the config file of a generated service.
+	// ports.ExtractPortsFromConfig is used when not set.
+	ConfigPorts func(configPath string) (map[int64]string, error)
+}
+
+// Manifests are Kubernetes objects running the network, one StatefulSet with its Service,
+// ConfigMap and Secret is created per job.
+type Manifests struct {
+	ConfigMaps   []ConfigMap
+	Secrets      []Secret
+	Services     []Service
+	StatefulSets []StatefulSet
+}
+
+type job struct {
+	name        string
+	containers  []Container
+	hostNetwork bool
+	homeFiles   []string
+	ports       []int64
+	// targetPorts maps service ports to container ports of Docker services running without host network
+	targetPorts map[int64]int64
+	volumes     []Volume
+	// exclude and override apply to the home files
+	exclude  map[string]bool
+	override map[string][]byte
+}
+
+// New creates Kubernetes manifests running the same tasks as the default Nomad jobs of the network.
+// Files generated into the homes of the services are packaged into ConfigMaps and Secrets and copied
+// into a persistent volume mounted to the same path as the network home has on the host.
+// Vega services use host network, so generated configs of the nodes don't need any change.
+func New(conf *config.Config, genServices types.GeneratedServices, opts Options) (*Manifests, error) {
+	if opts.Image == "" {
+		return nil, errors.New("image running the Vega binaries must be set")
+	}
+	if opts.InitImage == "" {
+		opts.InitImage = DefaultInitImage
+	}
+	if opts.ConfigPorts == nil {
+		opts.ConfigPorts = ports.ExtractPortsFromConfig
+	}
+
+	if len(genServices.PreGenerateJobs) != 0 {
+		log.Println("pre generate Nomad jobs can't be exported and are left out")
+	}
+
+	jobs := []job{}
+
+	for _, ns := range genServices.NodeSets.ToSlice() {
+		if ns.NomadJobRaw != nil {
+			log.Printf("node set %q has custom Nomad job definition, it's exported with the default one", ns.Name)
+		}
+
+		res := defaultResources
+		if ns.Visor != nil {
+			res = visorResources
+		}
+
+		homes := []string{ns.Vega.HomeDir, ns.Tendermint.HomeDir}
+		if ns.DataNode != nil {
+			homes = append(homes, ns.DataNode.HomeDir)
+		}
+		if ns.Visor != nil {
+			homes = append(homes, ns.Visor.HomeDir)
+		}
+
+		exclude, override, err := chainData(ns)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get chain data of %q: %w", ns.Name, err)
+		}
+
+		jobs = append(jobs, job{
+			name:        ns.Name,
+			containers:  taskContainers(opts.Image, res, supervisor.NodeSetTasks(ns)...),
+			hostNetwork: true,
+			homeFiles:   homes,
+			exclude:     exclude,
+			override:    override,
+		})
+	}
+
+	if w := genServices.Wallet; w != nil {
+		task := supervisor.WalletTask(w)
+		task.Name = w.Name
+
+		homes := []string{w.HomeDir}
+		if w.TokenPassphrasePath != "" {
+			homes = append(homes, w.TokenPassphrasePath)
+		}
+
+		jobs = append(jobs, job{
+			name:        w.Name,
+			containers:  taskContainers(opts.Image, defaultResources, task),
+			hostNetwork: true,
+			homeFiles:   homes,
+		})
+	}
+
+	if fc := genServices.Faucet; fc != nil && conf.Network.Faucet != nil {
+		task := supervisor.FaucetTask(conf.Network.Faucet, fc)
+		task.Name = fc.Name
+
+		jobs = append(jobs, job{
+			name:        fc.Name,
+			containers:  taskContainers(opts.Image, defaultResources, task),
+			hostNetwork: true,
+			homeFiles:   []string{fc.HomeDir, fc.WalletPassFilePath},
+		})
+	}
+
+	for i := range jobs {
+		for _, gs := range genServices.GetByName(jobs[i].name) {
+			if gs.ConfigFilePath == "" {
+				continue
+			}
+
+			configPorts, err := opts.ConfigPorts(gs.ConfigFilePath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to extract ports of %q: %w", jobs[i].name, err)
+			}
+
+			for port := range configPorts {
+				if port > 0 && !slices.Contains(jobs[i].ports, port) {
+					jobs[i].ports = append(jobs[i].ports, port)
+				}
+			}
+		}
+	}
+
+	for _, sc := range []*config.PStartConfig{conf.Network.PreStart, conf.Network.PostStart} {
+		if sc == nil {
+			continue
+		}
+
+		for _, dc := range sc.Docker {
+			jobs = append(jobs, dockerJob(dc))
+		}
+
+		for _, ec := range sc.Exec {
+			jobs = append(jobs, job{
+				name: ec.Name,
+				containers: []Container{{
+					Name:      containerName(ec.Name),
+					Image:     opts.Image,
+					Command:   []string{ec.Command},
+					Args:      ec.Args,
+					Env:       envVars(ec.Env),
+					Resources: resources(defaultResources),
+				}},
+				hostNetwork: true,
+			})
+		}
+	}
+
+	m := &Manifests{}
+	names := map[string]bool{}
+
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].name < jobs[j].name })
+
+	for _, j := range jobs {
+		j.name = objectName(j.name)
+		if names[j.name] {
+			return nil, fmt.Errorf("duplicate job name %q", j.name)
+		}
+		names[j.name] = true
+
+		if err := m.add(conf, j, opts); err != nil {
+			return nil, fmt.Errorf("failed to create manifests of %q: %w", j.name, err)
+		}
+	}
+
+	return m, nil
+}
+
+func (m *Manifests) add(conf *config.Config, j job, opts Options) error {
+	labels := map[string]string{
+		labelName:   j.name,
+		labelPartOf: objectName(conf.Network.Name),
+	}
+	meta := func(name string) ObjectMeta {
+		return ObjectMeta{Name: name, Labels: labels}
+	}
+
+	sort.Slice(j.ports, func(a, b int) bool { return j.ports[a] < j.ports[b] })
+
+	// ports of the host network can't be declared by more containers of the pod, they are all declared by the first one
+	if j.hostNetwork && len(j.containers) != 0 {
+		for _, p := range j.ports {
+			j.containers[0].Ports = append(j.containers[0].Ports, ContainerPort{ContainerPort: p})
+		}
+	}
+
+	if len(j.ports) != 0 {
+		svc := Service{
+			APIVersion: "v1",
+			Kind:       "Service",
+			Metadata:   meta(j.name),
+			Spec:       ServiceSpec{Selector: labels},
+		}
+
+		for _, p := range j.ports {
+			target := p
+			if to, ok := j.targetPorts[p]; ok {
+				target = to
+			}
+
+			svc.Spec.Ports = append(svc.Spec.Ports, ServicePort{
+				Name:       fmt.Sprintf("port-%d", p),
+				Port:       p,
+				TargetPort: target,
+			})
+		}
+
+		m.Services = append(m.Services, svc)
+	}
+
+	spec := PodSpec{
+		TerminationGracePeriodSeconds: terminationGracePeriodSeconds,
+		Containers:                    j.containers,
+		Volumes:                       j.volumes,
+	}
+	if j.hostNetwork {
+		spec.HostNetwork = true
+		spec.DNSPolicy = "ClusterFirstWithHostNet"
+	}
+
+	ss := StatefulSet{
+		APIVersion: "apps/v1",
+		Kind:       "StatefulSet",
+		Metadata:   meta(j.name),
+		Spec: StatefulSetSpec{
+			ServiceName: j.name,
+			Replicas:    1,
+			Selector:    LabelSelector{MatchLabels: labels},
+			Template: PodTemplateSpec{
+				Metadata: ObjectMeta{Labels: labels},
+			},
+		},
+	}
+
+	if len(j.homeFiles) != 0 {
+		cm, secret, err := homeFiles(*conf.OutputDir, j.homeFiles, j.exclude, j.override)
+		if err != nil {
+			return err
+		}
+
+		networkHome := *conf.OutputDir
+		projected := &ProjectedVolumeSource{}
+
+		if len(cm) != 0 {
+			name := j.name + "-config"
+			m.ConfigMaps = append(m.ConfigMaps, ConfigMap{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+				Metadata:   meta(name),
+				Data:       cm.data(),
+			})
+			projected.Sources = append(projected.Sources, VolumeProjection{
+				ConfigMap: &ObjectProjection{Name: name, Items: cm.items()},
+			})
+		}
+
+		if len(secret) != 0 {
+			name := j.name + "-secret"
+			data := secret.data()
+			for k, v := range data {
+				data[k] = base64.StdEncoding.EncodeToString([]byte(v))
+			}
+
+			m.Secrets = append(m.Secrets, Secret{
+				APIVersion: "v1",
+				Kind:       "Secret",
+				Metadata:   meta(name),
+				Type:       "Opaque",
+				Data:       data,
+			})
+			projected.Sources = append(projected.Sources, VolumeProjection{
+				Secret: &ObjectProjection{Name: name, Items: secret.items()},
+			})
+		}
+
+		homeMount := VolumeMount{Name: homeVolumeName, MountPath: networkHome}
+		for i := range spec.Containers {
+			spec.Containers[i].VolumeMounts = append(spec.Containers[i].VolumeMounts, homeMount)
+		}
+
+		spec.Volumes = append(spec.Volumes, Volume{Name: seedVolumeName, Projected: projected})
+		spec.InitContainers = []Container{{
+			Name:    "seed-home",
+			Image:   opts.InitImage,
+			Command: []string{"sh", "-c"},
+			// files are copied only once so the state of the nodes is kept over restarts
+			Args: []string{fmt.Sprintf(
+				"if [ ! -f %[1]s/%[2]s ]; then cp -rL %[3]s/* %[1]s/ && touch %[1]s/%[2]s; fi",
+				networkHome, seededMarker, seedMountPath,
+			)},
+			VolumeMounts: []VolumeMount{
+				{Name: seedVolumeName, MountPath: seedMountPath, ReadOnly: true},
+				homeMount,
+			},
+		}}
+
+		ss.Spec.VolumeClaimTemplates = []PersistentVolumeClaim{{
+			Metadata: ObjectMeta{Name: homeVolumeName},
+			Spec: PersistentVolumeClaimSpec{
+				AccessModes: []string{"ReadWriteOnce"},
+				Resources: ResourceRequirements{
+					Requests: map[string]string{"storage": fmt.Sprintf("%dMi", defaultHomeSizeMB)},
+				},
+			},
+		}}
+	}
+
+	ss.Spec.Template.Spec = spec
+	m.StatefulSets = append(m.StatefulSets, ss)
+
+	return nil
+}
+
+// Marshal returns manifests as multi document YAML, objects of each job are grouped together.
+func (m Manifests) Marshal() ([]byte, error) {
+	type object struct {
+		name string
+		v    interface{}
+	}
+
+	objects := []object{}
+	for _, cm := range m.ConfigMaps {
+		objects = append(objects, object{cm.Metadata.Labels[labelName], cm})
+	}
+	for _, s := range m.Secrets {
+		objects = append(objects, object{s.Metadata.Labels[labelName], s})
+	}
+	for _, s := range m.Services {
+		objects = append(objects, object{s.Metadata.Labels[labelName], s})
+	}
+	for _, ss := range m.StatefulSets {
+		objects = append(objects, object{ss.Metadata.Labels[labelName], ss})
+	}
+	sort.SliceStable(objects, func(i, j int) bool { return objects[i].name < objects[j].name })
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	for _, o := range objects {
+		if err := enc.Encode(o.v); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func dockerJob(dc config.DockerConfig) job {
+	c := Container{
+		Name:      containerName(dc.Name),
+		Image:     dc.Image,
+		Env:       envVars(dc.Env),
+		Resources: resources(mergeResources(dc.Resources)),
+	}
+
+	// the same as command and args of Docker driver in Nomad
+	if dc.Command != "" {
+		c.Command = []string{dc.Command}
+	}
+	c.Args = dc.Args
+
+	j := job{
+		name:        dc.Name,
+		targetPorts: map[int64]int64{},
+	}
+
+	if dc.StaticPort != nil {
+		value, to := int64(dc.StaticPort.Value), int64(dc.StaticPort.To)
+		if to == 0 {
+			to = value
+		}
+
+		c.Ports = []ContainerPort{{ContainerPort: to, HostPort: value}}
+		j.ports = []int64{value}
+		j.targetPorts[value] = to
+	}
+
+	for i, vm := range dc.VolumeMounts {
+		host, container, _ := strings.Cut(vm, ":")
+		name := fmt.Sprintf("volume-%d", i)
+
+		j.volumes = append(j.volumes, Volume{Name: name, HostPath: &HostPathVolumeSource{Path: host}})
+		c.VolumeMounts = append(c.VolumeMounts, VolumeMount{Name: name, MountPath: container})
+	}
+
+	j.containers = []Container{c}
+
+	return j
+}
+
+func taskContainers(image string, res config.Resources, tasks ...supervisor.Task) []Container {
+	containers := make([]Container, 0, len(tasks))
+	for _, t := range tasks {
+		containers = append(containers, Container{
+			Name:      containerName(t.Name),
+			Image:     image,
+			Command:   []string{t.Command},
+			Args:      t.Args,
+			Env:       envVars(t.Env),
+			Resources: resources(res),
+		})
+	}
+
+	return containers
+}
+
+func mergeResources(custom *config.Resources) config.Resources {
+	res := defaultResources
+	if custom == nil {
+		return res
+	}
+
+	if custom.CPU != nil {
+		res.CPU = custom.CPU
+	}
+	if custom.Cores != nil {
+		res.Cores = custom.Cores
+	}
+	if custom.MemoryMB != nil {
+		res.MemoryMB = custom.MemoryMB
+	}
+	if custom.MemoryMaxMB != nil {
+		res.MemoryMaxMB = custom.MemoryMaxMB
+	}
+	if custom.DiskMB != nil {
+		res.DiskMB = custom.DiskMB
+	}
+
+	return res
+}
+
+// resources converts Nomad resources to the Kubernetes ones, 1000 MHz of CPU is taken as one core.
+func resources(res config.Resources) *ResourceRequirements {
+	rr := &ResourceRequirements{Requests: map[string]string{}}
+
+	if res.Cores != nil {
+		rr.Requests["cpu"] = fmt.Sprintf("%d", *res.Cores)
+	} else if res.CPU != nil {
+		rr.Requests["cpu"] = fmt.Sprintf("%dm", *res.CPU)
+	}
+
+	if res.MemoryMB != nil {
+		rr.Requests["memory"] = fmt.Sprintf("%dMi", *res.MemoryMB)
+	}
+
+	if res.DiskMB != nil {
+		rr.Requests["ephemeral-storage"] = fmt.Sprintf("%dMi", *res.DiskMB)
+	}
+
+	if res.MemoryMaxMB != nil {
+		rr.Limits = map[string]string{"memory": fmt.Sprintf("%dMi", *res.MemoryMaxMB)}
+	}
+
+	return rr
+}
+
+func envVars(env map[string]string) []EnvVar {
+	vars := make([]EnvVar, 0, len(env))
+	for k, v := range env {
+		vars = append(vars, EnvVar{Name: k, Value: v})
+	}
+	sort.Slice(vars, func(i, j int) bool { return vars[i].Name < vars[j].Name })
+
+	if len(vars) == 0 {
+		return nil
+	}
+
+	return vars
+}
+
+type homeFile struct {
+	key      string
+	path     string
+	contents string
+}
+
+type homeFileList []homeFile
+
+func (l homeFileList) data() map[string]string {
+	data := make(map[string]string, len(l))
+	for _, f := range l {
+		data[f.key] = f.contents
+	}
+	return data
+}
+
+func (l homeFileList) items() []KeyToPath {
+	items := make([]KeyToPath, 0, len(l))
+	for _, f := range l {
+		items = append(items, KeyToPath{Key: f.key, Path: f.path})
+	}
+	return items
+}
+
+// homeFiles reads generated files of the service and splits them to configs and secrets.
+func homeFiles(networkHome string, paths []string, exclude map[string]bool, override map[string][]byte) (configs homeFileList, secrets homeFileList, err error) {
+	keys := map[string]bool{}
+	seen := map[string]bool{}
+
+	addFile := func(path string, contents []byte) {
+		rel, _ := filepath.Rel(networkHome, path)
+		rel = filepath.ToSlash(rel)
+
+		key := invalidKeyChars.ReplaceAllString(strings.ReplaceAll(rel, "/", "_"), "-")
+		for i := 2; keys[key]; i++ {
+			key = fmt.Sprintf("%s_%d", key, i)
+		}
+		keys[key] = true
+
+		f := homeFile{key: key, path: rel, contents: string(contents)}
+
+		name := filepath.Base(path)
+		if utf8.Valid(contents) && (filepath.Ext(name) == ".toml" || name == "genesis.json") {
+			configs = append(configs, f)
+		} else {
+			secrets = append(secrets, f)
+		}
+	}
+
+	for _, root := range paths {
+		if !strings.HasPrefix(root, networkHome+string(filepath.Separator)) {
+			log.Printf("file %q is outside of network home and is left out", root)
+			continue
+		}
+
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if exclude[path] {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			if d.IsDir() || seen[path] || !d.Type().IsRegular() {
+				return nil
+			}
+			seen[path] = true
+
+			contents, ok := override[path]
+			if !ok {
+				if contents, err = os.ReadFile(path); err != nil {
+					return fmt.Errorf("failed to read file %q: %w", path, err)
+				}
+			}
+
+			addFile(path, contents)
+
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return configs, secrets, nil
+}
+
+// chainData returns chain data of the node set to be left out, so the nodes start from the genesis.
+// Tendermint refuses to start without the signing state, so it is reset instead of being removed.
+func chainData(ns types.NodeSet) (exclude map[string]bool, override map[string][]byte, err error) {
+	exclude = map[string]bool{filepath.Join(ns.Vega.HomeDir, "state"): true}
+	if ns.DataNode != nil {
+		exclude[filepath.Join(ns.DataNode.HomeDir, "state")] = true
+	}
+
+	tmDataDir := filepath.Join(ns.Tendermint.HomeDir, "data")
+	privValidatorState := filepath.Join(tmDataDir, "priv_validator_state.json")
+
+	entries, err := os.ReadDir(tmDataDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("failed to read Tendermint data: %w", err)
+	}
+
+	for _, e := range entries {
+		if p := filepath.Join(tmDataDir, e.Name()); p != privValidatorState {
+			exclude[p] = true
+		}
+	}
+
+	return exclude, map[string][]byte{privValidatorState: []byte(emptyPrivValidatorState)}, nil
+}
+
+// objectName returns name valid as Kubernetes object name (RFC 1123 label).
+func objectName(name string) string {
+	name = strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if len(name) > 63 {
+		name = strings.TrimRight(name[:63], "-")
+	}
+	return name
+}
+
+func containerName(name string) string {
+	return objectName(name)
+}
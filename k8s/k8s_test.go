@@ -0,0 +1,119 @@
+package k8s_test
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/k8s"
+	"code.vegaprotocol.io/vegacapsule/types"
+	"code.vegaprotocol.io/vegacapsule/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// goldenHome replaces path of the test network home, so the golden file doesn't depend on location of the repository.
+const goldenHome = "/home/capsule/testnet"
+
+func TestNew(t *testing.T) {
+	home, err := filepath.Abs(filepath.Join("testdata", "network"))
+	require.NoError(t, err)
+
+	conf := &config.Config{
+		OutputDir:  utils.ToPoint(home),
+		VegaBinary: utils.ToPoint("/usr/local/bin/vega"),
+		Network: config.NetworkConfig{
+			Name:   "Testnet",
+			Faucet: &config.FaucetConfig{Name: "faucet-1"},
+			PreStart: &config.PStartConfig{
+				Docker: []config.DockerConfig{
+					{
+						Name:         "postgres-1",
+						Image:        "postgres:14",
+						Env:          map[string]string{"POSTGRES_USER": "vega"},
+						StaticPort:   &config.StaticPort{Value: 5232, To: 5432},
+						Resources:    &config.Resources{MemoryMaxMB: utils.ToPoint(1024)},
+						VolumeMounts: []string{"/tmp/postgres:/var/lib/postgresql/data"},
+					},
+				},
+				Exec: []config.ExecConfig{{Name: "script", Command: "/usr/local/bin/script.sh", Args: []string{"--fast"}}},
+			},
+		},
+	}
+
+	genServices := types.GeneratedServices{
+		NodeSets: types.NodeSetMap{
+			"testnet-nodeset-full-0": types.NodeSet{
+				Name: "testnet-nodeset-full-0",
+				Vega: types.VegaNode{
+					GeneratedService: types.GeneratedService{
+						Name:           "vega-full-0",
+						HomeDir:        filepath.Join(home, "vega", "node0"),
+						ConfigFilePath: filepath.Join(home, "vega", "node0", "config", "node", "config.toml"),
+					},
+					BinaryPath:             "/usr/local/bin/vega",
+					NodeWalletPassFilePath: filepath.Join(home, "vega", "node0", "node-vega-wallet-pass.txt"),
+				},
+				Tendermint: types.TendermintNode{
+					GeneratedService: types.GeneratedService{HomeDir: filepath.Join(home, "tendermint", "node0")},
+				},
+				DataNode: &types.DataNode{
+					GeneratedService: types.GeneratedService{
+						Name:           "data-node-0",
+						HomeDir:        filepath.Join(home, "data", "node0"),
+						ConfigFilePath: filepath.Join(home, "data", "node0", "config", "data-node", "config.toml"),
+					},
+					BinaryPath: "/usr/local/bin/vega",
+				},
+			},
+		},
+		Faucet: &types.Faucet{
+			GeneratedService: types.GeneratedService{
+				Name:           "testnet-faucet",
+				HomeDir:        filepath.Join(home, "faucet"),
+				ConfigFilePath: filepath.Join(home, "faucet", "config", "faucet", "config.toml"),
+			},
+			WalletPassFilePath: filepath.Join(home, "faucet", "wallet-pass.txt"),
+			BinaryPath:         "/usr/local/bin/vega",
+		},
+	}
+
+	configPorts := map[string]map[int64]string{
+		genServices.NodeSets["testnet-nodeset-full-0"].Vega.ConfigFilePath:     {3002: "API.Port"},
+		genServices.NodeSets["testnet-nodeset-full-0"].DataNode.ConfigFilePath: {3002: "API.Port", 3008: "Gateway.Port"},
+		genServices.Faucet.ConfigFilePath:                                      {1790: "Port"},
+	}
+
+	m, err := k8s.New(conf, genServices, k8s.Options{
+		Image: "vegaprotocol/capsule:latest",
+		ConfigPorts: func(configPath string) (map[int64]string, error) {
+			return configPorts[configPath], nil
+		},
+	})
+	require.NoError(t, err)
+
+	out, err := m.Marshal()
+	require.NoError(t, err)
+	out = bytes.ReplaceAll(out, []byte(home), []byte(goldenHome))
+
+	goldenPath := filepath.Join("testdata", "manifests.golden")
+	if *update {
+		require.NoError(t, os.WriteFile(goldenPath, out, 0o644))
+	}
+
+	golden, err := os.ReadFile(goldenPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, string(golden), string(out))
+}
+
+func TestNewWithoutImage(t *testing.T) {
+	_, err := k8s.New(&config.Config{}, types.GeneratedServices{}, k8s.Options{})
+	assert.Error(t, err)
+}
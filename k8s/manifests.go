@@ -0,0 +1,146 @@
+package k8s
+
+// Only fields of Kubernetes objects used by Capsule are defined, so the client libraries are not needed.
+
+type ObjectMeta struct {
+	Name   string            `yaml:"name,omitempty"`
+	Labels map[string]string `yaml:"labels,omitempty"`
+}
+
+type ConfigMap struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   ObjectMeta        `yaml:"metadata"`
+	Data       map[string]string `yaml:"data,omitempty"`
+}
+
+type Secret struct {
+	APIVersion string     `yaml:"apiVersion"`
+	Kind       string     `yaml:"kind"`
+	Metadata   ObjectMeta `yaml:"metadata"`
+	Type       string     `yaml:"type"`
+	// Data values are base64 encoded
+	Data map[string]string `yaml:"data,omitempty"`
+}
+
+type ServicePort struct {
+	Name       string `yaml:"name"`
+	Port       int64  `yaml:"port"`
+	TargetPort int64  `yaml:"targetPort"`
+}
+
+type ServiceSpec struct {
+	Selector map[string]string `yaml:"selector"`
+	Ports    []ServicePort     `yaml:"ports"`
+}
+
+type Service struct {
+	APIVersion string      `yaml:"apiVersion"`
+	Kind       string      `yaml:"kind"`
+	Metadata   ObjectMeta  `yaml:"metadata"`
+	Spec       ServiceSpec `yaml:"spec"`
+}
+
+type EnvVar struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
+type ContainerPort struct {
+	ContainerPort int64 `yaml:"containerPort"`
+	HostPort      int64 `yaml:"hostPort,omitempty"`
+}
+
+type ResourceRequirements struct {
+	Requests map[string]string `yaml:"requests,omitempty"`
+	Limits   map[string]string `yaml:"limits,omitempty"`
+}
+
+type VolumeMount struct {
+	Name      string `yaml:"name"`
+	MountPath string `yaml:"mountPath"`
+	ReadOnly  bool   `yaml:"readOnly,omitempty"`
+}
+
+type Container struct {
+	Name         string                `yaml:"name"`
+	Image        string                `yaml:"image"`
+	Command      []string              `yaml:"command,omitempty"`
+	Args         []string              `yaml:"args,omitempty"`
+	Env          []EnvVar              `yaml:"env,omitempty"`
+	Ports        []ContainerPort       `yaml:"ports,omitempty"`
+	Resources    *ResourceRequirements `yaml:"resources,omitempty"`
+	VolumeMounts []VolumeMount         `yaml:"volumeMounts,omitempty"`
+}
+
+type KeyToPath struct {
+	Key  string `yaml:"key"`
+	Path string `yaml:"path"`
+}
+
+type ObjectProjection struct {
+	Name  string      `yaml:"name"`
+	Items []KeyToPath `yaml:"items"`
+}
+
+type VolumeProjection struct {
+	ConfigMap *ObjectProjection `yaml:"configMap,omitempty"`
+	Secret    *ObjectProjection `yaml:"secret,omitempty"`
+}
+
+type ProjectedVolumeSource struct {
+	Sources []VolumeProjection `yaml:"sources"`
+}
+
+type HostPathVolumeSource struct {
+	Path string `yaml:"path"`
+}
+
+type Volume struct {
+	Name      string                 `yaml:"name"`
+	Projected *ProjectedVolumeSource `yaml:"projected,omitempty"`
+	HostPath  *HostPathVolumeSource  `yaml:"hostPath,omitempty"`
+}
+
+type PodSpec struct {
+	HostNetwork                   bool        `yaml:"hostNetwork,omitempty"`
+	DNSPolicy                     string      `yaml:"dnsPolicy,omitempty"`
+	TerminationGracePeriodSeconds int64       `yaml:"terminationGracePeriodSeconds"`
+	InitContainers                []Container `yaml:"initContainers,omitempty"`
+	Containers                    []Container `yaml:"containers"`
+	Volumes                       []Volume    `yaml:"volumes,omitempty"`
+}
+
+type PodTemplateSpec struct {
+	Metadata ObjectMeta `yaml:"metadata"`
+	Spec     PodSpec    `yaml:"spec"`
+}
+
+type LabelSelector struct {
+	MatchLabels map[string]string `yaml:"matchLabels"`
+}
+
+type PersistentVolumeClaimSpec struct {
+	AccessModes []string             `yaml:"accessModes"`
+	Resources   ResourceRequirements `yaml:"resources"`
+}
+
+type PersistentVolumeClaim struct {
+	Metadata ObjectMeta                `yaml:"metadata"`
+	Spec     PersistentVolumeClaimSpec `yaml:"spec"`
+}
+
+type StatefulSetSpec struct {
+	ServiceName          string                  `yaml:"serviceName"`
+	Replicas             int                     `yaml:"replicas"`
+	Selector             LabelSelector           `yaml:"selector"`
+	Template             PodTemplateSpec         `yaml:"template"`
+	VolumeClaimTemplates []PersistentVolumeClaim `yaml:"volumeClaimTemplates,omitempty"`
+}
+
+type StatefulSet struct {
+	APIVersion string          `yaml:"apiVersion"`
+	Kind       string          `yaml:"kind"`
+	Metadata   ObjectMeta      `yaml:"metadata"`
+	Spec       StatefulSetSpec `yaml:"spec"`
+}
@@ -27,6 +27,16 @@ func (ts *BaseTokenSession) Address() common.Address {
 	return ts.address
 }
 
+// Batched returns copy of the session that sends transactions with the given nonce and gas limit.
+// Gas is not estimated as the transaction can depend on not yet mined transactions of the batch.
+// The gas limit is not used by MintRaw, it always uses the maximum gas limit.
+func (ts *BaseTokenSession) Batched(nonce *big.Int, gasLimit uint64) *BaseTokenSession {
+	batched := *ts
+	batched.TransactOpts.Nonce = nonce
+	batched.TransactOpts.GasLimit = gasLimit
+	return &batched
+}
+
 func (ts *BaseTokenSession) ApproveSync(spender common.Address, value *big.Int) (*types.Transaction, error) {
 	sink := make(chan *generated.BaseTokenApproval)
 
@@ -139,7 +149,7 @@ func (ts *BaseTokenSession) MintRaw(to common.Address, amount *big.Int) (*types.
 
 	targetBalance := new(big.Int).Add(balance, amount)
 
-	tx, err := ts.mintRaw(to, targetBalance)
+	tx, err := ts.mintRaw(targetBalance)
 	if err != nil {
 		return nil, fmt.Errorf("failed to mint: %w", err)
 	}
@@ -147,8 +157,8 @@ func (ts *BaseTokenSession) MintRaw(to common.Address, amount *big.Int) (*types.
 	return tx, nil
 }
 
-func (ts *BaseTokenSession) mintRaw(to common.Address, targetBalance *big.Int) (*types.Transaction, error) {
-	signedTx, err := ts.createMintSignedTx(to, targetBalance)
+func (ts *BaseTokenSession) mintRaw(targetBalance *big.Int) (*types.Transaction, error) {
+	signedTx, err := ts.createMintSignedTx(targetBalance)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create signed mint tx: %w", err)
 	}
@@ -181,10 +191,10 @@ func (ts *BaseTokenSession) GetLastTransferValueSync(ctx context.Context, signed
 	return transfer.Value, nil
 }
 
-func (ts *BaseTokenSession) createMintSignedTx(to common.Address, targetBalance *big.Int) (*types.Transaction, error) {
-	nonce, err := ts.client.PendingNonceAt(ts.CallOpts.Context, to)
+func (ts *BaseTokenSession) createMintSignedTx(targetBalance *big.Int) (*types.Transaction, error) {
+	nonce, err := ts.nonce()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get nonce: %w", err)
+		return nil, err
 	}
 
 	gasPrice, err := ts.client.SuggestGasPrice(ts.CallOpts.Context)
@@ -210,6 +220,20 @@ func (ts *BaseTokenSession) createMintSignedTx(to common.Address, targetBalance
 	return signedTx, nil
 }
 
+// nonce returns nonce set by Batched or the pending nonce of the caller.
+func (ts *BaseTokenSession) nonce() (uint64, error) {
+	if ts.TransactOpts.Nonce != nil {
+		return ts.TransactOpts.Nonce.Uint64(), nil
+	}
+
+	nonce, err := ts.client.PendingNonceAt(ts.CallOpts.Context, ts.TransactOpts.From)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get nonce: %w", err)
+	}
+
+	return nonce, nil
+}
+
 func (ts *BaseTokenSession) prepareMintBytecode(targetBalance *big.Int) ([]byte, error) {
 	const script = `0x6000603460be8239805160601c9060145160183384609f565b908181116043575b82806044818088602f3082609f565b5063a9059cbb60e01b600052336004525af1005b63de5f72fd60e01b600052600492918280858180895af15060633086609f565b63de5f72fd60e01b60005291030491815b8381105a61ea6010161560925760019083808481808a5af150016074565b5090915060449050816020565b6024600081926044946370a0823160e01b83526004525afa506024519056`
 
@@ -87,6 +87,7 @@ func (ec *Client) NewERC20BridgeSession(
 		},
 		syncTimeout: *syncTimeout,
 		address:     bridgeAddress,
+		client:      ec,
 	}, nil
 }
 
@@ -126,6 +127,7 @@ func (ec *Client) NewStakingBridgeSession(
 		},
 		syncTimeout: *syncTimeout,
 		address:     bridgeAddress,
+		client:      ec,
 	}, nil
 }
 
@@ -181,6 +183,23 @@ func wait[T any](sink chan T, sub event.Subscription, tx *types.Transaction, tim
 	}
 }
 
+// WaitMined waits until the transaction is mined and fails when it was reverted.
+func (ec *Client) WaitMined(ctx context.Context, tx *types.Transaction, timeout time.Duration) (*types.Receipt, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	receipt, err := bind.WaitMined(ctx, ec, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for transaction %s: %w", tx.Hash(), err)
+	}
+
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return receipt, fmt.Errorf("transaction %s has been reverted", tx.Hash())
+	}
+
+	return receipt, nil
+}
+
 func HexStringToByte32Array(str string) ([32]byte, error) {
 	value := [32]byte{}
 
@@ -16,12 +16,21 @@ type ERC20BridgeSession struct {
 	generated.ERC20BridgeSession
 	syncTimeout time.Duration
 	address     common.Address
+	client      *Client
 }
 
 func (bs ERC20BridgeSession) Address() common.Address {
 	return bs.address
 }
 
+// Batched returns copy of the session that sends transactions with the given nonce and gas limit.
+// Gas is not estimated as the transaction can depend on not yet mined transactions of the batch.
+func (bs ERC20BridgeSession) Batched(nonce *big.Int, gasLimit uint64) ERC20BridgeSession {
+	bs.TransactOpts.Nonce = nonce
+	bs.TransactOpts.GasLimit = gasLimit
+	return bs
+}
+
 func (bs ERC20BridgeSession) DepositAssetSync(asset_source common.Address, amount *big.Int, vega_public_key [32]byte) (*types.Transaction, error) {
 	sink := make(chan *generated.ERC20BridgeAssetDeposited)
 
@@ -38,3 +47,20 @@ func (bs ERC20BridgeSession) DepositAssetSync(asset_source common.Address, amoun
 
 	return wait(sink, sub, tx, bs.syncTimeout)
 }
+
+// WithdrawAssetSync submits the withdrawal bundle signed by the validators and waits for the transaction receipt.
+func (bs ERC20BridgeSession) WithdrawAssetSync(
+	asset_source common.Address,
+	amount *big.Int,
+	target common.Address,
+	creation *big.Int,
+	nonce *big.Int,
+	signatures []byte,
+) (*types.Receipt, error) {
+	tx, err := bs.WithdrawAsset(asset_source, amount, target, creation, nonce, signatures)
+	if err != nil {
+		return nil, err
+	}
+
+	return bs.client.WaitMined(bs.CallOpts.Context, tx, bs.syncTimeout)
+}
@@ -0,0 +1,38 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Nonces hands out consecutive nonces of an account, so its transactions can be sent without waiting for each other.
+// Transactions of the account are then mined in the order of the nonces.
+type Nonces struct {
+	mu   sync.Mutex
+	next uint64
+}
+
+// NewNonces returns nonces of the account starting with its pending nonce.
+func (ec *Client) NewNonces(ctx context.Context, account common.Address) (*Nonces, error) {
+	nonce, err := ec.PendingNonceAt(ctx, account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce of %s: %w", account, err)
+	}
+
+	return &Nonces{next: nonce}, nil
+}
+
+// Next returns the next unused nonce.
+func (n *Nonces) Next() *big.Int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	nonce := n.next
+	n.next++
+
+	return new(big.Int).SetUint64(nonce)
+}
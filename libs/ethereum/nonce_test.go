@@ -0,0 +1,36 @@
+package ethereum_test
+
+import (
+	"sync"
+	"testing"
+
+	vgethereum "code.vegaprotocol.io/vegacapsule/libs/ethereum"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoncesNext(t *testing.T) {
+	var nonces vgethereum.Nonces
+
+	var mu sync.Mutex
+	seen := map[uint64]bool{}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nonce := nonces.Next().Uint64()
+
+			mu.Lock()
+			defer mu.Unlock()
+			seen[nonce] = true
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, seen, 100)
+	for i := uint64(0); i < 100; i++ {
+		assert.True(t, seen[i], "nonce %d not handed out", i)
+	}
+}
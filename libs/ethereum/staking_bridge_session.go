@@ -1,19 +1,54 @@
 package ethereum
 
 import (
+	"fmt"
+	"math/big"
+	"strings"
 	"time"
 
 	"code.vegaprotocol.io/vegacapsule/libs/ethereum/generated"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
+// removeStakeABI is ABI of the staking bridge method 0xdd01ba0b, the generated binding covers only staking.
+const removeStakeABI = `[{"inputs":[{"internalType":"uint256","name":"amount","type":"uint256"},{"internalType":"bytes32","name":"vega_public_key","type":"bytes32"}],"name":"remove_stake","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+
 type StakingBridgeSession struct {
 	generated.StakingBridgeSession
 	syncTimeout time.Duration
 	address     common.Address
+	client      *Client
 }
 
 func (ss StakingBridgeSession) Address() common.Address {
 	return ss.address
 }
+
+// RemoveStake removes stake of the Vega public key.
+//
+// Solidity: function remove_stake(uint256 amount, bytes32 vega_public_key) returns()
+func (ss StakingBridgeSession) RemoveStake(amount *big.Int, vega_public_key [32]byte) (*types.Transaction, error) {
+	parsed, err := abi.JSON(strings.NewReader(removeStakeABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse remove stake ABI: %w", err)
+	}
+
+	contract := bind.NewBoundContract(ss.address, parsed, ss.client, ss.client, ss.client)
+
+	opts := ss.TransactOpts
+	return contract.Transact(&opts, "remove_stake", amount, vega_public_key)
+}
+
+// RemoveStakeSync removes stake of the Vega public key and waits for the transaction receipt.
+func (ss StakingBridgeSession) RemoveStakeSync(amount *big.Int, vega_public_key [32]byte) (*types.Receipt, error) {
+	tx, err := ss.RemoveStake(amount, vega_public_key)
+	if err != nil {
+		return nil, err
+	}
+
+	return ss.client.WaitMined(ss.CallOpts.Context, tx, ss.syncTimeout)
+}
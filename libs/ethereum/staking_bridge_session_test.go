@@ -0,0 +1,20 @@
+package ethereum
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveStakeABI(t *testing.T) {
+	parsed, err := abi.JSON(strings.NewReader(removeStakeABI))
+	require.NoError(t, err)
+
+	method, ok := parsed.Methods["remove_stake"]
+	require.True(t, ok)
+	assert.Equal(t, "dd01ba0b", hex.EncodeToString(method.ID))
+}
@@ -0,0 +1,88 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var dataNodeClient = &http.Client{Timeout: 10 * time.Second}
+
+// WithdrawalApproval is a bundle signed by the validators that allows to withdraw an asset from the ERC20 bridge.
+type WithdrawalApproval struct {
+	AssetSource   common.Address
+	Amount        *big.Int
+	TargetAddress common.Address
+	Creation      *big.Int
+	Nonce         *big.Int
+	Signatures    []byte
+}
+
+type withdrawalApprovalResponse struct {
+	Withdrawal struct {
+		AssetSource   string `json:"assetSource"`
+		Amount        string `json:"amount"`
+		Nonce         string `json:"nonce"`
+		Signatures    string `json:"signatures"`
+		TargetAddress string `json:"targetAddress"`
+		Creation      string `json:"creation"`
+	} `json:"withdrawal"`
+}
+
+// FetchWithdrawalApproval gets the withdrawal bundle of the Vega withdrawal from the data node REST API.
+func FetchWithdrawalApproval(ctx context.Context, dataNodeURL, withdrawalID string) (*WithdrawalApproval, error) {
+	url := fmt.Sprintf("%s/api/v2/erc20/asset/withdrawal/%s", strings.TrimSuffix(dataNodeURL, "/"), withdrawalID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := dataNodeClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get withdrawal bundle: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get withdrawal bundle: unexpected status code %d from %q", resp.StatusCode, url)
+	}
+
+	var approval withdrawalApprovalResponse
+	if err := json.NewDecoder(resp.Body).Decode(&approval); err != nil {
+		return nil, fmt.Errorf("failed to decode withdrawal bundle: %w", err)
+	}
+
+	w := approval.Withdrawal
+
+	signatures, err := hexutil.Decode(w.Signatures)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode withdrawal signatures: %w", err)
+	}
+
+	numbers := map[string]string{"amount": w.Amount, "nonce": w.Nonce, "creation": w.Creation}
+	parsed := make(map[string]*big.Int, len(numbers))
+	for name, value := range numbers {
+		n, ok := new(big.Int).SetString(value, 10)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse withdrawal %s %q", name, value)
+		}
+		parsed[name] = n
+	}
+
+	return &WithdrawalApproval{
+		AssetSource:   common.HexToAddress(w.AssetSource),
+		Amount:        parsed["amount"],
+		TargetAddress: common.HexToAddress(w.TargetAddress),
+		Creation:      parsed["creation"],
+		Nonce:         parsed["nonce"],
+		Signatures:    signatures,
+	}, nil
+}
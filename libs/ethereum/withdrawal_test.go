@@ -0,0 +1,46 @@
+package ethereum_test
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	vgethereum "code.vegaprotocol.io/vegacapsule/libs/ethereum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchWithdrawalApproval(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/erc20/asset/withdrawal/w1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"withdrawal":{
+			"assetSource":"0x1b8a1B6CBE5c93609b46D1829Cc7f3Cb8eeE23a0",
+			"amount":"1000000000000000000000",
+			"nonce":"42",
+			"signatures":"0x0102ff",
+			"targetAddress":"0xEe7D375bcB50C26d52E1A4a472D8822A2A22d94F",
+			"creation":"1690000000"
+		}}`))
+	}))
+	defer srv.Close()
+
+	approval, err := vgethereum.FetchWithdrawalApproval(context.Background(), srv.URL+"/", "w1")
+	require.NoError(t, err)
+
+	amount, _ := new(big.Int).SetString("1000000000000000000000", 10)
+	assert.Equal(t, tUSDCTokenAddress, approval.AssetSource)
+	assert.Equal(t, contractOwnerAddress, approval.TargetAddress)
+	assert.Equal(t, amount, approval.Amount)
+	assert.Equal(t, big.NewInt(42), approval.Nonce)
+	assert.Equal(t, big.NewInt(1690000000), approval.Creation)
+	assert.Equal(t, []byte{0x01, 0x02, 0xff}, approval.Signatures)
+
+	_, err = vgethereum.FetchWithdrawalApproval(context.Background(), srv.URL, "unknown")
+	assert.Error(t, err)
+}
@@ -123,6 +123,86 @@ func printLogFile(logFile nomadLogFile, offset int64, follow bool) error {
 	return nil
 }
 
+// LastLines returns up to n last lines of the latest logs of all tasks in the directory, prefixed by the task name.
+// The lines are split evenly between the tasks.
+func LastLines(logsDir string, n int) ([]string, error) {
+	logFilePerTaskName, err := getLogsFilesPerTaskName(logsDir, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logs per task name: %w", err)
+	}
+
+	if len(logFilePerTaskName) == 0 || n <= 0 {
+		return nil, nil
+	}
+
+	perTask := n / len(logFilePerTaskName)
+	if perTask == 0 {
+		perTask = 1
+	}
+
+	lines := []string{}
+	for _, key := range logFilePerTaskName.SortedKeys() {
+		logFile := logFilePerTaskName[key]
+
+		taskLines, err := lastLines(logFile.path, perTask)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, l := range taskLines {
+			lines = append(lines, fmt.Sprintf("%s:    %s", logFile.taskName, l))
+		}
+	}
+
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+
+	return lines, nil
+}
+
+// lastLinesMaxBytes limits how much of a log file is read to find its last lines
+const lastLinesMaxBytes = 64 * 1024
+
+func lastLines(path string, n int) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fileInfo, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	offset := fileInfo.Size() - lastLinesMaxBytes
+	if offset < 0 {
+		offset = 0
+	}
+
+	b := make([]byte, fileInfo.Size()-offset)
+	if _, err := f.ReadAt(b, offset); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read log file %q: %w", path, err)
+	}
+
+	lines := strings.Split(strings.TrimRight(string(b), "\n"), "\n")
+	// the first line can be cut in the middle
+	if offset > 0 && len(lines) > 1 {
+		lines = lines[1:]
+	}
+
+	if len(lines) == 1 && lines[0] == "" {
+		return nil, nil
+	}
+
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+
+	return lines, nil
+}
+
 type logsFiles map[string]nomadLogFile
 
 func (lf logsFiles) SortedKeys() []string {
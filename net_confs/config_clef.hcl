@@ -4,12 +4,12 @@ network "testnet" {
   ethereum {
     chain_id   = "1440"
     network_id = "1441"
-    endpoint   = "ws://127.0.0.1:8545/"
+    endpoint   = "ws://127.0.0.1:${port("ganache")}/"
   }
   secondary_ethereum {
     chain_id   = "1450"
     network_id = "1451"
-    endpoint   = "ws://127.0.0.1:8546/"
+    endpoint   = "ws://127.0.0.1:${port("secondary-ganache")}/"
   }
 
   faucet "faucet-1" {
@@ -17,7 +17,7 @@ network "testnet" {
 
     template = <<-EOT
 [Node]
-  Port = 3002
+  Port = {{ port "faucet" }}
   IP = "127.0.0.1"
 EOT
   }
@@ -28,7 +28,7 @@ Name = "DV"
 
 [API]
   [API.GRPC]
-    Hosts = [{{range $i, $v := .Validators}}{{if ne $i 0}},{{end}}"127.0.0.1:30{{$i}}2"{{end}}]
+    Hosts = [{{range $i, $v := .Validators}}{{if ne $i 0}},{{end}}"127.0.0.1:{{ port "vega-grpc" $v.Index }}"{{end}}]
 EOT
   }
 
@@ -41,13 +41,13 @@ EOT
         "--chainId", "1440",
         "--networkId", "1441",
         "-h", "0.0.0.0",
-        "-p", "8545",
+        "-p", "${port("ganache")}",
         "-m", "ozone access unlock valid olympic save include omit supply green clown session",
         "--db", "/app/ganache-db",
       ]
       static_port {
-        value = 8545
-        to    = 8545
+        value = port("ganache")
+        to    = port("ganache")
       }
       auth_soft_fail = true
     }
@@ -60,13 +60,13 @@ EOT
         "--chainId", "1450",
         "--networkId", "1451",
         "-h", "0.0.0.0",
-        "-p", "8546",
+        "-p", "${port("secondary-ganache")}",
         "-m", "ozone access unlock valid olympic save include omit supply green clown session",
         "--db", "/app/ganache-db",
       ]
       static_port {
-        value = 8546
-        to    = 8546
+        value = port("secondary-ganache")
+        to    = port("secondary-ganache")
       }
       auth_soft_fail = true
     }
@@ -80,7 +80,7 @@ EOT
         POSTGRES_DBS      = "vega0,vega1,vega2,vega3,vega4,vega5,vega6"
       }
       static_port {
-        value = 5232
+        value = port("postgres")
         to    = 5432
       }
       auth_soft_fail = true
@@ -105,7 +105,7 @@ EOT
       ethereum_account_addresses = [
         "0xc5bf831ddf098fe218958d74676c6ffc64baa970", "0x843bf6fcf6550382a87bf01e12228682900571e0"
       ]
-      clef_rpc_address = "http://localhost:855{{ .NodeNumber }}"
+      clef_rpc_address = "http://localhost:{{ port \"clef\" .NodeNumber }}"
     }
 
     node_wallet_pass     = "n0d3w4ll3t-p4ssphr4e3"
@@ -10,12 +10,12 @@ network "testnet" {
   ethereum {
     chain_id   = "1440"
     network_id = "1441"
-    endpoint   = "ws://127.0.0.1:8545/"
+    endpoint   = "ws://127.0.0.1:${port("ganache")}/"
   }
   secondary_ethereum {
     chain_id   = "1450"
     network_id = "1451"
-    endpoint   = "ws://127.0.0.1:8546/"
+    endpoint   = "ws://127.0.0.1:${port("secondary-ganache")}/"
   }
 
   pre_start {
@@ -290,15 +290,15 @@ network "testnet" {
     Enabled = true
 
 [API]
-	Port = 30{{.NodeNumber}}2
+	Port = {{ port "vega-grpc" .NodeNumber }}
 	[API.REST]
-			Port = 30{{.NodeNumber}}3
+			Port = {{ port "vega-rest" .NodeNumber }}
 
 [Blockchain]
 	[Blockchain.Tendermint]
-		RPCAddr = "tcp://127.0.0.1:266{{.NodeNumber}}7"
+		RPCAddr = "tcp://127.0.0.1:{{ port "tendermint-rpc" .NodeNumber }}"
 	[Blockchain.Null]
-		Port = 31{{.NodeNumber}}1
+		Port = {{ port "vega-null-chain" .NodeNumber }}
 
 [EvtForward]
 	Level = "Info"
@@ -325,25 +325,25 @@ EOT
       tendermint = <<-EOT
 log_level = "info"
 
-proxy_app = "tcp://127.0.0.1:266{{.NodeNumber}}8"
+proxy_app = "tcp://127.0.0.1:{{ port "tendermint-abci" .NodeNumber }}"
 moniker = "{{.TendermintNodePrefix}}-{{.NodeNumber}}"
 
 [rpc]
-  laddr = "tcp://0.0.0.0:266{{.NodeNumber}}7"
+  laddr = "tcp://0.0.0.0:{{ port "tendermint-rpc" .NodeNumber }}"
   unsafe = true
   cors_allowed_origins = ["*"]
   cors_allowed_methods = ["HEAD", "GET", "POST", ]
   cors_allowed_headers = ["Origin", "Accept", "Content-Type", "X-Requested-With", "X-Server-Time", ]
 
 [p2p]
-  laddr = "tcp://0.0.0.0:266{{.NodeNumber}}6"
+  laddr = "tcp://0.0.0.0:{{ port "tendermint-p2p" .NodeNumber }}"
   addr_book_strict = false
   max_packet_msg_payload_size = 4096
   pex = false
   allow_duplicate_ip = true
   persistent_peers = "{{- range $i, $peer := .NodePeers -}}
 	  {{- if ne $i 0 }},{{end -}}
-	  {{- $peer.ID}}@127.0.0.1:266{{$peer.Index}}6
+	  {{- $peer.ID}}@127.0.0.1:{{ port "tendermint-p2p" $peer.Index }}
   {{- end -}}"
 
 
@@ -375,15 +375,15 @@ EOT
     Enabled = true
 
 [API]
-	Port = 30{{.NodeNumber}}2
+	Port = {{ port "vega-grpc" .NodeNumber }}
 	[API.REST]
-			Port = 30{{.NodeNumber}}3
+			Port = {{ port "vega-rest" .NodeNumber }}
 
 [Blockchain]
 	[Blockchain.Tendermint]
-		RPCAddr = "tcp://127.0.0.1:266{{.NodeNumber}}7"
+		RPCAddr = "tcp://127.0.0.1:{{ port "tendermint-rpc" .NodeNumber }}"
 	[Blockchain.Null]
-		Port = 31{{.NodeNumber}}1
+		Port = {{ port "vega-null-chain" .NodeNumber }}
 
 [EvtForward]
 	Level = "Info"
@@ -404,7 +404,7 @@ EOT
 
 [Broker]
   [Broker.Socket]
-    Port = 3005
+    Port = {{ port "vega-broker" .NodeNumber }}
     Enabled = true
 EOT
 
@@ -416,35 +416,35 @@ EOT
 
 GatewayEnabled = true
 [SqlStore]
-  Port = 5{{.NodeNumber}}32
+  Port = {{ port "data-node-postgres" .NodeNumber }}
 
 [API]
   Level = "Info"
-  Port = 30{{.NodeNumber}}7
-  CoreNodeGRPCPort = 30{{.NodeNumber}}2
+  Port = {{ port "data-node-grpc" .NodeNumber }}
+  CoreNodeGRPCPort = {{ port "vega-grpc" .NodeNumber }}
 
 [Pprof]
   Level = "Info"
   Enabled = true
-  Port = 6{{.NodeNumber}}60
+  Port = {{ port "data-node-pprof" .NodeNumber }}
   ProfilesDir = "{{.NodeHomeDir}}"
 
 [Gateway]
   Level = "Info"
-  Port = 30{{.NodeNumber}}8
+  Port = {{ port "data-node-gateway" .NodeNumber }}
   [Gateway.Node]
-    Port = 30{{.NodeNumber}}7
+    Port = {{ port "data-node-grpc" .NodeNumber }}
 
 [Metrics]
   Level = "Info"
   Timeout = "5s"
-  Port = 21{{.NodeNumber}}2
+  Port = {{ port "data-node-metrics" .NodeNumber }}
   Enabled = false
 [Broker]
   Level = "Info"
   UseEventFile = false
   [Broker.SocketConfig]
-    Port = 30{{.NodeNumber}}5
+    Port = {{ port "vega-broker" .NodeNumber }}
 
 EOT
 
@@ -455,25 +455,25 @@ EOT
       tendermint = <<-EOT
 log_level = "info"
 
-proxy_app = "tcp://127.0.0.1:266{{.NodeNumber}}8"
+proxy_app = "tcp://127.0.0.1:{{ port "tendermint-abci" .NodeNumber }}"
 moniker = "{{.TendermintNodePrefix}}-{{.NodeNumber}}"
 
 [rpc]
-  laddr = "tcp://0.0.0.0:266{{.NodeNumber}}7"
+  laddr = "tcp://0.0.0.0:{{ port "tendermint-rpc" .NodeNumber }}"
   unsafe = true
   cors_allowed_origins = ["*"]
   cors_allowed_methods = ["HEAD", "GET", "POST", ]
   cors_allowed_headers = ["Origin", "Accept", "Content-Type", "X-Requested-With", "X-Server-Time", ]
 
 [p2p]
-  laddr = "tcp://0.0.0.0:266{{.NodeNumber}}6"
+  laddr = "tcp://0.0.0.0:{{ port "tendermint-p2p" .NodeNumber }}"
   addr_book_strict = false
   max_packet_msg_payload_size = 4096
   pex = false
   allow_duplicate_ip = true
   persistent_peers = "{{- range $i, $peer := .NodePeers -}}
 	  {{- if ne $i 0 }},{{end -}}
-	  {{- $peer.ID}}@127.0.0.1:266{{$peer.Index}}6
+	  {{- $peer.ID}}@127.0.0.1:{{ port "tendermint-p2p" $peer.Index }}
   {{- end -}}"
 
 [mempool]
@@ -4,12 +4,12 @@ network "testnet" {
   ethereum {
     chain_id   = "1440"
     network_id = "1441"
-    endpoint   = "ws://127.0.0.1:8545/"
+    endpoint   = "ws://127.0.0.1:${port("ganache")}/"
   }
   secondary_ethereum {
     chain_id   = "1450"
     network_id = "1451"
-    endpoint   = "ws://127.0.0.1:8546/"
+    endpoint   = "ws://127.0.0.1:${port("secondary-ganache")}/"
   }
 
   faucet "faucet-1" {
@@ -17,7 +17,7 @@ network "testnet" {
 
     template = <<-EOT
 [Node]
-  Port = 3002
+  Port = {{ port "faucet" }}
   IP = "127.0.0.1"
 EOT
   }
@@ -28,7 +28,7 @@ Name = "DV"
 
 [API]
   [API.GRPC]
-    Hosts = [{{range $i, $v := .Validators}}{{if ne $i 0}},{{end}}"127.0.0.1:{{add 300 $i}}2"{{end}}]
+    Hosts = [{{range $i, $v := .Validators}}{{if ne $i 0}},{{end}}"127.0.0.1:{{ port "vega-grpc" $v.Index }}"{{end}}]
 EOT
   }
 
@@ -41,13 +41,13 @@ EOT
         "--chainId", "1440",
         "--networkId", "1441",
         "-h", "0.0.0.0",
-        "-p", "8545",
+        "-p", "${port("ganache")}",
         "-m", "ozone access unlock valid olympic save include omit supply green clown session",
         "--db", "/app/ganache-db",
       ]
       static_port {
-        value = 8545
-        to    = 8545
+        value = port("ganache")
+        to    = port("ganache")
       }
       auth_soft_fail = true
     }
@@ -59,13 +59,13 @@ EOT
         "--chainId", "1450",
         "--networkId", "1451",
         "-h", "0.0.0.0",
-        "-p", "8546",
+        "-p", "${port("secondary-ganache")}",
         "-m", "ozone access unlock valid olympic save include omit supply green clown session",
         "--db", "/app/ganache-db",
       ]
       static_port {
-        value = 8546
-        to    = 8546
+        value = port("secondary-ganache")
+        to    = port("secondary-ganache")
       }
       auth_soft_fail = true
     }
@@ -80,7 +80,7 @@ EOT
       }
 
       static_port {
-        value = 5232
+        value = port("postgres")
         to    = 5432
       }
       resources {
@@ -99,18 +99,18 @@ EOT
       image = "vegaprotocol/trading:latest"
       args  = []
       static_port {
-        value = 4200
+        value = port("trading")
         to    = 80
       }
       env = {
         NX_VEGA_ENV              = "CUSTOM"
-        NX_VEGA_URL              = "http://localhost:3028/query"
-        NX_ETHEREUM_PROVIDER_URL = "http://localhost:8545"
+        NX_VEGA_URL              = "http://localhost:${port("data-node-gateway", 2)}/query"
+        NX_ETHEREUM_PROVIDER_URL = "http://localhost:${port("ganache")}"
         NX_ETHERSCAN_URL         = "https://ropsten.etherscan.io"
         # TODO would be nice to sidecar a basic ETH block explorer pointing at local ganache node
         NX_VEGA_NETWORKS         = "{}"
         NX_USE_ENV_OVERRIDES     = "1"
-        NX_VEGA_EXPLORER_URL     = "https://localhost:4200" # If running explorer use the same port as above service
+        NX_VEGA_EXPLORER_URL     = "https://localhost:${port("trading")}" # If running explorer use the same port as above service
       }
       auth_soft_fail = true
     }
@@ -118,21 +118,21 @@ EOT
       image = "vegaprotocol/token:latest"
       args  = []
       static_port {
-        value = 4210
+        value = port("token")
         to    = 80
       }
       env = {
         NX_VEGA_ENV               = "CUSTOM"
-        NX_ETHEREUM_PROVIDER_URL  = "http://localhost:8545"
+        NX_ETHEREUM_PROVIDER_URL  = "http://localhost:${port("ganache")}"
         NX_ETHERSCAN_URL          = "https://ropsten.etherscan.io"
         # TODO would be nice to sidecar a basic ETH block explorer pointing at local ganache node
         NX_FAIRGROUND             = "false"
         NX_IS_NEW_BRIDGE_CONTRACT = "true"
         NX_VEGA_NETWORKS          = "{}"
-        NX_VEGA_URL               = "http://localhost:3028/query"
-        NX_VEGA_REST              = "http://localhost:3029"
+        NX_VEGA_URL               = "http://localhost:${port("data-node-gateway", 2)}/query"
+        NX_VEGA_REST              = "http://localhost:${port("data-node-gateway", 2)}"
         NX_ETHEREUM_CHAIN_ID      = "1440"
-        NX_VEGA_EXPLORER_URL      = "https://localhost:4200"
+        NX_VEGA_EXPLORER_URL      = "https://localhost:${port("trading")}"
       }
       auth_soft_fail = true
     }
@@ -140,17 +140,17 @@ EOT
       image = "vegaprotocol/explorer:latest"
       args  = []
       static_port {
-        value = 4220
+        value = port("explorer")
         to    = 80
       }
       env = {
         NX_CHAIN_EXPLORER_URL       = "https://explorer.vega.trading/.netlify/functions/chain-explorer-api"
-        NX_TENDERMINT_URL           = "http://localhost:26617"
-        NX_TENDERMINT_WEBSOCKET_URL = "wss://localhost:26617/websocket"
-        NX_VEGA_URL                 = "http://localhost:3028/query"
+        NX_TENDERMINT_URL           = "http://localhost:${port("tendermint-rpc", 1)}"
+        NX_TENDERMINT_WEBSOCKET_URL = "wss://localhost:${port("tendermint-rpc", 1)}/websocket"
+        NX_VEGA_URL                 = "http://localhost:${port("data-node-gateway", 2)}/query"
         NX_VEGA_NETWORKS            = "{}"
         NX_VEGA_ENV                 = "CUSTOM"
-        NX_VEGA_REST                = "http://localhost:3029"
+        NX_VEGA_REST                = "http://localhost:${port("data-node-gateway", 2)}"
       }
       auth_soft_fail = true
     }
@@ -181,7 +181,7 @@ EOT
 
     pre_start_probe {
       postgres {
-        connection = "user=vega dbname=vega{{ .NodeNumber }} password=vega port=5232 sslmode=disable"
+        connection = "user=vega dbname=vega{{ .NodeNumber }} password=vega port=${port("postgres")} sslmode=disable"
         query      = "select 10 + 10"
       }
     }
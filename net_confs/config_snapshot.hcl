@@ -4,12 +4,12 @@ network "testnet" {
   ethereum {
     chain_id   = "1440"
     network_id = "1441"
-    endpoint   = "ws://127.0.0.1:8545/"
+    endpoint   = "ws://127.0.0.1:${port("ganache")}/"
   }
   secondary_ethereum {
     chain_id   = "1450"
     network_id = "1451"
-    endpoint   = "ws://127.0.0.1:8546/"
+    endpoint   = "ws://127.0.0.1:${port("secondary-ganache")}/"
   }
 
   pre_start {
@@ -21,13 +21,13 @@ network "testnet" {
         "--chainId", "1440",
         "--networkId", "1441",
         "-h", "0.0.0.0",
-        "-p", "8545",
+        "-p", "${port("ganache")}",
         "-m", "ozone access unlock valid olympic save include omit supply green clown session",
         "--db", "/app/ganache-db",
       ]
       static_port {
-        value = 8545
-        to    = 8545
+        value = port("ganache")
+        to    = port("ganache")
       }
       auth_soft_fail = true
     }
@@ -39,13 +39,13 @@ network "testnet" {
         "--chainId", "1450",
         "--networkId", "1451",
         "-h", "0.0.0.0",
-        "-p", "8546",
+        "-p", "${port("secondary-ganache")}",
         "-m", "ozone access unlock valid olympic save include omit supply green clown session",
         "--db", "/app/ganache-db",
       ]
       static_port {
-        value = 8546
-        to    = 8546
+        value = port("secondary-ganache")
+        to    = port("secondary-ganache")
       }
       auth_soft_fail = true
     }
@@ -310,15 +310,15 @@ network "testnet" {
     Enabled = true
 
 [API]
-	Port = 30{{.NodeNumber}}2
+	Port = {{ port "vega-grpc" .NodeNumber }}
 	[API.REST]
-			Port = 30{{.NodeNumber}}3
+			Port = {{ port "vega-rest" .NodeNumber }}
 
 [Blockchain]
 	[Blockchain.Tendermint]
-		RPCAddr = "tcp://127.0.0.1:266{{.NodeNumber}}7"
+		RPCAddr = "tcp://127.0.0.1:{{ port "tendermint-rpc" .NodeNumber }}"
 	[Blockchain.Null]
-		Port = 31{{.NodeNumber}}1
+		Port = {{ port "vega-null-chain" .NodeNumber }}
 
 [EvtForward]
 	Level = "Info"
@@ -352,18 +352,18 @@ EOT
       tendermint = <<-EOT
 log_level = "info"
 
-proxy_app = "tcp://127.0.0.1:266{{.NodeNumber}}8"
+proxy_app = "tcp://127.0.0.1:{{ port "tendermint-abci" .NodeNumber }}"
 moniker = "{{.TendermintNodePrefix}}-{{.NodeNumber}}"
 
 [rpc]
-  laddr = "tcp://0.0.0.0:266{{.NodeNumber}}7"
+  laddr = "tcp://0.0.0.0:{{ port "tendermint-rpc" .NodeNumber }}"
   unsafe = true
   cors_allowed_origins = ["*"]
   cors_allowed_methods = ["HEAD", "GET", "POST", ]
   cors_allowed_headers = ["Origin", "Accept", "Content-Type", "X-Requested-With", "X-Server-Time", ]
 
 [p2p]
-  laddr = "tcp://0.0.0.0:266{{.NodeNumber}}6"
+  laddr = "tcp://0.0.0.0:{{ port "tendermint-p2p" .NodeNumber }}"
   addr_book_strict = false
   max_packet_msg_payload_size = 4096
   pex = false
@@ -371,7 +371,7 @@ moniker = "{{.TendermintNodePrefix}}-{{.NodeNumber}}"
 
   persistent_peers = "{{- range $i, $peer := .NodePeers -}}
 	  {{- if ne $i 0 }},{{end -}}
-	  {{- $peer.ID}}@127.0.0.1:266{{$peer.Index}}6
+	  {{- $peer.ID}}@127.0.0.1:{{ port "tendermint-p2p" $peer.Index }}
   {{- end -}}"
 
 
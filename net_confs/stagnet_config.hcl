@@ -4,12 +4,12 @@ network "testnet" {
   ethereum {
     chain_id   = "1440"
     network_id = "1441"
-    endpoint   = "ws://127.0.0.1:8545/"
+    endpoint   = "ws://127.0.0.1:${port("ganache")}/"
   }
   secondary_ethereum {
     chain_id   = "1450"
     network_id = "1451"
-    endpoint   = "ws://127.0.0.1:8546/"
+    endpoint   = "ws://127.0.0.1:${port("secondary-ganache")}/"
   }
 
   faucet "faucet-1" {
@@ -17,7 +17,7 @@ network "testnet" {
 
     template = <<-EOT
 [Node]
-  Port = 3002
+  Port = {{ port "faucet" }}
   IP = "127.0.0.1"
 EOT
   }
@@ -28,7 +28,7 @@ Name = "DV"
 
 [API]
   [API.GRPC]
-    Hosts = [{{range $i, $v := .Validators}}{{if ne $i 0}},{{end}}"127.0.0.1:30{{$i}}2"{{end}}]
+    Hosts = [{{range $i, $v := .Validators}}{{if ne $i 0}},{{end}}"127.0.0.1:{{ port "vega-grpc" $v.Index }}"{{end}}]
 EOT
   }
 
@@ -41,13 +41,13 @@ EOT
         "--chainId", "1440",
         "--networkId", "1441",
         "-h", "0.0.0.0",
-        "-p", "8545",
+        "-p", "${port("ganache")}",
         "-m", "cherry manage trip absorb logic half number test shed logic purpose rifle",
         "--db", "/app/ganache-db",
       ]
       static_port {
-        value = 8545
-        to    = 8545
+        value = port("ganache")
+        to    = port("ganache")
       }
     }
     docker_service "ganache-2" {
@@ -58,13 +58,13 @@ EOT
         "--chainId", "1450",
         "--networkId", "1451",
         "-h", "0.0.0.0",
-        "-p", "8546",
+        "-p", "${port("secondary-ganache")}",
         "-m", "ozone access unlock valid olympic save include omit supply green clown session",
         "--db", "/app/ganache-db",
       ]
       static_port {
-        value = 8546
-        to    = 8546
+        value = port("secondary-ganache")
+        to    = port("secondary-ganache")
       }
       auth_soft_fail = true
     }
@@ -90,15 +90,15 @@ EOT
 
       vega = <<-EOT
 [API]
-	Port = 30{{.NodeNumber}}2
+	Port = {{ port "vega-grpc" .NodeNumber }}
 	[API.REST]
-			Port = 30{{.NodeNumber}}3
+			Port = {{ port "vega-rest" .NodeNumber }}
 
 [Blockchain]
 	[Blockchain.Tendermint]
-		RPCAddr = "tcp://127.0.0.1:266{{.NodeNumber}}7"
+		RPCAddr = "tcp://127.0.0.1:{{ port "tendermint-rpc" .NodeNumber }}"
 	[Blockchain.Null]
-		Port = 31{{.NodeNumber}}1
+		Port = {{ port "vega-null-chain" .NodeNumber }}
 
 [EvtForward]
 	Level = "Info"
@@ -131,18 +131,18 @@ EOT
       tendermint = <<-EOT
 log_level = "info"
 
-proxy_app = "tcp://127.0.0.1:266{{.NodeNumber}}8"
+proxy_app = "tcp://127.0.0.1:{{ port "tendermint-abci" .NodeNumber }}"
 moniker = "{{.TendermintNodePrefix}}-{{.NodeNumber}}"
 
 [rpc]
-  laddr = "tcp://0.0.0.0:266{{.NodeNumber}}7"
+  laddr = "tcp://0.0.0.0:{{ port "tendermint-rpc" .NodeNumber }}"
   unsafe = true
   cors_allowed_origins = ["*"]
   cors_allowed_methods = ["HEAD", "GET", "POST", ]
   cors_allowed_headers = ["Origin", "Accept", "Content-Type", "X-Requested-With", "X-Server-Time", ]
 
 [p2p]
-  laddr = "tcp://0.0.0.0:266{{.NodeNumber}}6"
+  laddr = "tcp://0.0.0.0:{{ port "tendermint-p2p" .NodeNumber }}"
   addr_book_strict = false
   max_packet_msg_payload_size = 4096
   pex = false
@@ -150,7 +150,7 @@ moniker = "{{.TendermintNodePrefix}}-{{.NodeNumber}}"
 
   persistent_peers = "{{- range $i, $peer := .NodePeers -}}
 	  {{- if ne $i 0 }},{{end -}}
-	  {{- $peer.ID}}@127.0.0.1:266{{$peer.Index}}6
+	  {{- $peer.ID}}@127.0.0.1:{{ port "tendermint-p2p" $peer.Index }}
   {{- end -}}"
 
 
@@ -178,15 +178,15 @@ EOT
 
       vega = <<-EOT
 [API]
-	Port = 30{{.NodeNumber}}2
+	Port = {{ port "vega-grpc" .NodeNumber }}
 	[API.REST]
-			Port = 30{{.NodeNumber}}3
+			Port = {{ port "vega-rest" .NodeNumber }}
 
 [Blockchain]
 	[Blockchain.Tendermint]
-		RPCAddr = "tcp://127.0.0.1:266{{.NodeNumber}}7"
+		RPCAddr = "tcp://127.0.0.1:{{ port "tendermint-rpc" .NodeNumber }}"
 	[Blockchain.Null]
-		Port = 31{{.NodeNumber}}1
+		Port = {{ port "vega-null-chain" .NodeNumber }}
 
 [EvtForward]
 	Level = "Info"
@@ -207,7 +207,7 @@ EOT
 
 [Broker]
   [Broker.Socket]
-    Port = 30{{.NodeNumber}}5
+    Port = {{ port "vega-broker" .NodeNumber }}
     Enabled = true
 EOT
 
@@ -219,35 +219,35 @@ EOT
 
 GatewayEnabled = true
 [SqlStore]
-  Port = 5{{.NodeNumber}}32
+  Port = {{ port "data-node-postgres" .NodeNumber }}
 
 [API]
   Level = "Info"
-  Port = 30{{.NodeNumber}}7
-  CoreNodeGRPCPort = 30{{.NodeNumber}}2
+  Port = {{ port "data-node-grpc" .NodeNumber }}
+  CoreNodeGRPCPort = {{ port "vega-grpc" .NodeNumber }}
 
 [Pprof]
   Level = "Info"
   Enabled = true
-  Port = 6{{.NodeNumber}}60
+  Port = {{ port "data-node-pprof" .NodeNumber }}
   ProfilesDir = "{{.NodeHomeDir}}"
 
 [Gateway]
   Level = "Info"
-  Port = 30{{.NodeNumber}}8
+  Port = {{ port "data-node-gateway" .NodeNumber }}
   [Gateway.Node]
-    Port = 30{{.NodeNumber}}7
+    Port = {{ port "data-node-grpc" .NodeNumber }}
 
 [Metrics]
   Level = "Info"
   Timeout = "5s"
-  Port = 21{{.NodeNumber}}2
+  Port = {{ port "data-node-metrics" .NodeNumber }}
   Enabled = false
 [Broker]
   Level = "Info"
   UseEventFile = false
   [Broker.SocketConfig]
-    Port = 30{{.NodeNumber}}5
+    Port = {{ port "vega-broker" .NodeNumber }}
 
 EOT
 
@@ -258,25 +258,25 @@ EOT
       tendermint = <<-EOT
 log_level = "info"
 
-proxy_app = "tcp://127.0.0.1:266{{.NodeNumber}}8"
+proxy_app = "tcp://127.0.0.1:{{ port "tendermint-abci" .NodeNumber }}"
 moniker = "{{.TendermintNodePrefix}}-{{.NodeNumber}}"
 
 [rpc]
-  laddr = "tcp://0.0.0.0:266{{.NodeNumber}}7"
+  laddr = "tcp://0.0.0.0:{{ port "tendermint-rpc" .NodeNumber }}"
   unsafe = true
   cors_allowed_origins = ["*"]
   cors_allowed_methods = ["HEAD", "GET", "POST", ]
   cors_allowed_headers = ["Origin", "Accept", "Content-Type", "X-Requested-With", "X-Server-Time", ]
 
 [p2p]
-  laddr = "tcp://0.0.0.0:266{{.NodeNumber}}6"
+  laddr = "tcp://0.0.0.0:{{ port "tendermint-p2p" .NodeNumber }}"
   addr_book_strict = false
   max_packet_msg_payload_size = 4096
   pex = false
   allow_duplicate_ip = true
   persistent_peers = "{{- range $i, $peer := .NodePeers -}}
 	  {{- if ne $i 0 }},{{end -}}
-	  {{- $peer.ID}}@127.0.0.1:266{{$peer.Index}}6
+	  {{- $peer.ID}}@127.0.0.1:{{ port "tendermint-p2p" $peer.Index }}
   {{- end -}}"
 
 [mempool]
@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"time"
 
+	"code.vegaprotocol.io/vegacapsule/types"
+
 	"github.com/hashicorp/nomad/api"
 )
 
@@ -17,11 +20,14 @@ const (
 	taskReceivedMessage          = "received by client"
 	buildingTaskDirectoryMessage = "building task directory"
 	eventStartedType             = "Started"
+	notFoundResponseMessage      = "Unexpected response code: 404"
 )
 
 type allocationInfo struct {
 	taskName  string
 	taskState string
+	failed    bool
+	restarts  uint64
 	events    []*api.TaskEvent
 }
 
@@ -121,6 +127,16 @@ func (allocs allocations) pending() (bool, string) {
 	return false, ""
 }
 
+func (allocs allocations) failed() bool {
+	for _, a := range allocs {
+		if a.failed {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (allocs allocations) startedOrFinishedWithoutError() bool {
 	for _, a := range allocs {
 		if !a.started() && !a.finishedWithoutError() {
@@ -144,6 +160,8 @@ func (n *Client) getJobAllocsInfo(ctx context.Context, jobID string) (allocation
 			allocsInfo = append(allocsInfo, allocationInfo{
 				taskName:  tsName,
 				taskState: ts.State,
+				failed:    ts.Failed,
+				restarts:  ts.Restarts,
 				events:    ts.Events,
 			})
 		}
@@ -152,6 +170,47 @@ func (n *Client) getJobAllocsInfo(ctx context.Context, jobID string) (allocation
 	return allocsInfo, nil
 }
 
+// JobStatus returns status of the job based on states of its allocations.
+func (n *Client) JobStatus(ctx context.Context, jobID string) (*types.JobStatus, error) {
+	job, err := n.Info(ctx, jobID)
+	if err != nil {
+		// Nomad API client reports status codes only in the error message
+		if strings.Contains(err.Error(), notFoundResponseMessage) {
+			return &types.JobStatus{ID: jobID, Status: types.JobStatusNotFound}, nil
+		}
+		return nil, fmt.Errorf("failed to get job %q: %w", jobID, err)
+	}
+
+	allocs, err := n.getJobAllocsInfo(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allocations of job %q: %w", jobID, err)
+	}
+
+	status := &types.JobStatus{ID: jobID, Tasks: make([]types.TaskStatus, 0, len(allocs))}
+
+	sort.Slice(allocs, func(i, j int) bool { return allocs[i].taskName < allocs[j].taskName })
+	for _, a := range allocs {
+		status.Tasks = append(status.Tasks, types.TaskStatus{
+			Name:     a.taskName,
+			State:    a.taskState,
+			Restarts: a.restarts,
+		})
+	}
+
+	switch {
+	case job.Status != nil && *job.Status == Dead:
+		status.Status = types.JobStatusStopped
+	case allocs.failed():
+		status.Status = types.JobStatusFailed
+	case len(allocs) != 0 && allocs.startedOrFinishedWithoutError():
+		status.Status = types.JobStatusRunning
+	default:
+		status.Status = types.JobStatusPending
+	}
+
+	return status, nil
+}
+
 func (n *Client) jobTimedOut(ctx context.Context, t *time.Ticker, jobID string) (bool, error) {
 	select {
 	case <-t.C:
@@ -179,3 +238,54 @@ func (n *Client) jobTimedOut(ctx context.Context, t *time.Ticker, jobID string)
 		return false, nil
 	}
 }
+
+// runningAllocation returns the running allocation of the job.
+func (n *Client) runningAllocation(jobID string) (*api.Allocation, error) {
+	stubs, _, err := n.API.Jobs().Allocations(jobID, false, &api.QueryOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allocations of job %q: %w", jobID, err)
+	}
+
+	for _, stub := range stubs {
+		if stub.ClientStatus != api.AllocClientStatusRunning {
+			continue
+		}
+
+		alloc, _, err := n.API.Allocations().Info(stub.ID, &api.QueryOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get allocation %q of job %q: %w", stub.ID, jobID, err)
+		}
+
+		return alloc, nil
+	}
+
+	return nil, fmt.Errorf("job %q has no running allocation", jobID)
+}
+
+// SignalTask sends the signal, e.g. SIGKILL, to the task of the running job.
+func (n *Client) SignalTask(ctx context.Context, jobID, taskName, signal string) error {
+	alloc, err := n.runningAllocation(jobID)
+	if err != nil {
+		return err
+	}
+
+	if err := n.API.Allocations().Signal(alloc, &api.QueryOptions{}, taskName, signal); err != nil {
+		return fmt.Errorf("failed to send %s to task %q of job %q: %w", signal, taskName, jobID, err)
+	}
+
+	return nil
+}
+
+// RestartTask restarts the task of the running job in place.
+func (n *Client) RestartTask(ctx context.Context, jobID, taskName string) error {
+	alloc, err := n.runningAllocation(jobID)
+	if err != nil {
+		return err
+	}
+
+	if err := n.API.Allocations().Restart(alloc, taskName, &api.QueryOptions{}); err != nil {
+		return fmt.Errorf("failed to restart task %q of job %q: %w", taskName, jobID, err)
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"code.vegaprotocol.io/vegacapsule/config"
 	"code.vegaprotocol.io/vegacapsule/logscollector"
+	"code.vegaprotocol.io/vegacapsule/probes"
 	"code.vegaprotocol.io/vegacapsule/types"
 
 	"github.com/hashicorp/nomad/api"
@@ -21,6 +22,8 @@ type JobRunner struct {
 	Client        *Client
 	capsuleBinary string
 	logsOutputDir string
+	// ignoredJobPrefixes are prefixes of jobs that belong to other networks and must never be stopped
+	ignoredJobPrefixes []string
 }
 
 func NewJobRunner(c *Client, capsuleBinaryPath, logsOutputDir string) (*JobRunner, error) {
@@ -31,6 +34,22 @@ func NewJobRunner(c *Client, capsuleBinaryPath, logsOutputDir string) (*JobRunne
 	}, nil
 }
 
+// IgnoreJobsWithPrefixes makes the runner skip jobs with given prefixes when it stops all jobs.
+// It's used to protect jobs of other networks running in the same Nomad.
+func (r *JobRunner) IgnoreJobsWithPrefixes(prefixes ...string) {
+	r.ignoredJobPrefixes = append(r.ignoredJobPrefixes, prefixes...)
+}
+
+func (r *JobRunner) isIgnoredJob(jobID string) bool {
+	for _, prefix := range r.ignoredJobPrefixes {
+		if strings.HasPrefix(jobID, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (r *JobRunner) RunRawNomadJobs(ctx context.Context, rawJobs []string) ([]types.RawJobWithNomadJob, error) {
 	var mut sync.Mutex
 	jobs := make([]types.RawJobWithNomadJob, 0, len(rawJobs))
@@ -100,7 +119,7 @@ type jobWithPreProbe struct {
 
 // RunNodeSets returns list of started jobs.
 // When one of the jobs fails during startup it returns jobs that has alredy started before that.
-func (r *JobRunner) RunNodeSets(ctx context.Context, nodeSets []types.NodeSet, stopOnFailure bool) ([]*api.Job, error) {
+func (r *JobRunner) RunNodeSets(ctx context.Context, nodeSets []types.NodeSet, stopOnFailure bool) ([]string, error) {
 	jobs := make([]jobWithPreProbe, 0, len(nodeSets))
 
 	for _, ns := range nodeSets {
@@ -127,7 +146,7 @@ func (r *JobRunner) RunNodeSets(ctx context.Context, nodeSets []types.NodeSet, s
 	}
 
 	var mut sync.Mutex
-	startedJobs := make([]*api.Job, 0, len(nodeSets))
+	startedJobs := make([]string, 0, len(nodeSets))
 
 	eg := new(errgroup.Group)
 	for _, j := range jobs {
@@ -147,7 +166,7 @@ func (r *JobRunner) RunNodeSets(ctx context.Context, nodeSets []types.NodeSet, s
 			}
 
 			mut.Lock()
-			startedJobs = append(startedJobs, j.Job)
+			startedJobs = append(startedJobs, *j.Job.ID)
 			mut.Unlock()
 
 			return nil
@@ -309,37 +328,75 @@ func (r *JobRunner) StartWallet(
 }
 
 func (r *JobRunner) startNetwork(
-	gCtx context.Context,
+	ctx context.Context,
 	conf *config.Config,
 	generatedSvcs *types.GeneratedServices,
 	stopOnFailure bool,
 ) (*types.NetworkJobs, error) {
-	g, ctx := errgroup.WithContext(gCtx)
-
 	result := &types.NetworkJobs{
 		NodesSetsJobIDs: map[string]bool{},
 		ExtraJobIDs:     map[string]bool{},
 	}
-	var lock sync.Mutex
 
 	result.AddExtraJobIDs(generatedSvcs.PreGenerateJobsIDs())
 
-	if conf.Network.PreStart != nil {
-		extraJobIDs, err := r.runExecJobs(ctx, conf.Network.PreStart.Exec)
-		if err != nil {
-			return result, fmt.Errorf("failed to run pre start exec jobs: %w", err)
-		}
-		result.AddExtraJobIDs(extraJobIDs)
+	stages, err := conf.StartupStages(generatedSvcs)
+	if err != nil {
+		return result, fmt.Errorf("failed to resolve startup order: %w", err)
+	}
 
-		extraJobIDs, err = r.runDockerJobs(ctx, conf.Network.PreStart.Docker)
-		if err != nil {
-			return result, fmt.Errorf("failed to run pre start docker jobs: %w", err)
+	// services of a stage start in parallel once all services of the previous stages are healthy and ready
+	for _, stage := range stages {
+		if err := r.startStage(ctx, conf, generatedSvcs, stage, result, stopOnFailure); err != nil {
+			return result, fmt.Errorf("failed to start vega network: %w", err)
 		}
-		result.AddExtraJobIDs(extraJobIDs)
 	}
 
-	// create new error group to be able to call the `wait` function again
-	if generatedSvcs.Faucet != nil {
+	return result, nil
+}
+
+func (r *JobRunner) startStage(
+	gCtx context.Context,
+	conf *config.Config,
+	generatedSvcs *types.GeneratedServices,
+	stage config.StartupStage,
+	result *types.NetworkJobs,
+	stopOnFailure bool,
+) error {
+	g, ctx := errgroup.WithContext(gCtx)
+	var lock sync.Mutex
+
+	if len(stage.Exec) != 0 {
+		g.Go(func() error {
+			extraJobIDs, err := r.runExecJobs(ctx, stage.Exec)
+			if err != nil {
+				return fmt.Errorf("failed to run exec jobs: %w", err)
+			}
+
+			lock.Lock()
+			result.AddExtraJobIDs(extraJobIDs)
+			lock.Unlock()
+
+			return nil
+		})
+	}
+
+	if len(stage.Docker) != 0 {
+		g.Go(func() error {
+			extraJobIDs, err := r.runDockerJobs(ctx, stage.Docker)
+			if err != nil {
+				return fmt.Errorf("failed to run docker jobs: %w", err)
+			}
+
+			lock.Lock()
+			result.AddExtraJobIDs(extraJobIDs)
+			lock.Unlock()
+
+			return nil
+		})
+	}
+
+	if stage.Faucet && generatedSvcs.Faucet != nil {
 		g.Go(func() error {
 			jobID, err := r.StartFaucet(ctx, conf.Network.Faucet, generatedSvcs.Faucet)
 			if err != nil {
@@ -353,7 +410,7 @@ func (r *JobRunner) startNetwork(
 		})
 	}
 
-	if generatedSvcs.Wallet != nil {
+	if stage.Wallet && generatedSvcs.Wallet != nil {
 		g.Go(func() error {
 			jobID, err := r.StartWallet(ctx, conf.Network.Wallet, generatedSvcs.Wallet)
 			if err != nil {
@@ -368,36 +425,30 @@ func (r *JobRunner) startNetwork(
 		})
 	}
 
-	g.Go(func() error {
-		jobs, err := r.RunNodeSets(ctx, generatedSvcs.NodeSets.ToSlice(), stopOnFailure)
-
-		lock.Lock()
-		for _, job := range jobs {
-			result.NodesSetsJobIDs[*job.ID] = true
-		}
-		lock.Unlock()
+	if len(stage.NodeSets) != 0 {
+		g.Go(func() error {
+			jobIDs, err := r.RunNodeSets(ctx, stage.NodeSets, stopOnFailure)
 
-		if err != nil {
-			return fmt.Errorf("failed to run node sets: %w", err)
-		}
+			lock.Lock()
+			for _, jobID := range jobIDs {
+				result.NodesSetsJobIDs[jobID] = true
+			}
+			lock.Unlock()
 
-		return nil
-	})
+			if err != nil {
+				return fmt.Errorf("failed to run node sets: %w", err)
+			}
 
-	if err := g.Wait(); err != nil {
-		return result, fmt.Errorf("failed to start vega network: %w", err)
+			return nil
+		})
 	}
 
-	if conf.Network.PostStart != nil {
-		extraJobIDs, err := r.runDockerJobs(gCtx, conf.Network.PostStart.Docker)
-		if err != nil {
-			return result, fmt.Errorf("failed to run post start jobs: %w", err)
-		}
-
-		result.AddExtraJobIDs(extraJobIDs)
+	if err := g.Wait(); err != nil {
+		return err
 	}
 
-	return result, nil
+	// services depending on this stage start only when it's ready
+	return probes.WaitReady(gCtx, probes.StageReadinessChecks(conf, generatedSvcs, stage))
 }
 
 func (r *JobRunner) stopAllJobs(ctx context.Context) ([]string, error) {
@@ -408,7 +459,7 @@ func (r *JobRunner) stopAllJobs(ctx context.Context) ([]string, error) {
 
 	allJobIDs := []string{}
 	for _, job := range allJobs {
-		if job.ID == "" {
+		if job.ID == "" || r.isIgnoredJob(job.ID) {
 			continue
 		}
 		allJobIDs = append(allJobIDs, job.ID)
@@ -441,6 +492,29 @@ func (r *JobRunner) StopJobs(ctx context.Context, jobIDs []string) ([]string, er
 }
 
 // ListExposedPortsPerJob returns exposed ports per node
+// JobStatus returns status of the job in Nomad.
+func (r *JobRunner) JobStatus(ctx context.Context, jobID string) (*types.JobStatus, error) {
+	return r.Client.JobStatus(ctx, jobID)
+}
+
+// SignalTask sends the signal, e.g. SIGKILL, to the task of the running job.
+func (r *JobRunner) SignalTask(ctx context.Context, jobID, taskName, signal string) error {
+	if r.isIgnoredJob(jobID) {
+		return fmt.Errorf("job %q belongs to other network", jobID)
+	}
+
+	return r.Client.SignalTask(ctx, jobID, taskName, signal)
+}
+
+// RestartTask restarts the task of the running job, other tasks of the job keep running.
+func (r *JobRunner) RestartTask(ctx context.Context, jobID, taskName string) error {
+	if r.isIgnoredJob(jobID) {
+		return fmt.Errorf("job %q belongs to other network", jobID)
+	}
+
+	return r.Client.RestartTask(ctx, jobID, taskName)
+}
+
 func (r *JobRunner) ListExposedPortsPerJob(ctx context.Context, jobID string) ([]int64, error) {
 	job, err := r.Client.Info(ctx, jobID)
 	if err != nil {
@@ -0,0 +1,225 @@
+package plan
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/types"
+)
+
+// NodeSetsAddition describes node sets to be added to the group.
+type NodeSetsAddition struct {
+	GroupName string `json:"group_name"`
+	Count     int    `json:"count"`
+}
+
+// TemplateChange describes templates of a node set whose rendered output changes.
+type TemplateChange struct {
+	NodeSetName string   `json:"node_set_name"`
+	GroupName   string   `json:"group_name"`
+	Templates   []string `json:"templates"`
+}
+
+// Plan lists changes needed to move the generated network from the current to the desired configuration.
+type Plan struct {
+	AddNodeSets     []NodeSetsAddition `json:"add_node_sets"`
+	RemoveNodeSets  []string           `json:"remove_node_sets"`
+	UpdateTemplates []TemplateChange   `json:"update_templates"`
+	StartServices   []string           `json:"start_services"`
+	StopServices    []string           `json:"stop_services"`
+	RestartServices []string           `json:"restart_services"`
+}
+
+func (p Plan) Empty() bool {
+	return len(p.AddNodeSets) == 0 &&
+		len(p.RemoveNodeSets) == 0 &&
+		len(p.UpdateTemplates) == 0 &&
+		len(p.StartServices) == 0 &&
+		len(p.StopServices) == 0 &&
+		len(p.RestartServices) == 0
+}
+
+type nodeSetTemplater interface {
+	TemplateNodeSet(nc config.NodeConfig, ns types.NodeSet, fc *types.Faucet) (map[string][]byte, error)
+}
+
+// New compares the current configuration of the generated network with the desired one.
+// Templates of the node sets are rendered by currentGen and desiredGen with the current and desired configuration respectively.
+func New(
+	current, desired *config.Config,
+	genServices types.GeneratedServices,
+	currentGen, desiredGen nodeSetTemplater,
+) (*Plan, error) {
+	p := &Plan{}
+
+	removed := map[string]bool{}
+
+	for _, nc := range desired.Network.Nodes {
+		currentNC, _ := current.Network.GetNodeConfig(nc.Name)
+		nodeSets := sortedByIndex(genServices.GetNodeSetsByGroupName(nc.Name))
+
+		existing := len(nodeSets)
+		remove := []types.NodeSet{}
+
+		switch {
+		// node sets can't change their kind, so they are replaced
+		case currentNC != nil && requiresReplacement(*currentNC, nc):
+			remove = nodeSets
+			existing = 0
+		case existing > nc.Count:
+			remove = nodeSets[nc.Count:]
+		}
+
+		// the newest node sets are removed first
+		for i := len(remove) - 1; i >= 0; i-- {
+			p.RemoveNodeSets = append(p.RemoveNodeSets, remove[i].Name)
+			removed[remove[i].Name] = true
+		}
+
+		if nc.Count > existing {
+			p.AddNodeSets = append(p.AddNodeSets, NodeSetsAddition{
+				GroupName: nc.Name,
+				Count:     nc.Count - existing,
+			})
+		}
+	}
+
+	for _, nc := range current.Network.Nodes {
+		if _, err := desired.Network.GetNodeConfig(nc.Name); err == nil {
+			continue
+		}
+
+		for _, ns := range sortedByIndex(genServices.GetNodeSetsByGroupName(nc.Name)) {
+			p.RemoveNodeSets = append(p.RemoveNodeSets, ns.Name)
+			removed[ns.Name] = true
+		}
+	}
+
+	for _, nc := range desired.Network.Nodes {
+		currentNC, err := current.Network.GetNodeConfig(nc.Name)
+		if err != nil {
+			continue
+		}
+
+		for _, ns := range sortedByIndex(genServices.GetNodeSetsByGroupName(nc.Name)) {
+			if removed[ns.Name] {
+				continue
+			}
+
+			change, err := templateChange(*currentNC, nc, ns, genServices.Faucet, currentGen, desiredGen)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compare templates of %q: %w", ns.Name, err)
+			}
+
+			if change != nil {
+				p.UpdateTemplates = append(p.UpdateTemplates, *change)
+			}
+		}
+	}
+
+	p.diffServices(
+		servicesByName(current.Network.PreStart, current.Network.PostStart),
+		servicesByName(desired.Network.PreStart, desired.Network.PostStart),
+	)
+
+	return p, nil
+}
+
+func (p *Plan) diffServices(current, desired map[string]interface{}) {
+	for name, svc := range desired {
+		currentSvc, ok := current[name]
+		if !ok {
+			p.StartServices = append(p.StartServices, name)
+			continue
+		}
+
+		if !reflect.DeepEqual(currentSvc, svc) {
+			p.RestartServices = append(p.RestartServices, name)
+		}
+	}
+
+	for name := range current {
+		if _, ok := desired[name]; !ok {
+			p.StopServices = append(p.StopServices, name)
+		}
+	}
+
+	sort.Strings(p.StartServices)
+	sort.Strings(p.RestartServices)
+	sort.Strings(p.StopServices)
+}
+
+func templateChange(
+	currentNC, desiredNC config.NodeConfig,
+	ns types.NodeSet,
+	fc *types.Faucet,
+	currentGen, desiredGen nodeSetTemplater,
+) (*TemplateChange, error) {
+	currentTemplates, err := currentGen.TemplateNodeSet(currentNC, ns, fc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to template current configuration: %w", err)
+	}
+
+	desiredTemplates, err := desiredGen.TemplateNodeSet(desiredNC, ns, fc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to template desired configuration: %w", err)
+	}
+
+	changed := []string{}
+	for kind, out := range desiredTemplates {
+		if currentOut, ok := currentTemplates[kind]; !ok || !bytes.Equal(currentOut, out) {
+			changed = append(changed, kind)
+		}
+	}
+	for kind := range currentTemplates {
+		if _, ok := desiredTemplates[kind]; !ok {
+			changed = append(changed, kind)
+		}
+	}
+
+	if len(changed) == 0 {
+		return nil, nil
+	}
+	sort.Strings(changed)
+
+	return &TemplateChange{
+		NodeSetName: ns.Name,
+		GroupName:   ns.GroupName,
+		Templates:   changed,
+	}, nil
+}
+
+func requiresReplacement(current, desired config.NodeConfig) bool {
+	return current.Mode != desired.Mode ||
+		current.UseDataNode != desired.UseDataNode ||
+		current.VisorBinary != desired.VisorBinary
+}
+
+func servicesByName(configs ...*config.PStartConfig) map[string]interface{} {
+	out := map[string]interface{}{}
+
+	for _, c := range configs {
+		if c == nil {
+			continue
+		}
+
+		for _, dc := range c.Docker {
+			out[dc.Name] = dc
+		}
+		for _, ec := range c.Exec {
+			out[ec.Name] = ec
+		}
+	}
+
+	return out
+}
+
+func sortedByIndex(nodeSets []types.NodeSet) []types.NodeSet {
+	sort.Slice(nodeSets, func(i, j int) bool {
+		return nodeSets[i].Index < nodeSets[j].Index
+	})
+	return nodeSets
+}
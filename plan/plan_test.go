@@ -0,0 +1,90 @@
+package plan_test
+
+import (
+	"fmt"
+	"testing"
+
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/plan"
+	"code.vegaprotocol.io/vegacapsule/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// templater renders the raw Vega template with node set name appended
+type templater struct{}
+
+func (templater) TemplateNodeSet(nc config.NodeConfig, ns types.NodeSet, fc *types.Faucet) (map[string][]byte, error) {
+	out := map[string][]byte{}
+	if nc.ConfigTemplates.Vega != nil {
+		out["vega"] = []byte(*nc.ConfigTemplates.Vega + ns.Name)
+	}
+	return out, nil
+}
+
+func TestNew(t *testing.T) {
+	vegaTemplate := "vega"
+	changedVegaTemplate := "changed vega"
+
+	current := &config.Config{
+		Network: config.NetworkConfig{
+			Nodes: []config.NodeConfig{
+				{Name: "validators", Mode: types.NodeModeValidator, Count: 2, ConfigTemplates: config.ConfigTemplates{Vega: &vegaTemplate}},
+				{Name: "full", Mode: types.NodeModeFull, Count: 2},
+				{Name: "archive", Mode: types.NodeModeFull, Count: 1},
+			},
+			PreStart: &config.PStartConfig{
+				Docker: []config.DockerConfig{
+					{Name: "ganache", Image: "ganache:1"},
+					{Name: "postgres", Image: "postgres:14"},
+				},
+			},
+		},
+	}
+
+	desired := &config.Config{
+		Network: config.NetworkConfig{
+			Nodes: []config.NodeConfig{
+				{Name: "validators", Mode: types.NodeModeValidator, Count: 3, ConfigTemplates: config.ConfigTemplates{Vega: &changedVegaTemplate}},
+				{Name: "full", Mode: types.NodeModeFull, Count: 1},
+				{Name: "archive", Mode: types.NodeModeFull, Count: 1, UseDataNode: true},
+			},
+			PreStart: &config.PStartConfig{
+				Docker: []config.DockerConfig{
+					{Name: "ganache", Image: "ganache:2"},
+				},
+				Exec: []config.ExecConfig{
+					{Name: "faucet-bot"},
+				},
+			},
+		},
+	}
+
+	genServices := types.GeneratedServices{NodeSets: types.NodeSetMap{}}
+	for i, group := range []string{"validators", "validators", "full", "full", "archive"} {
+		name := fmt.Sprintf("testnet-nodeset-%s-%d", group, i)
+		genServices.NodeSets[name] = types.NodeSet{Name: name, GroupName: group, Index: i}
+	}
+
+	p, err := plan.New(current, desired, genServices, templater{}, templater{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []plan.NodeSetsAddition{
+		{GroupName: "validators", Count: 1},
+		{GroupName: "archive", Count: 1},
+	}, p.AddNodeSets)
+	assert.Equal(t, []string{"testnet-nodeset-full-3", "testnet-nodeset-archive-4"}, p.RemoveNodeSets)
+	assert.Equal(t, []plan.TemplateChange{
+		{NodeSetName: "testnet-nodeset-validators-0", GroupName: "validators", Templates: []string{"vega"}},
+		{NodeSetName: "testnet-nodeset-validators-1", GroupName: "validators", Templates: []string{"vega"}},
+	}, p.UpdateTemplates)
+	assert.Equal(t, []string{"faucet-bot"}, p.StartServices)
+	assert.Equal(t, []string{"postgres"}, p.StopServices)
+	assert.Equal(t, []string{"ganache"}, p.RestartServices)
+	assert.False(t, p.Empty())
+
+	p, err = plan.New(current, current, genServices, templater{}, templater{})
+	require.NoError(t, err)
+	assert.True(t, p.Empty())
+}
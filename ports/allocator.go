@@ -0,0 +1,189 @@
+package ports
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/Masterminds/sprig"
+)
+
+const (
+	// MinAllocatedPort and MaxAllocatedPort define range of automatically allocated ports.
+	// The range is below the default ephemeral port range of Linux and macOS.
+	MinAllocatedPort int64 = 20000
+	MaxAllocatedPort int64 = 32767
+
+	// TemplateFuncName is the name of the function used to request a port in templates.
+	TemplateFuncName = "port"
+)
+
+var ErrPortAllocationDisabled = errors.New("port allocation is not available for the network, please regenerate the network")
+
+// Allocator assigns free ports on the host to names. Once allocated, the same port is returned for the name,
+// so the allocation can be persisted and used again to template the same configuration.
+type Allocator struct {
+	mu sync.Mutex
+
+	// Ports maps names of the allocated ports to port numbers.
+	Ports map[string]int64
+
+	// reserved are ports that can't be allocated because they are used by other networks
+	reserved map[int64]struct{}
+	// isFree checks whether the port is not used by other process on the host
+	isFree func(port int64) bool
+}
+
+// NewAllocator returns allocator that never allocates any of the reserved ports.
+func NewAllocator(reserved []int64) *Allocator {
+	a := &Allocator{
+		Ports: map[string]int64{},
+	}
+	a.Reserve(reserved...)
+
+	return a
+}
+
+// Reserve excludes given ports from the allocation.
+func (a *Allocator) Reserve(ports ...int64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.reserved == nil {
+		a.reserved = map[int64]struct{}{}
+	}
+
+	for _, p := range ports {
+		a.reserved[p] = struct{}{}
+	}
+}
+
+// Port returns port allocated for the name. A new free port is allocated when the name is requested for the first time.
+func (a *Allocator) Port(name string) (int64, error) {
+	if a == nil {
+		return 0, ErrPortAllocationDisabled
+	}
+
+	if name == "" {
+		return 0, fmt.Errorf("port name must not be empty")
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.Ports == nil {
+		a.Ports = map[string]int64{}
+	}
+
+	if port, ok := a.Ports[name]; ok {
+		return port, nil
+	}
+
+	allocated := make(map[int64]struct{}, len(a.Ports))
+	for _, p := range a.Ports {
+		allocated[p] = struct{}{}
+	}
+
+	isFree := a.isFree
+	if isFree == nil {
+		isFree = portFree
+	}
+
+	for port := MinAllocatedPort; port <= MaxAllocatedPort; port++ {
+		if _, ok := allocated[port]; ok {
+			continue
+		}
+		if _, ok := a.reserved[port]; ok {
+			continue
+		}
+		if !isFree(port) {
+			continue
+		}
+
+		a.Ports[name] = port
+		return port, nil
+	}
+
+	return 0, fmt.Errorf("failed to allocate port %q: no free port in range %d-%d", name, MinAllocatedPort, MaxAllocatedPort)
+}
+
+// Clone returns copy of the allocator, ports allocated by the copy are not allocated by the original.
+func (a *Allocator) Clone() *Allocator {
+	if a == nil {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	c := &Allocator{
+		Ports:    make(map[string]int64, len(a.Ports)),
+		reserved: make(map[int64]struct{}, len(a.reserved)),
+		isFree:   a.isFree,
+	}
+	for name, port := range a.Ports {
+		c.Ports[name] = port
+	}
+	for port := range a.reserved {
+		c.reserved[port] = struct{}{}
+	}
+
+	return c
+}
+
+// AllocatedPorts returns allocated ports sorted by name.
+func (a *Allocator) AllocatedPorts() []PortWithName {
+	if a == nil {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	out := make([]PortWithName, 0, len(a.Ports))
+	for name, port := range a.Ports {
+		out = append(out, PortWithName{Name: name, Port: port})
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+
+	return out
+}
+
+// PortName joins name with optional suffixes, e.g. ("vega-grpc", 1) results in "vega-grpc-1".
+func PortName(name string, suffixes ...interface{}) string {
+	parts := make([]string, 0, len(suffixes)+1)
+	parts = append(parts, name)
+	for _, s := range suffixes {
+		parts = append(parts, fmt.Sprint(s))
+	}
+
+	return strings.Join(parts, "-")
+}
+
+// TemplateFuncs returns functions available in Capsule templates extended with the `port` function.
+// The function allocates named port, e.g. {{ port "vega-grpc" .NodeNumber }}.
+func TemplateFuncs(a *Allocator) template.FuncMap {
+	funcs := sprig.TxtFuncMap()
+	funcs[TemplateFuncName] = func(name string, suffixes ...interface{}) (int64, error) {
+		return a.Port(PortName(name, suffixes...))
+	}
+
+	return funcs
+}
+
+func portFree(port int64) bool {
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return false
+	}
+	l.Close()
+
+	return true
+}
@@ -0,0 +1,57 @@
+package ports_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"text/template"
+
+	"code.vegaprotocol.io/vegacapsule/ports"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocator(t *testing.T) {
+	a := ports.NewAllocator([]int64{ports.MinAllocatedPort, ports.MinAllocatedPort + 1})
+
+	grpcPort, err := a.Port("vega-grpc-0")
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, grpcPort, ports.MinAllocatedPort+2)
+	assert.LessOrEqual(t, grpcPort, ports.MaxAllocatedPort)
+
+	restPort, err := a.Port("vega-rest-0")
+	require.NoError(t, err)
+	assert.NotEqual(t, grpcPort, restPort)
+
+	samePort, err := a.Port("vega-grpc-0")
+	require.NoError(t, err)
+	assert.Equal(t, grpcPort, samePort)
+
+	// allocation survives persisting
+	b, err := json.Marshal(a)
+	require.NoError(t, err)
+
+	loaded := &ports.Allocator{}
+	require.NoError(t, json.Unmarshal(b, loaded))
+	assert.Equal(t, a.AllocatedPorts(), loaded.AllocatedPorts())
+
+	tmpl, err := template.New("test").Funcs(ports.TemplateFuncs(loaded)).Parse(`{{ port "vega-grpc" 0 }},{{ port "vega-rest" 0 }}`)
+	require.NoError(t, err)
+
+	buff := bytes.NewBuffer(nil)
+	require.NoError(t, tmpl.Execute(buff, nil))
+	assert.Equal(t, fmt.Sprintf("%d,%d", grpcPort, restPort), buff.String())
+
+	// ports allocated by clone are not allocated by the original
+	clone := a.Clone()
+	_, err = clone.Port("vega-grpc-1")
+	require.NoError(t, err)
+	assert.Len(t, clone.AllocatedPorts(), 3)
+	assert.Len(t, a.AllocatedPorts(), 2)
+
+	var nilAllocator *ports.Allocator
+	_, err = nilAllocator.Port("vega-grpc-0")
+	assert.ErrorIs(t, err, ports.ErrPortAllocationDisabled)
+}
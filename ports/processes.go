@@ -20,6 +20,8 @@ const (
 	portStatusListen = "LISTEN"
 	nomadProcessName = "nomad"
 	visorProcessName = "visor"
+	// supervisor of the native job runner runs as a Capsule subcommand
+	capsuleProcessName = "vegacapsule"
 )
 
 var networkProcessesNames = map[string]struct{}{
@@ -48,7 +50,9 @@ func ScanNetworkProcessesPorts() (map[int64]string, error) {
 			continue
 		}
 
-		if !(strings.Contains(parentName, nomadProcessName) || strings.Contains(parentName, visorProcessName)) {
+		if !(strings.Contains(parentName, nomadProcessName) ||
+			strings.Contains(parentName, visorProcessName) ||
+			strings.Contains(parentName, capsuleProcessName)) {
 			continue
 		}
 
@@ -0,0 +1,48 @@
+package ports
+
+import (
+	"errors"
+	"fmt"
+
+	"code.vegaprotocol.io/vegacapsule/types"
+)
+
+const (
+	// VegaRESTPortName is name of the REST API port in the Vega node config.
+	VegaRESTPortName = "API.REST"
+	// DataNodeRESTPortName is name of the REST API port in the data node config.
+	DataNodeRESTPortName = "Gateway"
+)
+
+var ErrDataNodeRESTNotFound = errors.New("no data node with REST API found")
+
+// RESTURL returns local URL of the port with given name in the config.
+func RESTURL(configPath, portName string) (string, error) {
+	configPorts, err := ExtractPortsFromConfig(configPath)
+	if err != nil {
+		return "", err
+	}
+
+	for port, name := range configPorts {
+		if name == portName {
+			return fmt.Sprintf("http://localhost:%d", port), nil
+		}
+	}
+
+	return "", fmt.Errorf("port %q not found in %q", portName, configPath)
+}
+
+// DataNodeRESTURL returns URL of the REST API of the first node set with a data node.
+func DataNodeRESTURL(nodeSets []types.NodeSet) (string, error) {
+	for _, ns := range nodeSets {
+		if ns.DataNode == nil {
+			continue
+		}
+
+		if url, err := RESTURL(ns.DataNode.ConfigFilePath, DataNodeRESTPortName); err == nil {
+			return url, nil
+		}
+	}
+
+	return "", ErrDataNodeRESTNotFound
+}
@@ -0,0 +1,76 @@
+package probes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type statisticsResponse struct {
+	Statistics struct {
+		BlockHeight string `json:"blockHeight"`
+	} `json:"statistics"`
+}
+
+// blockHeightProbe passes when the block height is higher than the one returned by the previous call.
+type blockHeightProbe struct {
+	url        string
+	lastHeight uint64
+}
+
+func newBlockHeightProbe(url string) *blockHeightProbe {
+	return &blockHeightProbe{
+		url: strings.TrimSuffix(url, "/") + "/statistics",
+	}
+}
+
+func newBlockHeightProbeErr(url string, err error) error {
+	return fmt.Errorf("failed to probe block height at %q: %w", url, err)
+}
+
+func (p *blockHeightProbe) probe(ctx context.Context, id string) error {
+	log.Printf("Probing block height with id %q and url %q", id, p.url)
+
+	ctx, cancel := context.WithTimeout(ctx, singleProbeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return newBlockHeightProbeErr(p.url, err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return newBlockHeightProbeErr(p.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newBlockHeightProbeErr(p.url, fmt.Errorf("unexpected status code %d", resp.StatusCode))
+	}
+
+	var stats statisticsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		return newBlockHeightProbeErr(p.url, fmt.Errorf("failed to decode statistics: %w", err))
+	}
+
+	height, err := strconv.ParseUint(stats.Statistics.BlockHeight, 10, 64)
+	if err != nil {
+		return newBlockHeightProbeErr(p.url, fmt.Errorf("failed to parse block height %q: %w", stats.Statistics.BlockHeight, err))
+	}
+
+	lastHeight := p.lastHeight
+	p.lastHeight = height
+
+	if lastHeight == 0 || height <= lastHeight {
+		return newBlockHeightProbeErr(p.url, fmt.Errorf("block height %d has not advanced", height))
+	}
+
+	log.Printf("Probing block height with id %q was successful, block height %d", id, height)
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package probes
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func newEthereumRPCProbeErr(url string, err error) error {
+	return fmt.Errorf("failed to probe Ethereum RPC url %q: %w", url, err)
+}
+
+// ProbeEthereumRPC checks that the Ethereum node reports the chain ID and that the contract is deployed if its address is given.
+func ProbeEthereumRPC(ctx context.Context, id, url string, chainID int64, contractAddress string) error {
+	log.Printf("Probing Ethereum RPC with id %q and url %q", id, url)
+
+	ctx, cancel := context.WithTimeout(ctx, singleProbeTimeout)
+	defer cancel()
+
+	client, err := ethclient.DialContext(ctx, url)
+	if err != nil {
+		return newEthereumRPCProbeErr(url, err)
+	}
+	defer client.Close()
+
+	actualChainID, err := client.ChainID(ctx)
+	if err != nil {
+		return newEthereumRPCProbeErr(url, fmt.Errorf("failed to get chain ID: %w", err))
+	}
+
+	if !actualChainID.IsInt64() || actualChainID.Int64() != chainID {
+		return newEthereumRPCProbeErr(url, fmt.Errorf("chain ID %s does not match expected %d", actualChainID, chainID))
+	}
+
+	if contractAddress == "" {
+		log.Printf("Probing Ethereum RPC with id %q was successful", id)
+		return nil
+	}
+
+	code, err := client.CodeAt(ctx, common.HexToAddress(contractAddress), nil)
+	if err != nil {
+		return newEthereumRPCProbeErr(url, fmt.Errorf("failed to get code of contract %q: %w", contractAddress, err))
+	}
+
+	if len(code) == 0 {
+		return newEthereumRPCProbeErr(url, fmt.Errorf("contract %q is not deployed", contractAddress))
+	}
+
+	log.Printf("Probing Ethereum RPC with id %q was successful", id)
+
+	return nil
+}
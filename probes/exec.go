@@ -0,0 +1,39 @@
+package probes
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os/exec"
+	"regexp"
+)
+
+func newExecProbeErr(cmd string, err error) error {
+	return fmt.Errorf("failed to probe command %q: %w", cmd, err)
+}
+
+// ProbeExec runs the command and checks that it succeeds and its standard output matches stdoutRegex if given.
+func ProbeExec(ctx context.Context, id, cmd string, args []string, stdoutRegex *regexp.Regexp) error {
+	log.Printf("Probing command with id %q and command %q", id, cmd)
+
+	ctx, cancel := context.WithTimeout(ctx, singleProbeTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	c := exec.CommandContext(ctx, cmd, args...)
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+
+	if err := c.Run(); err != nil {
+		return newExecProbeErr(cmd, fmt.Errorf("%w: %s", err, bytes.TrimSpace(stderr.Bytes())))
+	}
+
+	if stdoutRegex != nil && !stdoutRegex.Match(stdout.Bytes()) {
+		return newExecProbeErr(cmd, fmt.Errorf("output %q does not match %q", bytes.TrimSpace(stdout.Bytes()), stdoutRegex))
+	}
+
+	log.Printf("Probing command with id %q was successful", id)
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package probes
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func newGRPCProbeErr(address string, err error) error {
+	return fmt.Errorf("failed to probe gRPC address %q: %w", address, err)
+}
+
+// ProbeGRPC calls the standard gRPC health check of the server.
+// Servers that do not register the health service are considered serving when they respond with Unimplemented.
+func ProbeGRPC(ctx context.Context, id, address, service string) error {
+	log.Printf("Probing gRPC with id %q address %q", id, address)
+
+	ctx, cancel := context.WithTimeout(ctx, singleProbeTimeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return newGRPCProbeErr(address, err)
+	}
+	defer conn.Close()
+
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service})
+	if status.Code(err) == codes.Unimplemented {
+		log.Printf("Probing gRPC with id %q was successful, health service is not implemented", id)
+		return nil
+	}
+	if err != nil {
+		return newGRPCProbeErr(address, err)
+	}
+
+	if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		return newGRPCProbeErr(address, fmt.Errorf("service %q is not serving: %s", service, resp.Status))
+	}
+
+	log.Printf("Probing gRPC with id %q was successful", id)
+
+	return nil
+}
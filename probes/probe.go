@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"regexp"
 	"time"
 
 	"code.vegaprotocol.io/vegacapsule/types"
@@ -12,32 +13,90 @@ import (
 )
 
 var (
-	totalProbeTimeout  = time.Second * 40
-	singleProbeTimeout = time.Second * 2
+	totalProbeTimeout       = time.Second * 40
+	singleProbeTimeout      = time.Second * 2
+	defaultProbeInterval    = time.Second * 2
+	defaultSuccessThreshold = 1
 )
 
-func probe(ctx context.Context, id, probeType string, call func() error) error {
-	t := time.NewTicker(time.Second * 2)
+type settings struct {
+	timeout          time.Duration
+	interval         time.Duration
+	successThreshold int
+}
+
+func probeSettings(probes types.ProbesConfig) (settings, error) {
+	if err := probes.Validate(); err != nil {
+		return settings{}, err
+	}
+
+	s := settings{
+		timeout:          totalProbeTimeout,
+		interval:         defaultProbeInterval,
+		successThreshold: defaultSuccessThreshold,
+	}
+
+	// durations are validated above
+	if probes.Timeout != nil {
+		s.timeout, _ = time.ParseDuration(*probes.Timeout)
+	}
+	if probes.Interval != nil {
+		s.interval, _ = time.ParseDuration(*probes.Interval)
+	}
+	if probes.SuccessThreshold != nil {
+		s.successThreshold = *probes.SuccessThreshold
+	}
+
+	return s, nil
+}
+
+// probe calls the probe until it succeeds successThreshold times in a row.
+func probe(ctx context.Context, id, probeType string, s settings, call func() error) error {
+	t := time.NewTicker(s.interval)
+	defer t.Stop()
 
 	var err error
+	successes := 0
 	for {
 		select {
 		case <-ctx.Done():
 			return fmt.Errorf("%s: %w", ctx.Err(), err)
 		case <-t.C:
-			err = call()
+			callErr := call()
+			// a call interrupted by the deadline would hide the reason why the probe has been failing
+			if ctx.Err() != nil && callErr != nil && err != nil {
+				return fmt.Errorf("%s: %w", ctx.Err(), err)
+			}
+
+			err = callErr
 			if err == nil {
-				return nil
+				successes++
+				if successes >= s.successThreshold {
+					return nil
+				}
+				continue
 			}
 
+			successes = 0
 			log.Printf("Probe with id %q and type %q has failed %q", id, probeType, err)
 		}
 	}
 }
 
+// Probe waits until all the probes pass. Probes without timeout fail after the default timeout,
+// unless the context has a deadline.
 func Probe(ctx context.Context, id string, probes types.ProbesConfig) error {
-	ctx, cancel := context.WithTimeout(ctx, totalProbeTimeout)
-	defer cancel()
+	s, err := probeSettings(probes)
+	if err != nil {
+		return fmt.Errorf("invalid probes with id %q: %w", id, err)
+	}
+
+	// probes without own timeout wait until the deadline of the caller if there is one
+	if _, hasDeadline := ctx.Deadline(); !hasDeadline || probes.Timeout != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
 
 	eg, ctx := errgroup.WithContext(ctx)
 
@@ -47,14 +106,14 @@ func Probe(ctx context.Context, id string, probes types.ProbesConfig) error {
 		}
 
 		eg.Go(func() error {
-			return probe(ctx, id, "HTTP", call)
+			return probe(ctx, id, "HTTP", s, call)
 		})
 	}
 	if probes.TCP != nil {
 		call := func() error { return ProbeTCP(ctx, id, probes.TCP.Address) }
 
 		eg.Go(func() error {
-			return probe(ctx, id, "TCP", call)
+			return probe(ctx, id, "TCP", s, call)
 		})
 	}
 	if probes.Postgres != nil {
@@ -68,7 +127,52 @@ func Probe(ctx context.Context, id string, probes types.ProbesConfig) error {
 		}
 
 		eg.Go(func() error {
-			return probe(ctx, id, "Postgres", call)
+			return probe(ctx, id, "Postgres", s, call)
+		})
+	}
+	if probes.BlockHeight != nil {
+		bh := newBlockHeightProbe(probes.BlockHeight.URL)
+		call := func() error { return bh.probe(ctx, id) }
+
+		eg.Go(func() error {
+			return probe(ctx, id, "BlockHeight", s, call)
+		})
+	}
+
+	if probes.GRPC != nil {
+		var service string
+		if probes.GRPC.Service != nil {
+			service = *probes.GRPC.Service
+		}
+		call := func() error { return ProbeGRPC(ctx, id, probes.GRPC.Address, service) }
+
+		eg.Go(func() error {
+			return probe(ctx, id, "gRPC", s, call)
+		})
+	}
+	if probes.Exec != nil {
+		var stdoutRegex *regexp.Regexp
+		// regex is validated above
+		if probes.Exec.StdoutRegex != nil {
+			stdoutRegex = regexp.MustCompile(*probes.Exec.StdoutRegex)
+		}
+		call := func() error { return ProbeExec(ctx, id, probes.Exec.Cmd, probes.Exec.Args, stdoutRegex) }
+
+		eg.Go(func() error {
+			return probe(ctx, id, "Exec", s, call)
+		})
+	}
+	if probes.EthereumRPC != nil {
+		var contractAddress string
+		if probes.EthereumRPC.ContractAddress != nil {
+			contractAddress = *probes.EthereumRPC.ContractAddress
+		}
+		call := func() error {
+			return ProbeEthereumRPC(ctx, id, probes.EthereumRPC.URL, int64(probes.EthereumRPC.ChainID), contractAddress)
+		}
+
+		eg.Go(func() error {
+			return probe(ctx, id, "EthereumRPC", s, call)
 		})
 	}
 
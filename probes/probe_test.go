@@ -0,0 +1,159 @@
+package probes_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"code.vegaprotocol.io/vegacapsule/probes"
+	"code.vegaprotocol.io/vegacapsule/types"
+	"code.vegaprotocol.io/vegacapsule/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStatisticsServer(t *testing.T, advance bool) *httptest.Server {
+	var height atomic.Int64
+	height.Store(10)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/statistics", r.URL.Path)
+
+		h := height.Load()
+		if advance {
+			h = height.Add(1)
+		}
+
+		fmt.Fprintf(w, `{"statistics": {"blockHeight": "%d"}}`, h)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestProbeBlockHeight(t *testing.T) {
+	srv := newStatisticsServer(t, true)
+
+	err := probes.Probe(context.Background(), "node-1", types.ProbesConfig{
+		BlockHeight:      &types.BlockHeightProbe{URL: srv.URL},
+		Interval:         utils.ToPoint("10ms"),
+		Timeout:          utils.ToPoint("2s"),
+		SuccessThreshold: utils.ToPoint(3),
+	})
+	assert.NoError(t, err)
+}
+
+func TestProbeBlockHeightNotAdvancing(t *testing.T) {
+	srv := newStatisticsServer(t, false)
+
+	err := probes.Probe(context.Background(), "node-1", types.ProbesConfig{
+		BlockHeight: &types.BlockHeightProbe{URL: srv.URL},
+		Interval:    utils.ToPoint("10ms"),
+		Timeout:     utils.ToPoint("200ms"),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "context deadline exceeded")
+}
+
+func TestProbeInvalidSettings(t *testing.T) {
+	err := probes.Probe(context.Background(), "node-1", types.ProbesConfig{
+		Interval: utils.ToPoint("often"),
+	})
+	assert.Error(t, err)
+}
+
+func TestProbeExec(t *testing.T) {
+	testCases := []struct {
+		name        string
+		args        []string
+		stdoutRegex *string
+		err         string
+	}{
+		{name: "exit code", args: []string{"-c", "echo ready"}},
+		{name: "stdout regex", args: []string{"-c", "echo ready"}, stdoutRegex: utils.ToPoint("^rea")},
+		{name: "stdout regex not matching", args: []string{"-c", "echo starting"}, stdoutRegex: utils.ToPoint("^rea"), err: "does not match"},
+		{name: "non zero exit code", args: []string{"-c", "exit 1"}, err: "exit status 1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := probes.Probe(context.Background(), "exec", types.ProbesConfig{
+				Exec:     &types.ExecProbe{Cmd: "sh", Args: tc.args, StdoutRegex: tc.stdoutRegex},
+				Interval: utils.ToPoint("10ms"),
+				Timeout:  utils.ToPoint("200ms"),
+			})
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func newEthereumRPCServer(t *testing.T, chainID int, code string) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		var result string
+		switch req.Method {
+		case "eth_chainId":
+			result = fmt.Sprintf("0x%x", chainID)
+		case "eth_getCode":
+			result = code
+		default:
+			t.Errorf("unexpected method %q", req.Method)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"jsonrpc": "2.0", "id": %s, "result": %q}`, req.ID, result)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestProbeEthereumRPC(t *testing.T) {
+	contract := "0xaE15d2c3a2b3b3c1aBb79f6A2F0F5F5f6a7A4B22"
+
+	testCases := []struct {
+		name            string
+		serverChainID   int
+		serverCode      string
+		contractAddress *string
+		err             string
+	}{
+		{name: "chain ID", serverChainID: 1440},
+		{name: "contract deployed", serverChainID: 1440, serverCode: "0x6080", contractAddress: &contract},
+		{name: "chain ID not matching", serverChainID: 1, err: "chain ID 1 does not match expected 1440"},
+		{name: "contract not deployed", serverChainID: 1440, serverCode: "0x", contractAddress: &contract, err: "is not deployed"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := newEthereumRPCServer(t, tc.serverChainID, tc.serverCode)
+
+			err := probes.Probe(context.Background(), "ethereum", types.ProbesConfig{
+				EthereumRPC: &types.EthereumRPCProbe{URL: srv.URL, ChainID: 1440, ContractAddress: tc.contractAddress},
+				Interval:    utils.ToPoint("10ms"),
+				Timeout:     utils.ToPoint("200ms"),
+			})
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
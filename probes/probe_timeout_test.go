@@ -0,0 +1,80 @@
+package probes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"code.vegaprotocol.io/vegacapsule/types"
+	"code.vegaprotocol.io/vegacapsule/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDelayedStatisticsServer returns server whose block height starts advancing after the delay.
+func newDelayedStatisticsServer(t *testing.T, delay time.Duration) *httptest.Server {
+	start := time.Now()
+	var height atomic.Int64
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := height.Load()
+		if time.Since(start) > delay {
+			h = height.Add(1)
+		}
+
+		fmt.Fprintf(w, `{"statistics": {"blockHeight": "%d"}}`, h)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestProbeDefaultTimeout(t *testing.T) {
+	prevTimeout := totalProbeTimeout
+	totalProbeTimeout = 100 * time.Millisecond
+	t.Cleanup(func() { totalProbeTimeout = prevTimeout })
+
+	t.Run("applies without deadline of the caller", func(t *testing.T) {
+		srv := newDelayedStatisticsServer(t, time.Second)
+
+		err := Probe(context.Background(), "node-1", types.ProbesConfig{
+			BlockHeight: &types.BlockHeightProbe{URL: srv.URL},
+			Interval:    utils.ToPoint("10ms"),
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "context deadline exceeded")
+	})
+
+	t.Run("is replaced by deadline of the caller", func(t *testing.T) {
+		srv := newDelayedStatisticsServer(t, 300*time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		err := Probe(ctx, "node-1", types.ProbesConfig{
+			BlockHeight: &types.BlockHeightProbe{URL: srv.URL},
+			Interval:    utils.ToPoint("10ms"),
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("explicit timeout applies within deadline of the caller", func(t *testing.T) {
+		srv := newDelayedStatisticsServer(t, time.Second)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		err := Probe(ctx, "node-1", types.ProbesConfig{
+			BlockHeight: &types.BlockHeightProbe{URL: srv.URL},
+			Interval:    utils.ToPoint("10ms"),
+			Timeout:     utils.ToPoint("100ms"),
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "context deadline exceeded")
+	})
+}
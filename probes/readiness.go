@@ -0,0 +1,127 @@
+package probes
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/ports"
+	"code.vegaprotocol.io/vegacapsule/types"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ReadinessCheck represents readiness probes of a job of the network.
+type ReadinessCheck struct {
+	JobID  string
+	Probes types.ProbesConfig
+}
+
+// ReadinessChecks returns readiness probes of all jobs of the network.
+// Node sets without readiness probes wait for block height reported by the Vega node REST API to advance.
+func ReadinessChecks(conf *config.Config, genServices *types.GeneratedServices) []ReadinessCheck {
+	checks := []ReadinessCheck{}
+
+	for _, ns := range genServices.NodeSets.ToSlice() {
+		if ns.ReadinessProbe != nil {
+			checks = append(checks, ReadinessCheck{JobID: ns.Name, Probes: *ns.ReadinessProbe})
+			continue
+		}
+
+		if check := defaultNodeSetCheck(ns); check != nil {
+			checks = append(checks, *check)
+		}
+	}
+
+	stage := config.StartupStage{Wallet: true, Faucet: true}
+	for _, sc := range []*config.PStartConfig{conf.Network.PreStart, conf.Network.PostStart} {
+		if sc == nil {
+			continue
+		}
+		stage.Docker = append(stage.Docker, sc.Docker...)
+		stage.Exec = append(stage.Exec, sc.Exec...)
+	}
+
+	return append(checks, servicesChecks(conf, genServices, stage)...)
+}
+
+// StageReadinessChecks returns readiness probes defined for the jobs of the startup stage.
+func StageReadinessChecks(conf *config.Config, genServices *types.GeneratedServices, stage config.StartupStage) []ReadinessCheck {
+	checks := []ReadinessCheck{}
+
+	for _, ns := range stage.NodeSets {
+		if ns.ReadinessProbe != nil {
+			checks = append(checks, ReadinessCheck{JobID: ns.Name, Probes: *ns.ReadinessProbe})
+		}
+	}
+
+	return append(checks, servicesChecks(conf, genServices, stage)...)
+}
+
+func servicesChecks(conf *config.Config, genServices *types.GeneratedServices, stage config.StartupStage) []ReadinessCheck {
+	checks := []ReadinessCheck{}
+
+	if wc := conf.Network.Wallet; stage.Wallet && wc != nil && wc.ReadinessProbe != nil && genServices.Wallet != nil {
+		checks = append(checks, ReadinessCheck{JobID: genServices.Wallet.Name, Probes: *wc.ReadinessProbe})
+	}
+
+	if fc := conf.Network.Faucet; stage.Faucet && fc != nil && fc.ReadinessProbe != nil && genServices.Faucet != nil {
+		checks = append(checks, ReadinessCheck{JobID: genServices.Faucet.Name, Probes: *fc.ReadinessProbe})
+	}
+
+	for _, dc := range stage.Docker {
+		if dc.ReadinessProbe != nil {
+			checks = append(checks, ReadinessCheck{JobID: dc.Name, Probes: *dc.ReadinessProbe})
+		}
+	}
+
+	for _, ec := range stage.Exec {
+		if ec.ReadinessProbe != nil {
+			checks = append(checks, ReadinessCheck{JobID: ec.Name, Probes: *ec.ReadinessProbe})
+		}
+	}
+
+	return checks
+}
+
+func defaultNodeSetCheck(ns types.NodeSet) *ReadinessCheck {
+	configPorts, err := ports.ExtractPortsFromConfig(ns.Vega.ConfigFilePath)
+	if err != nil {
+		log.Printf("failed to find REST API port of node set %q, its readiness is not checked: %s", ns.Name, err)
+		return nil
+	}
+
+	for port, name := range configPorts {
+		if name == ports.VegaRESTPortName {
+			return &ReadinessCheck{
+				JobID: ns.Name,
+				Probes: types.ProbesConfig{
+					BlockHeight: &types.BlockHeightProbe{URL: fmt.Sprintf("http://localhost:%d", port)},
+				},
+			}
+		}
+	}
+
+	log.Printf("node set %q has no REST API port, its readiness is not checked", ns.Name)
+
+	return nil
+}
+
+// WaitReady waits until readiness probes of all the checks pass.
+func WaitReady(ctx context.Context, checks []ReadinessCheck) error {
+	eg, ctx := errgroup.WithContext(ctx)
+
+	for _, c := range checks {
+		c := c
+		eg.Go(func() error {
+			return Probe(ctx, c.JobID, c.Probes)
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		return fmt.Errorf("network is not ready: %w", err)
+	}
+
+	return nil
+}
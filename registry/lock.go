@@ -0,0 +1,33 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"code.vegaprotocol.io/vegacapsule/utils"
+)
+
+const (
+	lockFileName = registryFileName + ".lock"
+	lockTimeout  = time.Second * 30
+)
+
+var ErrLockTimeout = errors.New("timed out waiting for network registry lock")
+
+// lockRegistry acquires advisory lock of the registry shared by all Capsule processes.
+// A separate lock file is used, because the registry file is replaced on every persist.
+func lockRegistry(capsuleHome string) (*utils.FileLock, error) {
+	lockPath := filepath.Join(capsuleHome, lockFileName)
+
+	fileLock, err := utils.LockFile(lockPath, lockTimeout)
+	if errors.Is(err, utils.ErrFileLocked) {
+		return nil, fmt.Errorf("network registry %q is being modified by another Capsule command: %w", lockPath, ErrLockTimeout)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot lock network registry: %w", err)
+	}
+
+	return fileLock, nil
+}
@@ -0,0 +1,226 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"code.vegaprotocol.io/vegacapsule/utils"
+)
+
+const registryFileName = "networks.json"
+
+// Network represents a network registered in Capsule home.
+type Network struct {
+	Name     string
+	HomePath string
+	// JobPrefixes are prefixes of Nomad jobs IDs that belong to the network.
+	JobPrefixes []string
+}
+
+// NetworkRegistry keeps track of all named networks managed side by side under one Capsule home.
+type NetworkRegistry struct {
+	// Current is the name of the network used when neither network name nor home path is provided.
+	Current  string
+	Networks map[string]Network
+
+	path string
+}
+
+// Load loads the registry from given Capsule home. Empty registry is returned when it does not exist yet.
+func Load(capsuleHome string) (*NetworkRegistry, error) {
+	r := &NetworkRegistry{
+		Networks: map[string]Network{},
+		path:     filepath.Join(capsuleHome, registryFileName),
+	}
+
+	exists, err := utils.FileExists(r.path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot check network registry: %w", err)
+	}
+
+	if !exists {
+		return r, nil
+	}
+
+	b, err := os.ReadFile(r.path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read network registry: %w", err)
+	}
+
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, fmt.Errorf("cannot decode network registry %q: %w", r.path, err)
+	}
+
+	if r.Networks == nil {
+		r.Networks = map[string]Network{}
+	}
+
+	return r, nil
+}
+
+// LoadDefault loads the registry from default Capsule home.
+func LoadDefault() (*NetworkRegistry, error) {
+	capsuleHome, err := utils.CapsuleHome()
+	if err != nil {
+		return nil, err
+	}
+
+	return Load(capsuleHome)
+}
+
+// Update loads the registry from given Capsule home, modifies it and persists it. The registry is locked
+// during the whole update, so concurrent Capsule commands can't overwrite each other's changes.
+func Update(capsuleHome string, modify func(r *NetworkRegistry) error) (err error) {
+	l, err := lockRegistry(capsuleHome)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if unlockErr := l.Unlock(); err == nil {
+			err = unlockErr
+		}
+	}()
+
+	r, err := Load(capsuleHome)
+	if err != nil {
+		return err
+	}
+
+	if err := modify(r); err != nil {
+		return err
+	}
+
+	return r.Persist()
+}
+
+// UpdateDefault updates the registry in default Capsule home, see Update.
+func UpdateDefault(modify func(r *NetworkRegistry) error) error {
+	capsuleHome, err := utils.CapsuleHome()
+	if err != nil {
+		return err
+	}
+
+	return Update(capsuleHome, modify)
+}
+
+// Persist writes the registry. Use Update to modify the registry safely when other Capsule commands may run.
+func (r NetworkRegistry) Persist() error {
+	b, err := json.MarshalIndent(r, "", "\t")
+	if err != nil {
+		return fmt.Errorf("cannot encode network registry: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("cannot create directory for network registry: %w", err)
+	}
+
+	if err := utils.WriteFileAtomic(r.path, b, 0o644); err != nil {
+		return fmt.Errorf("cannot persist network registry: %w", err)
+	}
+
+	return nil
+}
+
+func (r NetworkRegistry) Get(name string) (*Network, error) {
+	n, ok := r.Networks[name]
+	if !ok {
+		return nil, fmt.Errorf("network %q is not registered, use 'network list' to see all registered networks", name)
+	}
+
+	return &n, nil
+}
+
+// GetByHomePath returns network registered with given home path or nil if there is none.
+func (r NetworkRegistry) GetByHomePath(homePath string) *Network {
+	for _, n := range r.Networks {
+		if filepath.Clean(n.HomePath) == filepath.Clean(homePath) {
+			return &n
+		}
+	}
+
+	return nil
+}
+
+// Register adds or updates the network. It fails when the name is already used by network in other
+// home directory or when any of the job prefixes collides with jobs of other network.
+func (r *NetworkRegistry) Register(n Network) error {
+	if existing, ok := r.Networks[n.Name]; ok && filepath.Clean(existing.HomePath) != filepath.Clean(n.HomePath) {
+		return fmt.Errorf("network %q is already registered with home path %q", n.Name, existing.HomePath)
+	}
+
+	for _, other := range r.Networks {
+		if other.Name == n.Name || filepath.Clean(other.HomePath) == filepath.Clean(n.HomePath) {
+			continue
+		}
+
+		for _, prefix := range n.JobPrefixes {
+			for _, otherPrefix := range other.JobPrefixes {
+				if strings.HasPrefix(prefix, otherPrefix) || strings.HasPrefix(otherPrefix, prefix) {
+					return fmt.Errorf(
+						"Nomad job prefix %q collides with prefix %q of network %q, please use different network or service names",
+						prefix, otherPrefix, other.Name,
+					)
+				}
+			}
+		}
+	}
+
+	// network could have been registered under different name before
+	if previous := r.GetByHomePath(n.HomePath); previous != nil && previous.Name != n.Name {
+		r.Unregister(previous.Name)
+	}
+
+	r.Networks[n.Name] = n
+
+	return nil
+}
+
+func (r *NetworkRegistry) Unregister(name string) {
+	delete(r.Networks, name)
+
+	if r.Current == name {
+		r.Current = ""
+	}
+}
+
+func (r *NetworkRegistry) Use(name string) error {
+	if _, err := r.Get(name); err != nil {
+		return err
+	}
+
+	r.Current = name
+
+	return nil
+}
+
+// OtherNetworksJobPrefixes returns job prefixes of all networks except the one in given home path.
+func (r NetworkRegistry) OtherNetworksJobPrefixes(homePath string) []string {
+	prefixes := []string{}
+	for _, n := range r.Networks {
+		if filepath.Clean(n.HomePath) == filepath.Clean(homePath) {
+			continue
+		}
+
+		prefixes = append(prefixes, n.JobPrefixes...)
+	}
+
+	return prefixes
+}
+
+// List returns all registered networks sorted by name.
+func (r NetworkRegistry) List() []Network {
+	out := make([]Network, 0, len(r.Networks))
+	for _, n := range r.Networks {
+		out = append(out, n)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name < out[j].Name
+	})
+
+	return out
+}
@@ -0,0 +1,70 @@
+package registry_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"code.vegaprotocol.io/vegacapsule/registry"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkRegistry(t *testing.T) {
+	capsuleHome := t.TempDir()
+
+	reg, err := registry.Load(capsuleHome)
+	require.NoError(t, err)
+	assert.Empty(t, reg.List())
+
+	require.NoError(t, reg.Register(registry.Network{Name: "old", HomePath: "/tmp/old", JobPrefixes: []string{"old-", "postgres"}}))
+	require.NoError(t, reg.Register(registry.Network{Name: "new", HomePath: "/tmp/new", JobPrefixes: []string{"new-"}}))
+
+	// same name with different home
+	assert.Error(t, reg.Register(registry.Network{Name: "old", HomePath: "/tmp/other", JobPrefixes: []string{"other-"}}))
+	// colliding jobs
+	assert.Error(t, reg.Register(registry.Network{Name: "other", HomePath: "/tmp/other", JobPrefixes: []string{"other-", "postgres"}}))
+
+	require.NoError(t, reg.Use("new"))
+	assert.Error(t, reg.Use("unknown"))
+	require.NoError(t, reg.Persist())
+
+	loaded, err := registry.Load(capsuleHome)
+	require.NoError(t, err)
+	assert.Equal(t, "new", loaded.Current)
+	assert.Equal(t, []string{"new", "old"}, []string{loaded.List()[0].Name, loaded.List()[1].Name})
+	assert.ElementsMatch(t, []string{"old-", "postgres"}, loaded.OtherNetworksJobPrefixes("/tmp/new/"))
+
+	n, err := loaded.Get("old")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"old-", "postgres"}, n.JobPrefixes)
+
+	loaded.Unregister("new")
+	assert.Empty(t, loaded.Current)
+	assert.Nil(t, loaded.GetByHomePath("/tmp/new"))
+}
+
+func TestConcurrentUpdates(t *testing.T) {
+	capsuleHome := t.TempDir()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := registry.Update(capsuleHome, func(r *registry.NetworkRegistry) error {
+				name := fmt.Sprintf("net-%d", i)
+				return r.Register(registry.Network{Name: name, HomePath: "/tmp/" + name, JobPrefixes: []string{name + "-"}})
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	reg, err := registry.Load(capsuleHome)
+	require.NoError(t, err)
+	assert.Len(t, reg.List(), 20)
+}
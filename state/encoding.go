@@ -1,31 +1,140 @@
 package state
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 )
 
+// stateEnvelope is the on-disk representation of the network state.
+// The Version field allows to migrate older states when the persisted structures change.
+type stateEnvelope struct {
+	Version    int               `json:"version"`
+	Encryption *encryptionHeader `json:"encryption,omitempty"`
+	State      json.RawMessage   `json:"state"`
+}
+
 func encodeState(state NetworkState) ([]byte, error) {
 	stateBytes, err := json.Marshal(state)
 	if err != nil {
 		return nil, fmt.Errorf("cannot convert network state structure into string: %w", err)
 	}
 
-	networkState := make([]byte, hex.EncodedLen(len(stateBytes)))
-	hex.Encode(networkState, stateBytes)
+	envelope := stateEnvelope{
+		Version: CurrentVersion(),
+		State:   stateBytes,
+	}
+
+	if state.encryption != nil {
+		rawState, err := rawStateFromJSON(stateBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := state.encryption.encryptSecrets(rawState); err != nil {
+			return nil, fmt.Errorf("cannot encrypt network state secrets: %w", err)
+		}
+
+		envelope.Encryption = &state.encryption.header
+		envelope.State, err = json.Marshal(rawState)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert network state structure into string: %w", err)
+		}
+	}
+
+	networkState, err := json.MarshalIndent(envelope, "", "\t")
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode network state envelope: %w", err)
+	}
 
 	return networkState, nil
 }
 
-func decodeState(data []byte) (*NetworkState, error) {
+// decodeState decodes and migrates the network state. Secrets of encrypted state
+// are decrypted only when the passphrase is provided.
+func decodeState(data []byte, passphrase []byte) (*NetworkState, error) {
 	if data == nil {
 		return &NetworkState{}, nil
 	}
 
+	version, header, rawState, err := decodeRawState(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := migrateState(version, rawState); err != nil {
+		return nil, err
+	}
+
+	var encryption *stateEncryption
+	if header != nil {
+		encryption, err = openStateEncryption(*header, passphrase)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := encryption.decryptSecrets(rawState); err != nil {
+			return nil, fmt.Errorf("cannot decrypt network state secrets: %w", err)
+		}
+	}
+
+	networkState, err := networkStateFromRaw(rawState)
+	if err != nil {
+		return nil, err
+	}
+	networkState.encryption = encryption
+
+	return networkState, nil
+}
+
+// decodeRawState returns version, encryption header and generic representation of the persisted state.
+// States persisted before the versioned envelope was introduced are hex encoded JSON
+// and are reported as version 0.
+func decodeRawState(data []byte) (int, *encryptionHeader, map[string]interface{}, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		envelope := stateEnvelope{}
+		if err := json.Unmarshal(trimmed, &envelope); err != nil {
+			return 0, nil, nil, fmt.Errorf("cannot decode network state envelope: %w", err)
+		}
+
+		rawState, err := rawStateFromJSON(envelope.State)
+		if err != nil {
+			return 0, nil, nil, err
+		}
+
+		return envelope.Version, envelope.Encryption, rawState, nil
+	}
+
 	stateBytes := make([]byte, hex.DecodedLen(len(data)))
 	if _, err := hex.Decode(stateBytes, data); err != nil {
-		return nil, fmt.Errorf("cannot decode network state: %w", err)
+		return 0, nil, nil, fmt.Errorf("cannot decode network state: %w", err)
+	}
+
+	rawState, err := rawStateFromJSON(stateBytes)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	return 0, nil, rawState, nil
+}
+
+func rawStateFromJSON(stateBytes []byte) (map[string]interface{}, error) {
+	rawState := map[string]interface{}{}
+
+	dec := json.NewDecoder(bytes.NewReader(stateBytes))
+	dec.UseNumber()
+	if err := dec.Decode(&rawState); err != nil {
+		return nil, fmt.Errorf("cannot decode network state from given data: %w", err)
+	}
+
+	return rawState, nil
+}
+
+func networkStateFromRaw(rawState map[string]interface{}) (*NetworkState, error) {
+	stateBytes, err := json.Marshal(rawState)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode migrated network state: %w", err)
 	}
 
 	networkState := &NetworkState{}
@@ -0,0 +1,271 @@
+package state
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// PassphraseEnv is an environment variable that can hold passphrase for encrypted network state.
+	PassphraseEnv = "VEGACAPSULE_STATE_PASSPHRASE"
+
+	encryptedValuePrefix = "encrypted:"
+	encryptionCipher     = "aes-256-gcm"
+	encryptionKDF        = "scrypt"
+	keyCheckValue        = "vegacapsule"
+)
+
+var (
+	ErrInvalidPassphrase  = errors.New("invalid network state passphrase")
+	ErrPassphraseRequired = errors.New("network state is encrypted, passphrase is required")
+)
+
+// secretPaths are paths of the secret fields in generic representation of the network state.
+// Wildcard "*" matches any key of a map or any item of a list.
+// Only secrets are encrypted, so the rest of the state can be read without the passphrase.
+var secretPaths = [][]string{
+	{"GeneratedServices", "NodeSets", "*", "Vega", "NodeWalletInfo", "EthereumPrivateKey"},
+	{"GeneratedServices", "NodeSets", "*", "Vega", "NodeWalletInfo", "VegaWalletRecoveryPhrase"},
+	{"Config", "Network", "Nodes", "*", "NodeWalletPass"},
+	{"Config", "Network", "Nodes", "*", "EthereumWalletPass"},
+	{"Config", "Network", "Nodes", "*", "VegaWalletPass"},
+	{"Config", "Network", "Faucet", "Pass"},
+	{"Config", "Seed"},
+}
+
+type encryptionHeader struct {
+	Cipher string `json:"cipher"`
+	KDF    string `json:"kdf"`
+	Salt   string `json:"salt"`
+	// KeyCheck is an encrypted known value used to verify the passphrase.
+	KeyCheck string `json:"key_check"`
+}
+
+type stateEncryption struct {
+	header encryptionHeader
+	// key is nil when the state has been loaded without passphrase.
+	key []byte
+}
+
+func newStateEncryption(passphrase []byte) (*stateEncryption, error) {
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key, err := deriveKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	keyCheck, err := encryptValue(key, keyCheckValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stateEncryption{
+		header: encryptionHeader{
+			Cipher:   encryptionCipher,
+			KDF:      encryptionKDF,
+			Salt:     base64.StdEncoding.EncodeToString(salt),
+			KeyCheck: keyCheck,
+		},
+		key: key,
+	}, nil
+}
+
+func openStateEncryption(header encryptionHeader, passphrase []byte) (*stateEncryption, error) {
+	if header.Cipher != encryptionCipher || header.KDF != encryptionKDF {
+		return nil, fmt.Errorf("unsupported network state encryption %s/%s", header.Cipher, header.KDF)
+	}
+
+	se := &stateEncryption{header: header}
+	if passphrase == nil {
+		return se, nil
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(header.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode network state salt: %w", err)
+	}
+
+	key, err := deriveKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	if check, err := decryptValue(key, header.KeyCheck); err != nil || check != keyCheckValue {
+		return nil, ErrInvalidPassphrase
+	}
+
+	se.key = key
+
+	return se, nil
+}
+
+func (se stateEncryption) encryptSecrets(rawState map[string]interface{}) error {
+	return transformSecrets(rawState, func(value string) (string, error) {
+		if isEncryptedValue(value) {
+			return value, nil
+		}
+
+		if se.key == nil {
+			return "", fmt.Errorf("network state is encrypted, the passphrase has to be provided to modify secrets: %w", ErrInvalidPassphrase)
+		}
+
+		return encryptValue(se.key, value)
+	})
+}
+
+func (se stateEncryption) decryptSecrets(rawState map[string]interface{}) error {
+	if se.key == nil {
+		return nil
+	}
+
+	return transformSecrets(rawState, func(value string) (string, error) {
+		if !isEncryptedValue(value) {
+			return value, nil
+		}
+
+		return decryptValue(se.key, value)
+	})
+}
+
+func transformSecrets(rawState map[string]interface{}, transform func(value string) (string, error)) error {
+	for _, path := range secretPaths {
+		if err := transformPath(rawState, path, transform); err != nil {
+			return fmt.Errorf("failed to process secret %s: %w", strings.Join(path, "."), err)
+		}
+	}
+
+	return nil
+}
+
+func transformPath(node interface{}, path []string, transform func(value string) (string, error)) error {
+	if len(path) == 0 {
+		return nil
+	}
+
+	key, rest := path[0], path[1:]
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		keys := []string{key}
+		if key == "*" {
+			keys = make([]string, 0, len(n))
+			for k := range n {
+				keys = append(keys, k)
+			}
+		}
+
+		for _, k := range keys {
+			child, ok := n[k]
+			if !ok || child == nil {
+				continue
+			}
+
+			if len(rest) > 0 {
+				if err := transformPath(child, rest, transform); err != nil {
+					return err
+				}
+				continue
+			}
+
+			value, ok := child.(string)
+			if !ok || value == "" {
+				continue
+			}
+
+			newValue, err := transform(value)
+			if err != nil {
+				return err
+			}
+			n[k] = newValue
+		}
+	case []interface{}:
+		if key != "*" {
+			return nil
+		}
+
+		for _, child := range n {
+			if err := transformPath(child, rest, transform); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func isEncryptedValue(value string) bool {
+	return strings.HasPrefix(value, encryptedValuePrefix)
+}
+
+func deriveKey(passphrase, salt []byte) ([]byte, error) {
+	key, err := scrypt.Key(passphrase, salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive network state key: %w", err)
+	}
+
+	return key, nil
+}
+
+func encryptValue(key []byte, value string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(value), nil)
+
+	return encryptedValuePrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func decryptValue(key []byte, value string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedValuePrefix))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode encrypted value: %w", err)
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return "", fmt.Errorf("failed to decrypt value: ciphertext too short")
+	}
+
+	plain, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value: %w", err)
+	}
+
+	return string(plain), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM cipher: %w", err)
+	}
+
+	return gcm, nil
+}
@@ -0,0 +1,53 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"code.vegaprotocol.io/vegacapsule/utils"
+)
+
+var ErrLockTimeout = errors.New("timed out waiting for network state lock")
+
+// Lock is an advisory lock of the network state shared by all Capsule processes.
+type Lock struct {
+	fileLock *utils.FileLock
+}
+
+// LockNetworkState acquires exclusive lock of the network state in given network directory.
+// It should be held across the whole load -> mutate -> persist cycle so concurrent
+// Capsule commands can't overwrite each other's changes.
+func LockNetworkState(networkDir string, timeout time.Duration) (*Lock, error) {
+	fileLock, err := utils.LockFile(lockFilePath(networkDir), timeout)
+	if errors.Is(err, utils.ErrFileLocked) {
+		return nil, fmt.Errorf(
+			"network in %q is being modified by another Capsule command, try again later or increase the lock timeout: %w",
+			networkDir, ErrLockTimeout,
+		)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock network state: %w", err)
+	}
+
+	return &Lock{fileLock: fileLock}, nil
+}
+
+func (l *Lock) Unlock() error {
+	if l == nil {
+		return nil
+	}
+
+	if err := l.fileLock.Unlock(); err != nil {
+		return fmt.Errorf("failed to unlock network state: %w", err)
+	}
+
+	return nil
+}
+
+// lockFilePath returns path of the lock file next to the network directory,
+// because the network directory itself can be removed and re-created while the lock is held.
+func lockFilePath(networkDir string) string {
+	return filepath.Clean(networkDir) + ".lock"
+}
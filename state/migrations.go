@@ -0,0 +1,176 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+)
+
+// Migration upgrades generic representation of the network state by one version.
+type Migration struct {
+	// Description is a short human readable summary of the migration.
+	Description string
+	// Migrate updates the state in place.
+	Migrate func(state map[string]interface{}) error
+}
+
+// migrations is an ordered registry of forward migrations where the migration
+// at index N upgrades the state from version N to version N+1.
+// Append a new migration every time a change in the persisted structures
+// (NetworkState, config.Config, types.GeneratedServices, ...) requires existing states to be rewritten.
+var migrations = []Migration{
+	{
+		Description: "wrap legacy hex encoded state into versioned envelope",
+		Migrate:     func(state map[string]interface{}) error { return nil },
+	},
+}
+
+// CurrentVersion returns the version of the network state format written by this Capsule.
+func CurrentVersion() int {
+	return len(migrations)
+}
+
+// MigrationStep is a migration that upgrades the state from FromVersion to FromVersion+1.
+type MigrationStep struct {
+	FromVersion int
+	Description string
+}
+
+type MigrationResult struct {
+	FromVersion int
+	ToVersion   int
+	Steps       []MigrationStep
+	// Changes lists paths of the state that were added (+), removed (-) or changed (~) by migrations.
+	Changes []string
+}
+
+func (mr MigrationResult) UpToDate() bool {
+	return len(mr.Steps) == 0
+}
+
+func migrateState(version int, state map[string]interface{}) ([]MigrationStep, error) {
+	if version > CurrentVersion() {
+		return nil, fmt.Errorf(
+			"network state version %d is newer than the latest supported version %d, please upgrade Capsule",
+			version, CurrentVersion(),
+		)
+	}
+
+	steps := make([]MigrationStep, 0, CurrentVersion()-version)
+	for v := version; v < CurrentVersion(); v++ {
+		m := migrations[v]
+
+		if err := m.Migrate(state); err != nil {
+			return nil, fmt.Errorf("failed to migrate network state from version %d to %d: %w", v, v+1, err)
+		}
+
+		steps = append(steps, MigrationStep{FromVersion: v, Description: m.Description})
+	}
+
+	return steps, nil
+}
+
+// MigrateNetworkState upgrades network state stored in networkDir to the latest version.
+// With dryRun the migrated state is not persisted and the result only reports what would change.
+func MigrateNetworkState(networkDir string, dryRun bool) (*MigrationResult, error) {
+	networkBytes, err := os.ReadFile(stateFilePath(networkDir))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read network state: %w", err)
+	}
+
+	version, header, rawState, err := decodeRawState(networkBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// decode the state one more time to keep an untouched copy for diffing
+	_, _, originalState, err := decodeRawState(networkBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	steps, err := migrateState(version, rawState)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MigrationResult{
+		FromVersion: version,
+		ToVersion:   CurrentVersion(),
+		Steps:       steps,
+		Changes:     diffRawState("", originalState, rawState),
+	}
+
+	if dryRun || result.UpToDate() {
+		return result, nil
+	}
+
+	netState, err := networkStateFromRaw(rawState)
+	if err != nil {
+		return nil, err
+	}
+
+	if netState.Config != nil {
+		netState.Config.OutputDir = &networkDir
+	}
+
+	// secrets stay encrypted as they are, there is no need for the passphrase
+	if header != nil {
+		netState.encryption = &stateEncryption{header: *header}
+	}
+
+	if err := netState.persist(networkDir); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func diffRawState(path string, before, after interface{}) []string {
+	beforeMap, beforeIsMap := before.(map[string]interface{})
+	afterMap, afterIsMap := after.(map[string]interface{})
+
+	if !beforeIsMap || !afterIsMap {
+		if reflect.DeepEqual(before, after) {
+			return nil
+		}
+		return []string{fmt.Sprintf("~ %s", path)}
+	}
+
+	keys := map[string]struct{}{}
+	for k := range beforeMap {
+		keys[k] = struct{}{}
+	}
+	for k := range afterMap {
+		keys[k] = struct{}{}
+	}
+
+	sortedKeys := make([]string, 0, len(keys))
+	for k := range keys {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Strings(sortedKeys)
+
+	var changes []string
+	for _, k := range sortedKeys {
+		keyPath := k
+		if path != "" {
+			keyPath = path + "." + k
+		}
+
+		b, inBefore := beforeMap[k]
+		a, inAfter := afterMap[k]
+
+		switch {
+		case !inBefore:
+			changes = append(changes, fmt.Sprintf("+ %s", keyPath))
+		case !inAfter:
+			changes = append(changes, fmt.Sprintf("- %s", keyPath))
+		default:
+			changes = append(changes, diffRawState(keyPath, b, a)...)
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,179 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redactedValue = "<redacted>"
+
+// ToRaw returns generic representation of the network state with the same structure as it is persisted.
+// Secrets are replaced with placeholder when redact is true.
+func (ns NetworkState) ToRaw(redact bool) (map[string]interface{}, error) {
+	stateBytes, err := json.Marshal(ns)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert network state structure into string: %w", err)
+	}
+
+	// numbers are kept as json.Number, so large integers don't lose precision as float64
+	rawState, err := rawStateFromJSON(stateBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if redact {
+		if err := transformSecrets(rawState, func(string) (string, error) { return redactedValue, nil }); err != nil {
+			return nil, err
+		}
+	}
+
+	return rawState, nil
+}
+
+// Query returns part of generic network state selected by JSONPath-like selector.
+//
+// Selector is a dot separated list of keys, optionally prefixed with `$.`. List items are selected by index
+// either as a key (`nodes.0`) or in brackets (`nodes[0]`). Keys are matched case insensitively
+// and underscores are ignored, so `home_dir` matches `HomeDir`.
+// Generated services can be selected directly without the `generated_services` prefix,
+// e.g. `nodesets.testnet-nodeset-validators-0-validator.vega.home_dir`.
+func Query(rawState map[string]interface{}, selector string) (interface{}, error) {
+	segments, err := parseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(segments) == 0 {
+		return rawState, nil
+	}
+
+	if _, ok := lookupKey(rawState, segments[0]); !ok {
+		if genServices, ok := lookupKey(rawState, "GeneratedServices"); ok {
+			if genServicesMap, ok := genServices.(map[string]interface{}); ok {
+				if _, ok := lookupKey(genServicesMap, segments[0]); ok {
+					return query(genServices, segments, "generated_services")
+				}
+			}
+		}
+	}
+
+	return query(rawState, segments, "$")
+}
+
+// MarshalYAML encodes generic network state or its part as YAML. Numbers kept as json.Number
+// are written as YAML numbers, yaml.Marshal would write them as quoted strings.
+func MarshalYAML(v interface{}) ([]byte, error) {
+	return yaml.Marshal(toYAMLValue(v))
+}
+
+func toYAMLValue(node interface{}) interface{} {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(n))
+		for k, v := range n {
+			out[k] = toYAMLValue(v)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(n))
+		for i, v := range n {
+			out[i] = toYAMLValue(v)
+		}
+		return out
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(n.String(), ".eE") {
+			tag = "!!float"
+		}
+		// the number is written as it is, so large integers don't lose precision
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: n.String()}
+	default:
+		return n
+	}
+}
+
+func query(node interface{}, segments []string, path string) (interface{}, error) {
+	for _, segment := range segments {
+		switch n := node.(type) {
+		case map[string]interface{}:
+			child, ok := lookupKey(n, segment)
+			if !ok {
+				return nil, fmt.Errorf("key %q not found in %q, available keys: %s", segment, path, strings.Join(sortedKeys(n), ", "))
+			}
+			node = child
+		case []interface{}:
+			idx, err := strconv.Atoi(segment)
+			if err != nil {
+				return nil, fmt.Errorf("%q is a list and must be indexed by a number, got %q", path, segment)
+			}
+
+			if idx < 0 || idx >= len(n) {
+				return nil, fmt.Errorf("index %d out of range of %q with length %d", idx, path, len(n))
+			}
+			node = n[idx]
+		default:
+			return nil, fmt.Errorf("cannot select %q from %q: value is not an object or a list", segment, path)
+		}
+
+		path = path + "." + segment
+	}
+
+	return node, nil
+}
+
+func lookupKey(m map[string]interface{}, key string) (interface{}, bool) {
+	if v, ok := m[key]; ok {
+		return v, true
+	}
+
+	normalizedKey := normalizeKey(key)
+	for k, v := range m {
+		if normalizeKey(k) == normalizedKey {
+			return v, true
+		}
+	}
+
+	return nil, false
+}
+
+func normalizeKey(key string) string {
+	return strings.ToLower(strings.ReplaceAll(key, "_", ""))
+}
+
+func parseSelector(selector string) ([]string, error) {
+	selector = strings.TrimSpace(selector)
+	selector = strings.TrimPrefix(selector, "$")
+	selector = strings.TrimPrefix(selector, ".")
+
+	if selector == "" {
+		return nil, nil
+	}
+
+	// convert bracket indexes to dot notation: nodes[0] -> nodes.0
+	selector = strings.ReplaceAll(selector, "]", "")
+	selector = strings.ReplaceAll(selector, "[", ".")
+
+	segments := strings.Split(selector, ".")
+	for _, s := range segments {
+		if s == "" {
+			return nil, fmt.Errorf("invalid selector %q: empty key", selector)
+		}
+	}
+
+	return segments, nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,69 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"code.vegaprotocol.io/vegacapsule/utils"
+)
+
+// RelocateNetworkState rewrites all absolute paths in the network state stored in networkDir
+// that point to previousNetworkDir, e.g. after the network home has been moved or imported from archive.
+// Secrets stay encrypted as they are, so there is no need for the passphrase.
+func RelocateNetworkState(networkDir, previousNetworkDir string) error {
+	networkBytes, err := os.ReadFile(stateFilePath(networkDir))
+	if err != nil {
+		return fmt.Errorf("cannot read network state: %w", err)
+	}
+
+	version, header, rawState, err := decodeRawState(networkBytes)
+	if err != nil {
+		return err
+	}
+
+	if _, err := migrateState(version, rawState); err != nil {
+		return err
+	}
+
+	oldPath := filepath.Clean(previousNetworkDir)
+	newPath := filepath.Clean(networkDir)
+
+	relocated := replaceStrings(rawState, func(s string) string {
+		return utils.ReplacePathPrefix(s, oldPath, newPath)
+	})
+
+	netState, err := networkStateFromRaw(relocated.(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
+	if netState.Config != nil {
+		netState.Config.OutputDir = &networkDir
+	}
+
+	if header != nil {
+		netState.encryption = &stateEncryption{header: *header}
+	}
+
+	return netState.persist(networkDir)
+}
+
+func replaceStrings(node interface{}, replace func(string) string) interface{} {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for k, v := range n {
+			n[k] = replaceStrings(v, replace)
+		}
+		return n
+	case []interface{}:
+		for i, v := range n {
+			n[i] = replaceStrings(v, replace)
+		}
+		return n
+	case string:
+		return replace(n)
+	default:
+		return n
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 
 	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/ports"
 	"code.vegaprotocol.io/vegacapsule/types"
 	"code.vegaprotocol.io/vegacapsule/utils"
 )
@@ -17,6 +18,33 @@ type NetworkState struct {
 	GeneratedServices *types.GeneratedServices
 	RunningJobs       *types.NetworkJobs
 	VegaChainID       string
+	// Ports are ports allocated for the network by the `port` template function.
+	Ports *ports.Allocator
+
+	encryption *stateEncryption
+}
+
+type loadOptions struct {
+	passphrase     []byte
+	withoutSecrets bool
+}
+
+type LoadOption func(*loadOptions)
+
+// WithPassphrase enables encryption of the state secrets.
+// Secrets of already encrypted state are decrypted with given passphrase.
+func WithPassphrase(passphrase []byte) LoadOption {
+	return func(lo *loadOptions) {
+		lo.passphrase = passphrase
+	}
+}
+
+// WithoutSecrets allows to load encrypted state without passphrase. Secrets are left encrypted
+// and are persisted as they are, so it should be used only by callers that don't use the secrets.
+func WithoutSecrets() LoadOption {
+	return func(lo *loadOptions) {
+		lo.withoutSecrets = true
+	}
 }
 
 func (ns *NetworkState) Empty() bool {
@@ -27,20 +55,58 @@ func (ns *NetworkState) Running() bool {
 	return !ns.Empty() && ns.RunningJobs != nil && len(ns.RunningJobs.NodesSetsJobIDs) != 0
 }
 
+// Encrypted returns whether secrets of the network state are persisted encrypted.
+func (ns NetworkState) Encrypted() bool {
+	return ns.encryption != nil
+}
+
+// SetPassphrase changes the passphrase used to encrypt secrets. Nil passphrase disables the encryption.
+// It fails when the state has been loaded without passphrase and the secrets could not be decrypted.
+func (ns *NetworkState) SetPassphrase(passphrase []byte) error {
+	if ns.encryption != nil && ns.encryption.key == nil {
+		return fmt.Errorf("failed to change passphrase of encrypted network state: %w", ErrInvalidPassphrase)
+	}
+
+	if passphrase == nil {
+		ns.encryption = nil
+		return nil
+	}
+
+	encryption, err := newStateEncryption(passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to change passphrase of network state: %w", err)
+	}
+	ns.encryption = encryption
+
+	return nil
+}
+
 func (ns NetworkState) Persist() error {
+	return ns.persist(*ns.Config.OutputDir)
+}
+
+func (ns NetworkState) persist(networkDir string) error {
 	networkBytes, err := encodeState(ns)
 	if err != nil {
 		return fmt.Errorf("failed to persist network state: %w", err)
 	}
 
-	if err := os.WriteFile(stateFilePath(*ns.Config.OutputDir), networkBytes, 0o644); err != nil {
+	if err := utils.WriteFileAtomic(stateFilePath(networkDir), networkBytes, 0o644); err != nil {
 		return fmt.Errorf("failed to persist network state: %w", err)
 	}
 
 	return nil
 }
 
-func LoadNetworkState(networkDir string) (*NetworkState, error) {
+// LoadNetworkState returns consistent snapshot of the network state.
+// Callers that are going to persist the state should hold the lock obtained from LockNetworkState.
+// Loading encrypted state fails with ErrPassphraseRequired unless the WithPassphrase or WithoutSecrets option is given.
+func LoadNetworkState(networkDir string, opts ...LoadOption) (*NetworkState, error) {
+	lo := &loadOptions{}
+	for _, opt := range opts {
+		opt(lo)
+	}
+
 	statePath := stateFilePath(networkDir)
 	configExists, err := utils.FileExists(statePath)
 	if err != nil {
@@ -48,7 +114,14 @@ func LoadNetworkState(networkDir string) (*NetworkState, error) {
 	}
 
 	if !configExists {
-		return &NetworkState{}, nil
+		netState := &NetworkState{}
+		if lo.passphrase != nil {
+			if err := netState.SetPassphrase(lo.passphrase); err != nil {
+				return nil, err
+			}
+		}
+
+		return netState, nil
 	}
 
 	networkBytes, err := os.ReadFile(statePath)
@@ -56,12 +129,24 @@ func LoadNetworkState(networkDir string) (*NetworkState, error) {
 		return nil, fmt.Errorf("cannot read network state: %w", err)
 	}
 
-	netState, err := decodeState(networkBytes)
+	netState, err := decodeState(networkBytes, lo.passphrase)
 	if err != nil {
 		return nil, err
 	}
 
+	if netState.Encrypted() && lo.passphrase == nil && !lo.withoutSecrets {
+		return nil, fmt.Errorf("cannot load network state: %w", ErrPassphraseRequired)
+	}
+
+	// encrypt secrets of plain state when the passphrase is given
+	if !netState.Encrypted() && lo.passphrase != nil {
+		if err := netState.SetPassphrase(lo.passphrase); err != nil {
+			return nil, err
+		}
+	}
+
 	netState.Config.OutputDir = &networkDir
+	netState.Config.SetPortAllocator(netState.Ports)
 
 	return netState, nil
 }
@@ -0,0 +1,273 @@
+package state_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/state"
+	"code.vegaprotocol.io/vegacapsule/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testNetworkState(t *testing.T, networkDir string) state.NetworkState {
+	t.Helper()
+
+	conf, err := config.DefaultConfig()
+	require.NoError(t, err)
+	conf.OutputDir = &networkDir
+
+	genServices := types.DefaultGeneratedServices()
+	genServices.NodeSets["testnet-nodeset-validators-0-validator"] = types.NodeSet{
+		Name: "testnet-nodeset-validators-0-validator",
+		Mode: types.NodeModeValidator,
+		Vega: types.VegaNode{
+			NodeWalletInfo: &types.NodeWalletInfo{
+				EthereumAddress:          "0xEe7D375bcB50C26d52E1A4a472D8822A2A22d94F",
+				EthereumPrivateKey:       "a37f4c2a678aefb5037bf415a826df1540b330b7e471aa54184877ba901b9ef0",
+				VegaWalletRecoveryPhrase: "secret recovery phrase",
+			},
+		},
+	}
+
+	return state.NetworkState{
+		Config:            conf,
+		GeneratedServices: &genServices,
+		RunningJobs:       &types.NetworkJobs{},
+		VegaChainID:       "testnet-001",
+	}
+}
+
+func writeLegacyState(t *testing.T, networkDir string, ns state.NetworkState) {
+	t.Helper()
+
+	stateBytes, err := json.Marshal(ns)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(networkDir, "network.dat"), []byte(hex.EncodeToString(stateBytes)), 0o644))
+}
+
+func TestPersistAndLoadNetworkState(t *testing.T) {
+	networkDir := t.TempDir()
+	ns := testNetworkState(t, networkDir)
+
+	require.NoError(t, ns.Persist())
+
+	loaded, err := state.LoadNetworkState(networkDir)
+	require.NoError(t, err)
+	assert.Equal(t, ns.VegaChainID, loaded.VegaChainID)
+	assert.Equal(t, ns.GeneratedServices.NodeSets, loaded.GeneratedServices.NodeSets)
+	assert.Equal(t, networkDir, *loaded.Config.OutputDir)
+}
+
+func TestLoadLegacyNetworkState(t *testing.T) {
+	networkDir := t.TempDir()
+	ns := testNetworkState(t, networkDir)
+	writeLegacyState(t, networkDir, ns)
+
+	loaded, err := state.LoadNetworkState(networkDir)
+	require.NoError(t, err)
+	assert.Equal(t, ns.VegaChainID, loaded.VegaChainID)
+	assert.Equal(t, ns.GeneratedServices.NodeSets, loaded.GeneratedServices.NodeSets)
+}
+
+func TestMigrateNetworkState(t *testing.T) {
+	networkDir := t.TempDir()
+	statePath := filepath.Join(networkDir, "network.dat")
+	writeLegacyState(t, networkDir, testNetworkState(t, networkDir))
+
+	legacyBytes, err := os.ReadFile(statePath)
+	require.NoError(t, err)
+
+	result, err := state.MigrateNetworkState(networkDir, true)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.FromVersion)
+	assert.Equal(t, state.CurrentVersion(), result.ToVersion)
+	assert.False(t, result.UpToDate())
+
+	// dry run must not touch the state file
+	dryRunBytes, err := os.ReadFile(statePath)
+	require.NoError(t, err)
+	assert.Equal(t, legacyBytes, dryRunBytes)
+
+	_, err = state.MigrateNetworkState(networkDir, false)
+	require.NoError(t, err)
+
+	result, err = state.MigrateNetworkState(networkDir, true)
+	require.NoError(t, err)
+	assert.True(t, result.UpToDate())
+}
+
+func TestLoadNetworkStateFromNewerVersionFails(t *testing.T) {
+	networkDir := t.TempDir()
+	envelope := []byte(`{"version": 999, "state": {}}`)
+	require.NoError(t, os.WriteFile(filepath.Join(networkDir, "network.dat"), envelope, 0o644))
+
+	_, err := state.LoadNetworkState(networkDir)
+	assert.Error(t, err)
+}
+
+func TestLockNetworkState(t *testing.T) {
+	networkDir := filepath.Join(t.TempDir(), "testnet")
+
+	lock, err := state.LockNetworkState(networkDir, time.Second)
+	require.NoError(t, err)
+
+	_, err = state.LockNetworkState(networkDir, time.Millisecond*200)
+	assert.ErrorIs(t, err, state.ErrLockTimeout)
+
+	require.NoError(t, lock.Unlock())
+
+	lock, err = state.LockNetworkState(networkDir, time.Second)
+	require.NoError(t, err)
+	require.NoError(t, lock.Unlock())
+}
+
+func TestEncryptedNetworkState(t *testing.T) {
+	networkDir := t.TempDir()
+	nodeSetName := "testnet-nodeset-validators-0-validator"
+	passphrase := []byte("p4ssphr4se")
+
+	netState, err := state.LoadNetworkState(networkDir, state.WithPassphrase(passphrase))
+	require.NoError(t, err)
+	assert.True(t, netState.Encrypted())
+
+	ns := testNetworkState(t, networkDir)
+	netState.Config = ns.Config
+	netState.GeneratedServices = ns.GeneratedServices
+	netState.RunningJobs = ns.RunningJobs
+	require.NoError(t, netState.Persist())
+
+	expectedWalletInfo := ns.GeneratedServices.NodeSets[nodeSetName].Vega.NodeWalletInfo
+
+	stateBytes, err := os.ReadFile(filepath.Join(networkDir, "network.dat"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(stateBytes), expectedWalletInfo.EthereumPrivateKey)
+	assert.NotContains(t, string(stateBytes), expectedWalletInfo.VegaWalletRecoveryPhrase)
+	assert.Contains(t, string(stateBytes), expectedWalletInfo.EthereumAddress)
+
+	t.Run("without passphrase fails", func(t *testing.T) {
+		_, err := state.LoadNetworkState(networkDir)
+		assert.ErrorIs(t, err, state.ErrPassphraseRequired)
+	})
+
+	t.Run("without secrets only non secret fields are readable", func(t *testing.T) {
+		loaded, err := state.LoadNetworkState(networkDir, state.WithoutSecrets())
+		require.NoError(t, err)
+
+		walletInfo := loaded.GeneratedServices.NodeSets[nodeSetName].Vega.NodeWalletInfo
+		assert.Equal(t, expectedWalletInfo.EthereumAddress, walletInfo.EthereumAddress)
+		assert.NotEqual(t, expectedWalletInfo.EthereumPrivateKey, walletInfo.EthereumPrivateKey)
+
+		// persisting without passphrase keeps secrets encrypted
+		require.NoError(t, loaded.Persist())
+		assert.Error(t, loaded.SetPassphrase([]byte("new")))
+	})
+
+	t.Run("wrong passphrase fails", func(t *testing.T) {
+		_, err := state.LoadNetworkState(networkDir, state.WithPassphrase([]byte("wrong")))
+		assert.ErrorIs(t, err, state.ErrInvalidPassphrase)
+	})
+
+	t.Run("with passphrase secrets are decrypted", func(t *testing.T) {
+		loaded, err := state.LoadNetworkState(networkDir, state.WithPassphrase(passphrase))
+		require.NoError(t, err)
+		assert.Equal(t, expectedWalletInfo, loaded.GeneratedServices.NodeSets[nodeSetName].Vega.NodeWalletInfo)
+	})
+
+	t.Run("rekey", func(t *testing.T) {
+		loaded, err := state.LoadNetworkState(networkDir, state.WithPassphrase(passphrase))
+		require.NoError(t, err)
+		require.NoError(t, loaded.SetPassphrase([]byte("new")))
+		require.NoError(t, loaded.Persist())
+
+		loaded, err = state.LoadNetworkState(networkDir, state.WithPassphrase([]byte("new")))
+		require.NoError(t, err)
+		assert.Equal(t, expectedWalletInfo, loaded.GeneratedServices.NodeSets[nodeSetName].Vega.NodeWalletInfo)
+	})
+}
+
+func TestQueryNetworkState(t *testing.T) {
+	ns := testNetworkState(t, t.TempDir())
+	nodeSet := ns.GeneratedServices.NodeSets["testnet-nodeset-validators-0-validator"]
+	nodeSet.Vega.HomeDir = "/tmp/testnet/vega/node0"
+	// not representable as float64
+	nodeSet.Index = 9007199254740993
+	ns.GeneratedServices.NodeSets[nodeSet.Name] = nodeSet
+
+	rawState, err := ns.ToRaw(false)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		selector string
+		expected interface{}
+	}{
+		{selector: "nodesets.testnet-nodeset-validators-0-validator.vega.home_dir", expected: "/tmp/testnet/vega/node0"},
+		{selector: "$.GeneratedServices.NodeSets.testnet-nodeset-validators-0-validator.Vega.HomeDir", expected: "/tmp/testnet/vega/node0"},
+		{selector: "vega_chain_id", expected: "testnet-001"},
+		{selector: "config.node_dir_prefix", expected: "node"},
+		{selector: "nodesets.testnet-nodeset-validators-0-validator.index", expected: json.Number("9007199254740993")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.selector, func(t *testing.T) {
+			value, err := state.Query(rawState, tc.selector)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+
+	_, err = state.Query(rawState, "nodesets.non-existing.vega")
+	assert.Error(t, err)
+
+	nodeSetState, err := state.Query(rawState, "nodesets.testnet-nodeset-validators-0-validator")
+	require.NoError(t, err)
+	nodeSetYAML, err := state.MarshalYAML(nodeSetState)
+	require.NoError(t, err)
+	assert.Contains(t, string(nodeSetYAML), "\nIndex: 9007199254740993\n")
+
+	indexYAML, err := state.MarshalYAML(json.Number("9"))
+	require.NoError(t, err)
+	assert.Equal(t, "9\n", string(indexYAML))
+
+	redactedState, err := ns.ToRaw(true)
+	require.NoError(t, err)
+
+	privateKey, err := state.Query(redactedState, "nodesets.testnet-nodeset-validators-0-validator.vega.node_wallet_info.ethereum_private_key")
+	require.NoError(t, err)
+	assert.Equal(t, "<redacted>", privateKey)
+}
+
+func TestRelocateNetworkState(t *testing.T) {
+	root := t.TempDir()
+	previousDir := filepath.Join(root, "net")
+	networkDir := filepath.Join(root, "moved")
+	require.NoError(t, os.Mkdir(previousDir, 0o755))
+
+	ns := testNetworkState(t, previousDir)
+	nodeSet := ns.GeneratedServices.NodeSets["testnet-nodeset-validators-0-validator"]
+	nodeSet.Vega.HomeDir = filepath.Join(previousDir, "vega", "node0")
+	nodeSet.Vega.ConfigFilePath = previousDir
+	// sibling directory sharing prefix with the network directory is not relocated
+	nodeSet.Tendermint.HomeDir = filepath.Join(root, "net2", "tendermint")
+	ns.GeneratedServices.NodeSets[nodeSet.Name] = nodeSet
+	require.NoError(t, ns.Persist())
+
+	require.NoError(t, os.Rename(previousDir, networkDir))
+	require.NoError(t, state.RelocateNetworkState(networkDir, previousDir))
+
+	loaded, err := state.LoadNetworkState(networkDir)
+	require.NoError(t, err)
+	assert.Equal(t, networkDir, *loaded.Config.OutputDir)
+
+	relocated := loaded.GeneratedServices.NodeSets[nodeSet.Name]
+	assert.Equal(t, filepath.Join(networkDir, "vega", "node0"), relocated.Vega.HomeDir)
+	assert.Equal(t, networkDir, relocated.Vega.ConfigFilePath)
+	assert.Equal(t, filepath.Join(root, "net2", "tendermint"), relocated.Tendermint.HomeDir)
+}
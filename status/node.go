@@ -0,0 +1,219 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	chainStatusConnected   = "CHAIN_STATUS_CONNECTED"
+	validatorStatusPrefix  = "VALIDATOR_NODE_STATUS_"
+	blockHeightHeader      = "X-Block-Height"
+	grpcBlockHeightHeader  = "Grpc-Metadata-X-Block-Height"
+	visorCurrentFolderName = "current"
+	visorNotStartedState   = "not started"
+	httpTimeout            = 5 * time.Second
+)
+
+var httpClient = http.Client{Timeout: httpTimeout}
+
+// NodeEndpoints are URLs of REST APIs used to check a node set.
+type NodeEndpoints struct {
+	// VegaREST is URL of the Vega node REST API.
+	VegaREST string
+	// DataNodeREST is URL of the data node REST API of the node set, empty if it doesn't run a data node.
+	DataNodeREST string
+	// ValidatorsREST is URL of any data node REST API of the network, it's used to look up validator status.
+	ValidatorsREST string
+}
+
+// NodeStatus is status of a node set reported by its Vega node and data node.
+type NodeStatus struct {
+	BlockHeight uint64 `json:"block_height"`
+	ChainID     string `json:"chain_id"`
+	ChainStatus string `json:"chain_status"`
+	// Stalled is true when the block height has not advanced between two samples.
+	Stalled bool `json:"stalled"`
+	// Validator is validator status reported by data node or mode of the node set.
+	Validator      string  `json:"validator"`
+	DataNodeHeight *uint64 `json:"data_node_block_height,omitempty"`
+	// DataNodeLag is number of blocks the data node is behind the Vega node.
+	DataNodeLag *int64 `json:"data_node_lag,omitempty"`
+	// Visor is name of the folder with binaries currently run by Visor.
+	Visor string `json:"visor,omitempty"`
+}
+
+type statisticsResponse struct {
+	Statistics struct {
+		BlockHeight string `json:"blockHeight"`
+		ChainID     string `json:"chainId"`
+		Status      string `json:"status"`
+	} `json:"statistics"`
+}
+
+type nodeResponse struct {
+	Node struct {
+		RankingScore struct {
+			Status string `json:"status"`
+		} `json:"rankingScore"`
+	} `json:"node"`
+}
+
+// CheckNode samples block height of the Vega node twice, opts.SampleInterval apart, and checks that it advances.
+// Lag of the data node and validator status are checked when the endpoints are set.
+func CheckNode(ctx context.Context, endpoints NodeEndpoints, validatorID string, opts Options) (*NodeStatus, []string) {
+	node := &NodeStatus{}
+	issues := []string{}
+
+	first, err := vegaStatistics(ctx, endpoints.VegaREST)
+	if err != nil {
+		return node, append(issues, err.Error())
+	}
+
+	select {
+	case <-ctx.Done():
+		return node, append(issues, ctx.Err().Error())
+	case <-time.After(opts.SampleInterval):
+	}
+
+	second, err := vegaStatistics(ctx, endpoints.VegaREST)
+	if err != nil {
+		return node, append(issues, err.Error())
+	}
+
+	node.BlockHeight = second.height
+	node.ChainID = second.Statistics.ChainID
+	node.ChainStatus = second.Statistics.Status
+
+	if second.height <= first.height {
+		node.Stalled = true
+		issues = append(issues, fmt.Sprintf("stalled: block height %d has not advanced in %s", second.height, opts.SampleInterval))
+	}
+
+	if node.ChainStatus != chainStatusConnected {
+		issues = append(issues, fmt.Sprintf("chain status is %s", node.ChainStatus))
+	}
+
+	if endpoints.DataNodeREST != "" {
+		height, err := dataNodeBlockHeight(ctx, endpoints.DataNodeREST)
+		if err != nil {
+			issues = append(issues, err.Error())
+		} else {
+			lag := int64(node.BlockHeight) - int64(height)
+			node.DataNodeHeight = &height
+			node.DataNodeLag = &lag
+
+			if lag > opts.MaxDataNodeLag {
+				issues = append(issues, fmt.Sprintf("data node is %d blocks behind", lag))
+			}
+		}
+	}
+
+	if validatorID != "" && endpoints.ValidatorsREST != "" {
+		status, err := validatorStatus(ctx, endpoints.ValidatorsREST, validatorID)
+		if err != nil {
+			issues = append(issues, err.Error())
+		} else {
+			node.Validator = status
+		}
+	}
+
+	return node, issues
+}
+
+type statistics struct {
+	statisticsResponse
+	height uint64
+}
+
+func vegaStatistics(ctx context.Context, url string) (*statistics, error) {
+	resp, err := get(ctx, strings.TrimSuffix(url, "/")+"/statistics")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Vega node statistics: %w", err)
+	}
+	defer resp.Body.Close()
+
+	stats := &statistics{}
+	if err := json.NewDecoder(resp.Body).Decode(&stats.statisticsResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode Vega node statistics: %w", err)
+	}
+
+	stats.height, err = strconv.ParseUint(stats.Statistics.BlockHeight, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse block height %q: %w", stats.Statistics.BlockHeight, err)
+	}
+
+	return stats, nil
+}
+
+// dataNodeBlockHeight returns block height the data node has processed, it's sent in headers of all REST API responses.
+func dataNodeBlockHeight(ctx context.Context, url string) (uint64, error) {
+	resp, err := get(ctx, strings.TrimSuffix(url, "/")+"/api/v2/info")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get data node info: %w", err)
+	}
+	resp.Body.Close()
+
+	header := resp.Header.Get(blockHeightHeader)
+	if header == "" {
+		header = resp.Header.Get(grpcBlockHeightHeader)
+	}
+
+	height, err := strconv.ParseUint(header, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse data node block height %q: %w", header, err)
+	}
+
+	return height, nil
+}
+
+func validatorStatus(ctx context.Context, url, nodeID string) (string, error) {
+	resp, err := get(ctx, fmt.Sprintf("%s/api/v2/node/%s", strings.TrimSuffix(url, "/"), nodeID))
+	if err != nil {
+		return "", fmt.Errorf("failed to get validator status: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var node nodeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&node); err != nil {
+		return "", fmt.Errorf("failed to decode validator status: %w", err)
+	}
+
+	return strings.ToLower(strings.TrimPrefix(node.Node.RankingScore.Status, validatorStatusPrefix)), nil
+}
+
+func get(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status code %d from %q", resp.StatusCode, url)
+	}
+
+	return resp, nil
+}
+
+// visorState returns name of the folder Visor runs the binaries from, e.g. genesis or the version of the last upgrade.
+func visorState(visorHome string) string {
+	target, err := os.Readlink(filepath.Join(visorHome, visorCurrentFolderName))
+	if err != nil {
+		return visorNotStartedState
+	}
+
+	return filepath.Base(target)
+}
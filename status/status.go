@@ -0,0 +1,299 @@
+package status
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/ports"
+	"code.vegaprotocol.io/vegacapsule/probes"
+	"code.vegaprotocol.io/vegacapsule/types"
+	"code.vegaprotocol.io/vegacapsule/utils"
+)
+
+const (
+	KindNodeSet           = "node_set"
+	KindWallet            = "wallet"
+	KindFaucet            = "faucet"
+	KindDockerService     = "docker_service"
+	KindExecService       = "exec_service"
+	KindEthereum          = "ethereum"
+	KindSecondaryEthereum = "secondary_ethereum"
+)
+
+// Options changes how the status is collected.
+type Options struct {
+	// SampleInterval is time between two block height samples, nodes with block height not advancing in this time are stalled.
+	SampleInterval time.Duration
+	// ProbeTimeout is maximum time for readiness probes of a job to pass.
+	ProbeTimeout time.Duration
+	// MaxDataNodeLag is number of blocks a data node can be behind its Vega node without being flagged.
+	MaxDataNodeLag int64
+}
+
+// NetworkStatus is health overview of all components of the network.
+type NetworkStatus struct {
+	Components []ComponentStatus `json:"components"`
+}
+
+// Healthy returns true when none of the components has issues.
+func (s NetworkStatus) Healthy() bool {
+	return s.Unhealthy() == 0
+}
+
+// Unhealthy returns number of components with issues.
+func (s NetworkStatus) Unhealthy() int {
+	n := 0
+	for _, c := range s.Components {
+		if len(c.Issues) != 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// ComponentStatus is status of a single job of the network or of an Ethereum endpoint.
+type ComponentStatus struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+	// Job is nil for components that are not run by Capsule.
+	Job *types.JobStatus `json:"job,omitempty"`
+	// ProbesPassed is nil when the component has no readiness probes or they were not run.
+	ProbesPassed *bool       `json:"probes_passed,omitempty"`
+	Node         *NodeStatus `json:"node,omitempty"`
+	Issues       []string    `json:"issues,omitempty"`
+}
+
+func (c ComponentStatus) running() bool {
+	return c.Job != nil && c.Job.Status == types.JobStatusRunning
+}
+
+type jobStatuser interface {
+	JobStatus(ctx context.Context, jobID string) (*types.JobStatus, error)
+}
+
+// Collect checks all jobs of the network, their readiness probes, Vega nodes and Ethereum endpoints.
+// Problems found are reported as issues of the components, the checks run concurrently.
+func Collect(
+	ctx context.Context,
+	runner jobStatuser,
+	conf *config.Config,
+	genServices *types.GeneratedServices,
+	opts Options,
+) *NetworkStatus {
+	components := []ComponentStatus{}
+
+	nodeSets := genServices.NodeSets.ToSlice()
+	for _, ns := range nodeSets {
+		components = append(components, ComponentStatus{Name: ns.Name, Kind: KindNodeSet})
+	}
+	if genServices.Wallet != nil {
+		components = append(components, ComponentStatus{Name: genServices.Wallet.Name, Kind: KindWallet})
+	}
+	if genServices.Faucet != nil {
+		components = append(components, ComponentStatus{Name: genServices.Faucet.Name, Kind: KindFaucet})
+	}
+	if conf.Network.PreStart != nil {
+		for _, dc := range conf.Network.PreStart.Docker {
+			components = append(components, ComponentStatus{Name: dc.Name, Kind: KindDockerService})
+		}
+		for _, ec := range conf.Network.PreStart.Exec {
+			components = append(components, ComponentStatus{Name: ec.Name, Kind: KindExecService})
+		}
+	}
+	// post start exec services are not run
+	if conf.Network.PostStart != nil {
+		for _, dc := range conf.Network.PostStart.Docker {
+			components = append(components, ComponentStatus{Name: dc.Name, Kind: KindDockerService})
+		}
+	}
+
+	for i := range components {
+		c := &components[i]
+
+		js, err := runner.JobStatus(ctx, c.Name)
+		if err != nil {
+			c.Issues = append(c.Issues, fmt.Sprintf("failed to get job status: %s", err))
+			continue
+		}
+
+		c.Job = js
+		if js.Status != types.JobStatusRunning {
+			c.Issues = append(c.Issues, fmt.Sprintf("job is %s", js.Status))
+		}
+	}
+
+	for _, eth := range []struct {
+		kind string
+		conf config.EthereumConfig
+	}{
+		{kind: KindEthereum, conf: conf.Network.Ethereum},
+		{kind: KindSecondaryEthereum, conf: conf.Network.SecondaryEthereum},
+	} {
+		if eth.conf.Endpoint != "" {
+			components = append(components, ComponentStatus{Name: eth.conf.Endpoint, Kind: eth.kind})
+		}
+	}
+
+	var (
+		wg sync.WaitGroup
+		// mu guards the components updated by the concurrent checks
+		mu sync.Mutex
+	)
+
+	update := func(i int, f func(c *ComponentStatus)) {
+		mu.Lock()
+		defer mu.Unlock()
+		f(&components[i])
+	}
+
+	index := make(map[string]int, len(components))
+	for i, c := range components {
+		if c.Job != nil {
+			index[c.Name] = i
+		}
+	}
+
+	for _, check := range readinessChecks(conf, genServices) {
+		i, ok := index[check.JobID]
+		if !ok || !components[i].running() {
+			continue
+		}
+
+		check := check
+		check.Probes.Timeout = utils.ToPoint(opts.ProbeTimeout.String())
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := probes.Probe(ctx, check.JobID, check.Probes)
+			update(i, func(c *ComponentStatus) {
+				c.ProbesPassed = utils.ToPoint(err == nil)
+				if err != nil {
+					c.Issues = append(c.Issues, fmt.Sprintf("readiness probes failed: %s", err))
+				}
+			})
+		}()
+	}
+
+	// validators are checked against data node only when there is one
+	validatorsREST, _ := ports.DataNodeRESTURL(nodeSets)
+
+	for _, ns := range nodeSets {
+		i, ok := index[ns.Name]
+		if !ok || !components[i].running() {
+			continue
+		}
+
+		ns := ns
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			node, issues := checkNodeSet(ctx, ns, validatorsREST, opts)
+			update(i, func(c *ComponentStatus) {
+				c.Node = node
+				c.Issues = append(c.Issues, issues...)
+			})
+		}()
+	}
+
+	for i, c := range components {
+		if c.Kind != KindEthereum && c.Kind != KindSecondaryEthereum {
+			continue
+		}
+
+		eth := conf.Network.Ethereum
+		if c.Kind == KindSecondaryEthereum {
+			eth = conf.Network.SecondaryEthereum
+		}
+
+		i := i
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := checkEthereum(ctx, eth, opts)
+			update(i, func(c *ComponentStatus) {
+				c.ProbesPassed = utils.ToPoint(err == nil)
+				if err != nil {
+					c.Issues = append(c.Issues, err.Error())
+				}
+			})
+		}()
+	}
+
+	wg.Wait()
+
+	return &NetworkStatus{Components: components}
+}
+
+func checkNodeSet(ctx context.Context, ns types.NodeSet, validatorsREST string, opts Options) (*NodeStatus, []string) {
+	endpoints := NodeEndpoints{ValidatorsREST: validatorsREST}
+
+	url, err := ports.RESTURL(ns.Vega.ConfigFilePath, ports.VegaRESTPortName)
+	if err != nil {
+		return nil, []string{fmt.Sprintf("failed to find Vega node REST API: %s", err)}
+	}
+	endpoints.VegaREST = url
+
+	issues := []string{}
+
+	if ns.DataNode != nil {
+		url, err := ports.RESTURL(ns.DataNode.ConfigFilePath, ports.DataNodeRESTPortName)
+		if err != nil {
+			issues = append(issues, fmt.Sprintf("failed to find data node REST API: %s", err))
+		}
+		endpoints.DataNodeREST = url
+	}
+
+	var validatorID string
+	if ns.IsValidator() && ns.Vega.NodeWalletInfo != nil {
+		validatorID = ns.Vega.NodeWalletInfo.VegaWalletID
+	}
+
+	node, nodeIssues := CheckNode(ctx, endpoints, validatorID, opts)
+	if node.Validator == "" {
+		node.Validator = ns.Mode
+	}
+	if ns.Visor != nil {
+		node.Visor = visorState(ns.Visor.HomeDir)
+	}
+
+	return node, append(issues, nodeIssues...)
+}
+
+func checkEthereum(ctx context.Context, eth config.EthereumConfig, opts Options) error {
+	chainID, err := strconv.ParseInt(eth.ChainID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse chain ID %q: %w", eth.ChainID, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.ProbeTimeout)
+	defer cancel()
+
+	if err := probes.ProbeEthereumRPC(ctx, "ethereum", eth.Endpoint, chainID, ""); err != nil {
+		return fmt.Errorf("Ethereum endpoint is not reachable: %w", err)
+	}
+
+	return nil
+}
+
+// readinessChecks returns configured readiness probes, node sets without readiness probes are checked by CheckNode.
+func readinessChecks(conf *config.Config, genServices *types.GeneratedServices) []probes.ReadinessCheck {
+	checks := []probes.ReadinessCheck{}
+	for _, check := range probes.ReadinessChecks(conf, genServices) {
+		if ns, ok := genServices.NodeSets[check.JobID]; ok && ns.ReadinessProbe == nil {
+			continue
+		}
+		checks = append(checks, check)
+	}
+
+	return checks
+}
@@ -0,0 +1,143 @@
+package status_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/status"
+	"code.vegaprotocol.io/vegacapsule/types"
+	"code.vegaprotocol.io/vegacapsule/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testOpts = status.Options{
+	SampleInterval: 10 * time.Millisecond,
+	ProbeTimeout:   time.Second,
+	MaxDataNodeLag: 5,
+}
+
+func newVegaServer(t *testing.T, advance bool) *httptest.Server {
+	var height atomic.Int64
+	height.Store(100)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/statistics", r.URL.Path)
+
+		h := height.Load()
+		if advance {
+			h = height.Add(1)
+		}
+
+		fmt.Fprintf(w, `{"statistics": {"blockHeight": "%d", "chainId": "testnet-1", "status": "CHAIN_STATUS_CONNECTED"}}`, h)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func newDataNodeServer(t *testing.T, height int) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Block-Height", fmt.Sprint(height))
+
+		switch r.URL.Path {
+		case "/api/v2/info":
+			fmt.Fprint(w, `{}`)
+		case "/api/v2/node/node-1":
+			fmt.Fprint(w, `{"node": {"rankingScore": {"status": "VALIDATOR_NODE_STATUS_TENDERMINT"}}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestCheckNode(t *testing.T) {
+	vega := newVegaServer(t, true)
+	dataNode := newDataNodeServer(t, 100)
+
+	node, issues := status.CheckNode(context.Background(), status.NodeEndpoints{
+		VegaREST:       vega.URL,
+		DataNodeREST:   dataNode.URL,
+		ValidatorsREST: dataNode.URL,
+	}, "node-1", testOpts)
+
+	assert.Empty(t, issues)
+	assert.Equal(t, uint64(102), node.BlockHeight)
+	assert.Equal(t, "testnet-1", node.ChainID)
+	assert.False(t, node.Stalled)
+	assert.Equal(t, "tendermint", node.Validator)
+	require.NotNil(t, node.DataNodeLag)
+	assert.Equal(t, int64(2), *node.DataNodeLag)
+}
+
+func TestCheckNodeIssues(t *testing.T) {
+	vega := newVegaServer(t, false)
+	dataNode := newDataNodeServer(t, 50)
+
+	node, issues := status.CheckNode(context.Background(), status.NodeEndpoints{
+		VegaREST:     vega.URL,
+		DataNodeREST: dataNode.URL,
+	}, "", testOpts)
+
+	assert.True(t, node.Stalled)
+	assert.Equal(t, []string{
+		"stalled: block height 100 has not advanced in 10ms",
+		"data node is 50 blocks behind",
+	}, issues)
+}
+
+type fakeRunner map[string]string
+
+func (r fakeRunner) JobStatus(ctx context.Context, jobID string) (*types.JobStatus, error) {
+	return &types.JobStatus{ID: jobID, Status: r[jobID]}, nil
+}
+
+func TestCollect(t *testing.T) {
+	conf := &config.Config{
+		Network: config.NetworkConfig{
+			PreStart: &config.PStartConfig{
+				Exec: []config.ExecConfig{{
+					Name:           "ready",
+					ReadinessProbe: &types.ProbesConfig{Exec: &types.ExecProbe{Cmd: "true"}, Interval: utils.ToPoint("10ms")},
+				}},
+				Docker: []config.DockerConfig{{Name: "postgres"}},
+			},
+		},
+	}
+	genServices := &types.GeneratedServices{
+		Wallet: &types.Wallet{GeneratedService: types.GeneratedService{Name: "wallet"}},
+	}
+
+	runner := fakeRunner{
+		"wallet":   types.JobStatusRunning,
+		"ready":    types.JobStatusRunning,
+		"postgres": types.JobStatusFailed,
+	}
+
+	netStatus := status.Collect(context.Background(), runner, conf, genServices, testOpts)
+	require.Len(t, netStatus.Components, 3)
+	assert.Equal(t, 1, netStatus.Unhealthy())
+
+	ready := netStatus.Components[2]
+	assert.Equal(t, "ready", ready.Name)
+	require.NotNil(t, ready.ProbesPassed)
+	assert.True(t, *ready.ProbesPassed)
+
+	postgres := netStatus.Components[1]
+	assert.Equal(t, []string{"job is failed"}, postgres.Issues)
+
+	var out bytes.Buffer
+	require.NoError(t, netStatus.WriteTable(&out))
+	assert.Contains(t, out.String(), "postgres  docker_service  failed   -       -       -         -          -              -      job is failed\n")
+}
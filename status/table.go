@@ -0,0 +1,62 @@
+package status
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+const emptyCell = "-"
+
+// WriteTable writes the status as a table with one row per component.
+func (s NetworkStatus) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "NAME\tKIND\tJOB\tPROBES\tHEIGHT\tCHAIN ID\tVALIDATOR\tDATA NODE LAG\tVISOR\tISSUES")
+
+	for _, c := range s.Components {
+		job, probes := emptyCell, emptyCell
+		height, chainID, validator, lag, visor := emptyCell, emptyCell, emptyCell, emptyCell, emptyCell
+		issues := "OK"
+
+		if c.Job != nil {
+			job = c.Job.Status
+		}
+		if c.ProbesPassed != nil {
+			probes = "failed"
+			if *c.ProbesPassed {
+				probes = "passed"
+			}
+		}
+		if n := c.Node; n != nil {
+			height = strconv.FormatUint(n.BlockHeight, 10)
+			if n.Stalled {
+				height += " (stalled)"
+			}
+			chainID = orEmpty(n.ChainID)
+			validator = orEmpty(n.Validator)
+			visor = orEmpty(n.Visor)
+			if n.DataNodeLag != nil {
+				lag = strconv.FormatInt(*n.DataNodeLag, 10)
+			}
+		}
+		if len(c.Issues) != 0 {
+			issues = strings.Join(c.Issues, "; ")
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			c.Name, c.Kind, job, probes, height, chainID, validator, lag, visor, issues,
+		)
+	}
+
+	return tw.Flush()
+}
+
+func orEmpty(s string) string {
+	if s == "" {
+		return emptyCell
+	}
+	return s
+}
@@ -0,0 +1,120 @@
+package supervisor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+
+	"code.vegaprotocol.io/vegacapsule/utils"
+
+	psprocess "github.com/shirou/gopsutil/v3/process"
+)
+
+const (
+	JobPending = "pending"
+	JobRunning = "running"
+	JobFailed  = "failed"
+	JobStopped = "stopped"
+)
+
+// Task is a process run by the supervisor, it's an equivalent of Nomad raw_exec task.
+type Task struct {
+	Name    string            `json:"name"`
+	Command string            `json:"command"`
+	Args    []string          `json:"args"`
+	Env     map[string]string `json:"env,omitempty"`
+}
+
+// RestartPolicy defines how failed tasks are restarted.
+// The job fails when a task fails more times than Attempts.
+type RestartPolicy struct {
+	Attempts int           `json:"attempts"`
+	Delay    time.Duration `json:"delay"`
+}
+
+type Job struct {
+	ID    string `json:"id"`
+	Tasks []Task `json:"tasks"`
+	// Ports are static ports exposed by the job
+	Ports       []int64       `json:"ports,omitempty"`
+	Restart     RestartPolicy `json:"restart"`
+	KillTimeout time.Duration `json:"kill_timeout"`
+	// LogsDir is a directory where stdout and stderr of the tasks are written to
+	LogsDir string `json:"logs_dir"`
+}
+
+type TaskState struct {
+	PID       int       `json:"pid"`
+	Restarts  int       `json:"restarts"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// Alive returns true when the task process is still running. Process created after the task has been started
+// only reuses PID of the task that has already exited.
+func (ts TaskState) Alive() bool {
+	if ts.PID <= 0 || !processAlive(ts.PID) {
+		return false
+	}
+
+	p, err := psprocess.NewProcess(int32(ts.PID))
+	if err != nil {
+		return false
+	}
+
+	createdAt, err := p.CreateTime()
+	if err != nil {
+		return false
+	}
+
+	// creation time is measured with lower precision than the start time
+	return time.UnixMilli(createdAt).Before(ts.StartedAt.Add(time.Second))
+}
+
+// JobState is persisted by the supervisor process, so the job can be inspected and stopped from other processes.
+type JobState struct {
+	Job          Job                   `json:"job"`
+	PID          int                   `json:"pid"`
+	Status       string                `json:"status"`
+	Error        string                `json:"error,omitempty"`
+	RunningSince time.Time             `json:"running_since,omitempty"`
+	Tasks        map[string]*TaskState `json:"tasks"`
+}
+
+// Alive returns true when supervisor process of the job is running.
+func (s JobState) Alive() bool {
+	return s.PID > 0 && processAlive(s.PID)
+}
+
+func LoadJobState(path string) (*JobState, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &JobState{}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal job state %q: %w", path, err)
+	}
+
+	return s, nil
+}
+
+func (s JobState) Persist(path string) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal job state: %w", err)
+	}
+
+	// readers never see partially written state
+	if err := utils.WriteFileAtomic(path, b, 0o644); err != nil {
+		return fmt.Errorf("failed to write job state: %w", err)
+	}
+
+	return nil
+}
+
+func processAlive(pid int) bool {
+	return syscall.Kill(pid, 0) == nil
+}
@@ -0,0 +1,768 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/logscollector"
+	"code.vegaprotocol.io/vegacapsule/probes"
+	"code.vegaprotocol.io/vegacapsule/types"
+
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	stateFileExt = ".json"
+
+	// task states are the same as in Nomad
+	taskRunning = "running"
+	taskDead    = "dead"
+)
+
+var (
+	// minHealthyTime is how long all tasks of a job must run before the job is considered started
+	minHealthyTime = time.Second * 5
+	startDeadline  = time.Minute
+	pollInterval   = time.Millisecond * 500
+)
+
+// JobRunner runs jobs of the network as child processes of supervisor processes, without Nomad.
+// Every job gets its own supervisor process started by the `supervisor run` command of the Capsule binary,
+// so the jobs keep running after the Capsule command exits.
+type JobRunner struct {
+	capsuleBinary string
+	logsOutputDir string
+	stateDir      string
+	restart       RestartPolicy
+	killTimeout   time.Duration
+	// ignoredJobPrefixes are prefixes of jobs that belong to other networks and must never be stopped
+	ignoredJobPrefixes []string
+}
+
+func NewJobRunner(capsuleBinaryPath, logsOutputDir, stateDir string, restart RestartPolicy, killTimeout time.Duration) (*JobRunner, error) {
+	if err := os.MkdirAll(stateDir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create supervisor state directory %q: %w", stateDir, err)
+	}
+
+	return &JobRunner{
+		capsuleBinary: capsuleBinaryPath,
+		logsOutputDir: logsOutputDir,
+		stateDir:      stateDir,
+		restart:       restart,
+		killTimeout:   killTimeout,
+	}, nil
+}
+
+// IgnoreJobsWithPrefixes makes the runner skip jobs with given prefixes when it stops all jobs.
+func (r *JobRunner) IgnoreJobsWithPrefixes(prefixes ...string) {
+	r.ignoredJobPrefixes = append(r.ignoredJobPrefixes, prefixes...)
+}
+
+func (r *JobRunner) isIgnoredJob(jobID string) bool {
+	for _, prefix := range r.ignoredJobPrefixes {
+		if strings.HasPrefix(jobID, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *JobRunner) statePath(jobID string) string {
+	return filepath.Join(r.stateDir, jobID+stateFileExt)
+}
+
+// RunRawNomadJobs fails for any job, as raw Nomad jobs can only run in Nomad.
+func (r *JobRunner) RunRawNomadJobs(ctx context.Context, rawJobs []string) ([]types.RawJobWithNomadJob, error) {
+	if len(rawJobs) != 0 {
+		return nil, fmt.Errorf("pre generate Nomad jobs can't be run by the native runner, use the Nomad runner instead")
+	}
+
+	return nil, nil
+}
+
+// JobRunning returns true when the job's supervisor runs and the job hasn't failed.
+func (r *JobRunner) JobRunning(jobID string) bool {
+	s, err := LoadJobState(r.statePath(jobID))
+	if err != nil {
+		return false
+	}
+
+	return s.Alive() && (s.Status == JobRunning || s.Status == JobPending)
+}
+
+func (r *JobRunner) runAndWait(ctx context.Context, job Job, probesConf *types.ProbesConfig) error {
+	if probesConf != nil {
+		if err := probes.Probe(ctx, job.ID, *probesConf); err != nil {
+			return err
+		}
+	}
+
+	err := r.startSupervisor(ctx, job)
+	if err == nil {
+		return nil
+	}
+
+	if !errors.Is(err, errJobStart) {
+		return err
+	}
+
+	fmt.Printf("\nLogs from failed %s job:\n", job.ID)
+
+	if err := logscollector.TailLastLogs(job.LogsDir); err != nil {
+		return fmt.Errorf("failed to print logs from failed job: %w", err)
+	}
+
+	return err
+}
+
+var errJobStart = errors.New("job failed to start")
+
+func (r *JobRunner) startSupervisor(ctx context.Context, job Job) error {
+	statePath := r.statePath(job.ID)
+
+	state := JobState{
+		Job:    job,
+		Status: JobPending,
+	}
+	if err := state.Persist(statePath); err != nil {
+		return err
+	}
+
+	// output of the supervisor itself, task logs are written to the logs directory
+	out, err := os.Create(filepath.Join(r.stateDir, job.ID+".log"))
+	if err != nil {
+		return fmt.Errorf("failed to create supervisor log file: %w", err)
+	}
+	defer out.Close()
+
+	cmd := exec.Command(r.capsuleBinary, "supervisor", "run", "--state-file", statePath)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	// new session detaches the supervisor from the terminal, so it outlives the current command
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start supervisor of job %q: %w", job.ID, err)
+	}
+
+	// reap the supervisor if it exits before this process does
+	exited := make(chan struct{})
+	go func() {
+		cmd.Wait() // nolint:errcheck
+		close(exited)
+	}()
+
+	log.Printf("job %q started with supervisor pid %d", job.ID, cmd.Process.Pid)
+
+	return r.waitForHealthy(ctx, job.ID, exited)
+}
+
+// waitForHealthy waits until the job runs for minHealthyTime. The supervisor records its PID in the state
+// once it loads the job, until then exited is used to check whether it is still starting.
+func (r *JobRunner) waitForHealthy(ctx context.Context, jobID string, exited <-chan struct{}) error {
+	ctx, cancel := context.WithTimeout(ctx, startDeadline)
+	defer cancel()
+
+	t := time.NewTicker(pollInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to run %s job: %w: starting deadline has been exceeded", jobID, errJobStart)
+		case <-t.C:
+		}
+
+		s, err := LoadJobState(r.statePath(jobID))
+		if err != nil {
+			return fmt.Errorf("failed to load state of job %q: %w", jobID, err)
+		}
+
+		switch {
+		case s.Status == JobFailed:
+			return fmt.Errorf("failed to run %s job: %w: %s", jobID, errJobStart, s.Error)
+		case s.PID == 0 && s.Status == JobPending:
+			select {
+			case <-exited:
+				return fmt.Errorf("failed to run %s job: %w: supervisor has exited", jobID, errJobStart)
+			default:
+			}
+		case !s.Alive():
+			return fmt.Errorf("failed to run %s job: %w: supervisor has exited", jobID, errJobStart)
+		case s.Status == JobRunning && time.Since(s.RunningSince) >= minHealthyTime:
+			return nil
+		}
+	}
+}
+
+// RunNodeSets returns list of started jobs.
+// When one of the jobs fails during startup it returns jobs that has alredy started before that.
+func (r *JobRunner) RunNodeSets(ctx context.Context, nodeSets []types.NodeSet, stopOnFailure bool) ([]string, error) {
+	for _, ns := range nodeSets {
+		if ns.NomadJobRaw != nil {
+			return nil, fmt.Errorf("node set %q has custom Nomad job definition that can't be run by the native runner", ns.Name)
+		}
+	}
+
+	var mut sync.Mutex
+	startedJobs := make([]string, 0, len(nodeSets))
+
+	eg := new(errgroup.Group)
+	for _, ns := range nodeSets {
+		ns := ns
+
+		eg.Go(func() error {
+			job := r.defaultNodeSetJob(ns)
+
+			if err := r.runAndWait(ctx, job, ns.PreStartProbe); err != nil {
+				if stopOnFailure {
+					if _, err := r.stopJobsByIDs(ctx, []string{job.ID}); err != nil {
+						log.Printf("Failed to stop failed job %s, this job might need to be stopped manually. Reason %s", job.ID, err)
+					}
+				} else {
+					log.Println("The --do-not-stop-on-failure flag present. Nodeset won't be stopped")
+				}
+
+				return err
+			}
+
+			mut.Lock()
+			startedJobs = append(startedJobs, job.ID)
+			mut.Unlock()
+
+			return nil
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		return startedJobs, fmt.Errorf("failed to wait for node sets: %w", err)
+	}
+
+	return startedJobs, nil
+}
+
+func (r *JobRunner) runJob(ctx context.Context, job Job) (string, error) {
+	// Skip for already running jobs
+	if r.JobRunning(job.ID) {
+		return job.ID, nil
+	}
+
+	if err := r.runAndWait(ctx, job, nil); err != nil {
+		return "", fmt.Errorf("failed to run pre start job %q: %w", job.ID, err)
+	}
+
+	return job.ID, nil
+}
+
+func (r *JobRunner) RunDockerJob(ctx context.Context, dc config.DockerConfig) (string, error) {
+	return r.runJob(ctx, r.defaultDockerJob(dc))
+}
+
+func (r *JobRunner) RunExecJob(ctx context.Context, ec config.ExecConfig) (string, error) {
+	return r.runJob(ctx, r.defaultExecJob(ec))
+}
+
+func (r *JobRunner) runJobs(ctx context.Context, jobs []Job) ([]string, error) {
+	g, ctx := errgroup.WithContext(ctx)
+	jobIDs := make([]string, 0, len(jobs))
+
+	var jobIDsLock sync.Mutex
+
+	for _, job := range jobs {
+		// capture in the loop by copy
+		job := job
+		g.Go(func() error {
+			jobID, err := r.runJob(ctx, job)
+			if err != nil {
+				return err
+			}
+
+			jobIDsLock.Lock()
+			jobIDs = append(jobIDs, jobID)
+			jobIDsLock.Unlock()
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return jobIDs, nil
+}
+
+func (r *JobRunner) StartFaucet(
+	ctx context.Context,
+	faucetConfig *config.FaucetConfig,
+	genFaucet *types.Faucet,
+) (string, error) {
+	jobID, err := r.runJob(ctx, r.defaultFaucetJob(faucetConfig, genFaucet))
+	if err != nil {
+		return "", fmt.Errorf("failed to run the faucet job %q: %w", genFaucet.Name, err)
+	}
+
+	return jobID, nil
+}
+
+func (r *JobRunner) StartWallet(
+	ctx context.Context,
+	walletConfig *config.WalletConfig,
+	genWallet *types.Wallet,
+) (string, error) {
+	jobID, err := r.runJob(ctx, r.defaultWalletJob(genWallet))
+	if err != nil {
+		return "", fmt.Errorf("failed to run the wallet job %q: %w", genWallet.Name, err)
+	}
+
+	return jobID, nil
+}
+
+func (r *JobRunner) StartNetwork(
+	ctx context.Context,
+	conf *config.Config,
+	generatedSvcs *types.GeneratedServices,
+	stopAllJobsOnFailure bool,
+) (*types.NetworkJobs, error) {
+	if len(generatedSvcs.PreGenerateJobsIDs()) != 0 {
+		return nil, fmt.Errorf("network with pre generate Nomad jobs can't be run by the native runner")
+	}
+
+	netJobs, err := r.startNetwork(ctx, conf, generatedSvcs, stopAllJobsOnFailure)
+	if err != nil {
+		if stopAllJobsOnFailure {
+			if _, err := r.stopAllJobs(ctx); err != nil {
+				log.Printf("Failed to stop all jobs - please stop processes manually: %s", err)
+			}
+			return nil, err
+		}
+		log.Println("Part of the network could not start, but it has been required to not stop existing jobs on failure, so we continue as normal...")
+	}
+
+	return netJobs, nil
+}
+
+func (r *JobRunner) startNetwork(
+	ctx context.Context,
+	conf *config.Config,
+	generatedSvcs *types.GeneratedServices,
+	stopOnFailure bool,
+) (*types.NetworkJobs, error) {
+	result := &types.NetworkJobs{
+		NodesSetsJobIDs: map[string]bool{},
+		ExtraJobIDs:     map[string]bool{},
+	}
+
+	stages, err := conf.StartupStages(generatedSvcs)
+	if err != nil {
+		return result, fmt.Errorf("failed to resolve startup order: %w", err)
+	}
+
+	// services of a stage start in parallel once all services of the previous stages are healthy and ready
+	for _, stage := range stages {
+		if err := r.startStage(ctx, conf, generatedSvcs, stage, result, stopOnFailure); err != nil {
+			return result, fmt.Errorf("failed to start vega network: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+func (r *JobRunner) startStage(
+	gCtx context.Context,
+	conf *config.Config,
+	generatedSvcs *types.GeneratedServices,
+	stage config.StartupStage,
+	result *types.NetworkJobs,
+	stopOnFailure bool,
+) error {
+	g, ctx := errgroup.WithContext(gCtx)
+	var lock sync.Mutex
+
+	extraJobs := make([]Job, 0, len(stage.Exec)+len(stage.Docker))
+	for _, ec := range stage.Exec {
+		extraJobs = append(extraJobs, r.defaultExecJob(ec))
+	}
+	extraJobs = append(extraJobs, r.dockerJobs(stage.Docker)...)
+
+	if len(extraJobs) != 0 {
+		g.Go(func() error {
+			extraJobIDs, err := r.runJobs(ctx, extraJobs)
+			if err != nil {
+				return fmt.Errorf("failed to run exec and docker jobs: %w", err)
+			}
+
+			lock.Lock()
+			result.AddExtraJobIDs(extraJobIDs)
+			lock.Unlock()
+
+			return nil
+		})
+	}
+
+	if stage.Faucet && generatedSvcs.Faucet != nil {
+		g.Go(func() error {
+			jobID, err := r.StartFaucet(ctx, conf.Network.Faucet, generatedSvcs.Faucet)
+			if err != nil {
+				return err
+			}
+
+			lock.Lock()
+			result.FaucetJobID = jobID
+			lock.Unlock()
+			return nil
+		})
+	}
+
+	if stage.Wallet && generatedSvcs.Wallet != nil {
+		g.Go(func() error {
+			jobID, err := r.StartWallet(ctx, conf.Network.Wallet, generatedSvcs.Wallet)
+			if err != nil {
+				return err
+			}
+
+			lock.Lock()
+			result.WalletJobID = jobID
+			lock.Unlock()
+
+			return nil
+		})
+	}
+
+	if len(stage.NodeSets) != 0 {
+		g.Go(func() error {
+			jobIDs, err := r.RunNodeSets(ctx, stage.NodeSets, stopOnFailure)
+
+			lock.Lock()
+			for _, jobID := range jobIDs {
+				result.NodesSetsJobIDs[jobID] = true
+			}
+			lock.Unlock()
+
+			if err != nil {
+				return fmt.Errorf("failed to run node sets: %w", err)
+			}
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	// services depending on this stage start only when it's ready
+	return probes.WaitReady(gCtx, probes.StageReadinessChecks(conf, generatedSvcs, stage))
+}
+
+func (r *JobRunner) dockerJobs(dockerConfigs []config.DockerConfig) []Job {
+	jobs := make([]Job, 0, len(dockerConfigs))
+	for _, dc := range dockerConfigs {
+		jobs = append(jobs, r.defaultDockerJob(dc))
+	}
+
+	return jobs
+}
+
+// listJobs returns IDs of all jobs with state in the state directory.
+func (r *JobRunner) listJobs() ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(r.stateDir, "*"+stateFileExt))
+	if err != nil {
+		return nil, err
+	}
+
+	jobIDs := make([]string, 0, len(paths))
+	for _, p := range paths {
+		jobIDs = append(jobIDs, strings.TrimSuffix(filepath.Base(p), stateFileExt))
+	}
+
+	return jobIDs, nil
+}
+
+func (r *JobRunner) stopAllJobs(ctx context.Context) ([]string, error) {
+	allJobs, err := r.listJobs()
+	if err != nil {
+		return nil, err
+	}
+
+	allJobIDs := []string{}
+	for _, jobID := range allJobs {
+		if r.isIgnoredJob(jobID) {
+			continue
+		}
+		allJobIDs = append(allJobIDs, jobID)
+	}
+
+	return r.stopJobsByIDs(ctx, allJobIDs)
+}
+
+func (r *JobRunner) StopNetwork(ctx context.Context, jobs *types.NetworkJobs, nodesOnly bool) ([]string, error) {
+	// no jobs, no network started
+	if jobs == nil {
+		if !nodesOnly {
+			return r.stopAllJobs(ctx)
+		}
+
+		return nil, nil
+	}
+
+	allJobIDs := []string{}
+	if !nodesOnly {
+		allJobIDs = append(jobs.ExtraJobIDs.ToSlice(), jobs.WalletJobID, jobs.FaucetJobID)
+	}
+	allJobIDs = append(allJobIDs, jobs.NodesSetsJobIDs.ToSlice()...)
+
+	return r.stopJobsByIDs(ctx, allJobIDs)
+}
+
+func (r *JobRunner) StopJobs(ctx context.Context, jobIDs []string) ([]string, error) {
+	return r.stopJobsByIDs(ctx, jobIDs)
+}
+
+// ListExposedPorts returns static ports of running jobs
+func (r *JobRunner) ListExposedPorts(ctx context.Context) (map[string][]int64, error) {
+	jobIDs, err := r.listJobs()
+	if err != nil {
+		return nil, err
+	}
+
+	portsPerJob := map[string][]int64{}
+	for _, jobID := range jobIDs {
+		s, err := LoadJobState(r.statePath(jobID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load state of job %q: %w", jobID, err)
+		}
+
+		if !s.Alive() || s.Status != JobRunning {
+			continue
+		}
+
+		portsPerJob[jobID] = s.Job.Ports
+	}
+
+	return portsPerJob, nil
+}
+
+// JobStatus returns status of the job from the state persisted by its supervisor.
+func (r *JobRunner) JobStatus(ctx context.Context, jobID string) (*types.JobStatus, error) {
+	s, err := LoadJobState(r.statePath(jobID))
+	if errors.Is(err, os.ErrNotExist) {
+		return &types.JobStatus{ID: jobID, Status: types.JobStatusNotFound}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state of job %q: %w", jobID, err)
+	}
+
+	status := &types.JobStatus{ID: jobID, Status: s.Status}
+
+	// the supervisor could have been killed without updating the state
+	if !s.Alive() && s.Status != JobFailed {
+		status.Status = JobStopped
+	}
+
+	for _, t := range s.Job.Tasks {
+		ts := types.TaskStatus{Name: t.Name, State: taskDead}
+		if st, ok := s.Tasks[t.Name]; ok {
+			ts.Restarts = uint64(st.Restarts)
+			if status.Status == JobRunning && st.PID > 0 && processAlive(st.PID) {
+				ts.State = taskRunning
+			}
+		}
+		status.Tasks = append(status.Tasks, ts)
+	}
+
+	return status, nil
+}
+
+// signals are the signals that can be sent to the tasks.
+var signals = map[string]syscall.Signal{
+	"SIGKILL": syscall.SIGKILL,
+	"SIGTERM": syscall.SIGTERM,
+	"SIGSTOP": syscall.SIGSTOP,
+	"SIGCONT": syscall.SIGCONT,
+}
+
+// runningTask returns state of the running job and its task.
+func (r *JobRunner) runningTask(jobID, taskName string) (*JobState, *TaskState, error) {
+	if r.isIgnoredJob(jobID) {
+		return nil, nil, fmt.Errorf("job %q belongs to other network", jobID)
+	}
+
+	s, err := LoadJobState(r.statePath(jobID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, fmt.Errorf("job %q is not running", jobID)
+		}
+		return nil, nil, err
+	}
+
+	if !s.Alive() || s.Status != JobRunning {
+		return nil, nil, fmt.Errorf("job %q is not running", jobID)
+	}
+
+	ts, ok := s.Tasks[taskName]
+	if !ok || ts.PID <= 0 {
+		return nil, nil, fmt.Errorf("job %q has no task %q", jobID, taskName)
+	}
+
+	return s, ts, nil
+}
+
+// SignalTask sends the signal, e.g. SIGKILL, to the process group of the task of the running job.
+// Killed tasks are restarted by the supervisor according to the restart policy.
+func (r *JobRunner) SignalTask(ctx context.Context, jobID, taskName, signal string) error {
+	sig, ok := signals[signal]
+	if !ok {
+		return fmt.Errorf("signal %q is not supported", signal)
+	}
+
+	_, ts, err := r.runningTask(jobID, taskName)
+	if err != nil {
+		return err
+	}
+
+	// tasks run in their own process groups
+	if err := syscall.Kill(-ts.PID, sig); err != nil {
+		return fmt.Errorf("failed to send %s to task %q of job %q: %w", signal, taskName, jobID, err)
+	}
+
+	return nil
+}
+
+// RestartTask restarts the running job, as the supervisor runs all tasks of the job together
+// the other tasks of the job are restarted too.
+func (r *JobRunner) RestartTask(ctx context.Context, jobID, taskName string) error {
+	s, _, err := r.runningTask(jobID, taskName)
+	if err != nil {
+		return err
+	}
+
+	if err := r.stopJob(ctx, jobID); err != nil {
+		return fmt.Errorf("failed to stop job %q: %w", jobID, err)
+	}
+
+	if err := r.runAndWait(ctx, s.Job, nil); err != nil {
+		return fmt.Errorf("failed to start job %q: %w", jobID, err)
+	}
+
+	return nil
+}
+
+func (r *JobRunner) stopJobsByIDs(ctx context.Context, allJobIDs []string) ([]string, error) {
+	// Apparently, we can have blank job IDs, so skipping them.
+	cleanedUpJobIDs := []string{}
+	for _, jobID := range allJobIDs {
+		if jobID == "" {
+			continue
+		}
+		cleanedUpJobIDs = append(cleanedUpJobIDs, jobID)
+	}
+
+	if len(cleanedUpJobIDs) == 0 {
+		log.Println("No job to be stopped.")
+		return nil, nil
+	}
+
+	log.Printf("Trying to stop jobs: %s\n", strings.Join(cleanedUpJobIDs, ", "))
+
+	g, ctx := errgroup.WithContext(ctx)
+	for _, jobID := range cleanedUpJobIDs {
+		cpyJobID := jobID
+		g.Go(func() error {
+			if err := r.stopJob(ctx, cpyJobID); err != nil {
+				return fmt.Errorf("cannot stop job %q: %w", cpyJobID, err)
+			}
+
+			log.Printf("Job %q stopped\n", cpyJobID)
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("could not stop all jobs: %w", err)
+	}
+
+	log.Println("Jobs have been stopped.")
+
+	return cleanedUpJobIDs, nil
+}
+
+// stopJob asks the supervisor to stop the tasks of the job. When the supervisor doesn't stop in time it is killed.
+// Tasks still running after that, e.g. left behind by crashed supervisor, are killed too.
+func (r *JobRunner) stopJob(ctx context.Context, jobID string) error {
+	statePath := r.statePath(jobID)
+
+	s, err := LoadJobState(statePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	if s.Alive() {
+		if err := syscall.Kill(s.PID, syscall.SIGTERM); err != nil {
+			return fmt.Errorf("failed to terminate supervisor: %w", err)
+		}
+
+		if !waitForExit(ctx, s.PID, s.Job.KillTimeout+time.Second*5) {
+			log.Printf("supervisor of job %q did not stop in time, killing it", jobID)
+
+			if err := syscall.Kill(s.PID, syscall.SIGKILL); err != nil {
+				log.Printf("failed to kill supervisor of job %q: %s", jobID, err)
+			}
+		}
+
+		if updated, err := LoadJobState(statePath); err == nil {
+			s = updated
+		}
+	}
+
+	for name, ts := range s.Tasks {
+		if !ts.Alive() {
+			continue
+		}
+
+		log.Printf("task %q of job %q is still running, killing it", name, jobID)
+
+		// tasks run in their own process groups
+		if err := syscall.Kill(-ts.PID, syscall.SIGKILL); err != nil {
+			log.Printf("failed to kill task %q of job %q: %s", name, jobID, err)
+		}
+	}
+
+	if err := os.Remove(statePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove job state: %w", err)
+	}
+
+	return nil
+}
+
+func waitForExit(ctx context.Context, pid int, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	t := time.NewTicker(pollInterval)
+	defer t.Stop()
+
+	for processAlive(pid) {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-t.C:
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,158 @@
+package supervisor
+
+import (
+	"fmt"
+	"path"
+
+	"code.vegaprotocol.io/vegacapsule/config"
+	"code.vegaprotocol.io/vegacapsule/types"
+)
+
+// Jobs are the same as the default Nomad jobs in nomad/job_runner_defaults.go,
+// only the log collector task is not needed as the tasks write logs directly to the logs directory.
+
+func (r *JobRunner) newJob(id string, tasks ...Task) Job {
+	return Job{
+		ID:          id,
+		Tasks:       tasks,
+		Restart:     r.restart,
+		KillTimeout: r.killTimeout,
+		LogsDir:     path.Join(r.logsOutputDir, id),
+	}
+}
+
+// NodeSetTasks returns tasks running the node set, they are the same as tasks of the default Nomad node set job.
+func NodeSetTasks(ns types.NodeSet) []Task {
+	if ns.Visor != nil {
+		return []Task{
+			{
+				Name:    ns.Visor.Name,
+				Command: ns.Visor.BinaryPath,
+				Args: []string{
+					"run",
+					"--home", ns.Visor.HomeDir,
+				},
+			},
+		}
+	}
+
+	tasks := []Task{
+		{
+			Name:    ns.Vega.Name,
+			Command: ns.Vega.BinaryPath,
+			Args: []string{
+				"node",
+				"--home", ns.Vega.HomeDir,
+				"--tendermint-home", ns.Tendermint.HomeDir,
+				"--nodewallet-passphrase-file", ns.Vega.NodeWalletPassFilePath,
+			},
+		},
+	}
+
+	if ns.DataNode != nil {
+		tasks = append(tasks, Task{
+			Name:    ns.DataNode.Name,
+			Command: ns.DataNode.BinaryPath,
+			Args: []string{
+				config.DataNodeSubCmd,
+				"node",
+				"--home", ns.DataNode.HomeDir,
+			},
+		})
+	}
+
+	return tasks
+}
+
+func (r *JobRunner) defaultNodeSetJob(ns types.NodeSet) Job {
+	return r.newJob(ns.Name, NodeSetTasks(ns)...)
+}
+
+// WalletTask returns task running the wallet service.
+func WalletTask(wallet *types.Wallet) Task {
+	args := []string{
+		config.WalletSubCmd,
+		"service",
+		"run",
+		"--network", wallet.Network,
+		"--automatic-consent",
+		"--no-version-check",
+		"--output", "json",
+		"--home", wallet.HomeDir,
+	}
+
+	if len(wallet.TokenPassphrasePath) > 0 {
+		args = append(args, "--load-tokens", "--tokens-passphrase-file", wallet.TokenPassphrasePath)
+	}
+
+	return Task{
+		Name:    "wallet-1",
+		Command: wallet.BinaryPath,
+		Args:    args,
+	}
+}
+
+func (r *JobRunner) defaultWalletJob(wallet *types.Wallet) Job {
+	return r.newJob(wallet.Name, WalletTask(wallet))
+}
+
+// FaucetTask returns task running the faucet service.
+func FaucetTask(conf *config.FaucetConfig, fc *types.Faucet) Task {
+	return Task{
+		Name:    conf.Name,
+		Command: fc.BinaryPath,
+		Args: []string{
+			config.FaucetSubCmd,
+			"run",
+			"--passphrase-file", fc.WalletPassFilePath,
+			"--home", fc.HomeDir,
+		},
+	}
+}
+
+func (r *JobRunner) defaultFaucetJob(conf *config.FaucetConfig, fc *types.Faucet) Job {
+	return r.newJob(fc.Name, FaucetTask(conf, fc))
+}
+
+// defaultDockerJob runs the container with the Docker CLI, signals sent to the CLI are passed to the container.
+func (r *JobRunner) defaultDockerJob(conf config.DockerConfig) Job {
+	args := []string{"run", "--rm", "--name", conf.Name}
+
+	var ports []int64
+	if conf.StaticPort != nil {
+		args = append(args, "-p", fmt.Sprintf("%d:%d", conf.StaticPort.Value, conf.StaticPort.To))
+		ports = append(ports, int64(conf.StaticPort.Value))
+	}
+
+	for _, env := range envList(conf.Env) {
+		args = append(args, "-e", env)
+	}
+
+	for _, volume := range conf.VolumeMounts {
+		args = append(args, "-v", volume)
+	}
+
+	args = append(args, conf.Image)
+	if conf.Command != "" {
+		args = append(args, conf.Command)
+	}
+	args = append(args, conf.Args...)
+
+	job := r.newJob(conf.Name, Task{
+		Name:    conf.Name,
+		Command: "docker",
+		Args:    args,
+	})
+	job.Ports = ports
+
+	return job
+}
+
+func (r *JobRunner) defaultExecJob(conf config.ExecConfig) Job {
+	return r.newJob(conf.Name, Task{
+		Name:    conf.Name,
+		Command: conf.Command,
+		Args:    conf.Args,
+		Env:     conf.Env,
+	})
+}
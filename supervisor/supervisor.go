@@ -0,0 +1,253 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// logsTimeFormat is the same format as used for the logs collected from Nomad jobs,
+// so the logs can be read by the `logs` command.
+const logsTimeFormat = time.RFC3339
+
+var errTaskExited = errors.New("task exited")
+
+// Supervisor runs tasks of a single job as child processes and restarts them according to the job restart policy.
+type Supervisor struct {
+	statePath string
+
+	mu    sync.Mutex
+	state JobState
+}
+
+// New loads the job from the state file. The state file is updated by the supervisor while the job runs.
+func New(statePath string) (*Supervisor, error) {
+	s, err := LoadJobState(statePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load job state: %w", err)
+	}
+
+	s.PID = os.Getpid()
+	s.Status = JobPending
+	s.Error = ""
+	s.Tasks = map[string]*TaskState{}
+
+	return &Supervisor{
+		statePath: statePath,
+		state:     *s,
+	}, nil
+}
+
+// Run starts all tasks of the job and supervises them until the context is cancelled or one of the tasks fails.
+// All tasks are stopped when Run returns.
+func (s *Supervisor) Run(ctx context.Context) error {
+	job := s.state.Job
+
+	if err := os.MkdirAll(job.LogsDir, os.ModePerm); err != nil {
+		return s.fail(fmt.Errorf("failed to create logs directory %q: %w", job.LogsDir, err))
+	}
+
+	procs := make([]*process, 0, len(job.Tasks))
+	for _, t := range job.Tasks {
+		p, err := s.startTask(t)
+		if err != nil {
+			for _, started := range procs {
+				started.terminate(job.KillTimeout)
+			}
+			return s.fail(fmt.Errorf("failed to start task %q: %w", t.Name, err))
+		}
+
+		procs = append(procs, p)
+	}
+
+	s.update(func(st *JobState) {
+		st.Status = JobRunning
+		st.RunningSince = time.Now()
+	})
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	for i, t := range job.Tasks {
+		t, p := t, procs[i]
+
+		eg.Go(func() error {
+			return s.superviseTask(egCtx, t, p)
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		return s.fail(err)
+	}
+
+	s.update(func(st *JobState) {
+		st.Status = JobStopped
+	})
+
+	return nil
+}
+
+func (s *Supervisor) superviseTask(ctx context.Context, t Task, p *process) error {
+	job := s.state.Job
+
+	for restarts := 0; ; restarts++ {
+		select {
+		case <-ctx.Done():
+			p.terminate(job.KillTimeout)
+			return nil
+		case <-p.done:
+		}
+
+		exitErr := p.err
+		if exitErr == nil {
+			exitErr = errTaskExited
+		}
+
+		if restarts >= job.Restart.Attempts {
+			return fmt.Errorf("task %q has failed: %w", t.Name, exitErr)
+		}
+
+		log.Printf("task %q has failed, restarting in %s (attempt %d/%d): %s",
+			t.Name, job.Restart.Delay, restarts+1, job.Restart.Attempts, exitErr)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(job.Restart.Delay):
+		}
+
+		var err error
+		p, err = s.startTask(t)
+		if err != nil {
+			return fmt.Errorf("failed to restart task %q: %w", t.Name, err)
+		}
+
+		s.update(func(st *JobState) {
+			st.Tasks[t.Name].Restarts = restarts + 1
+		})
+	}
+}
+
+func (s *Supervisor) startTask(t Task) (*process, error) {
+	logsDir := s.state.Job.LogsDir
+	now := time.Now().Format(logsTimeFormat)
+
+	stdout, err := os.OpenFile(filepath.Join(logsDir, fmt.Sprintf("%s.stdout-%s.log", t.Name, now)), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdout log file: %w", err)
+	}
+
+	stderr, err := os.OpenFile(filepath.Join(logsDir, fmt.Sprintf("%s.stderr-%s.log", t.Name, now)), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		stdout.Close()
+		return nil, fmt.Errorf("failed to create stderr log file: %w", err)
+	}
+
+	cmd := exec.Command(t.Command, t.Args...)
+	cmd.Env = append(os.Environ(), envList(t.Env)...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	// tasks get their own process group, so processes spawned by them are stopped as well
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	if err := cmd.Start(); err != nil {
+		stdout.Close()
+		stderr.Close()
+		return nil, err
+	}
+
+	p := &process{
+		cmd:  cmd,
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		p.err = cmd.Wait()
+		stdout.Close()
+		stderr.Close()
+		close(p.done)
+	}()
+
+	s.update(func(st *JobState) {
+		ts, ok := st.Tasks[t.Name]
+		if !ok {
+			ts = &TaskState{}
+			st.Tasks[t.Name] = ts
+		}
+
+		ts.PID = cmd.Process.Pid
+		ts.StartedAt = time.Now()
+	})
+
+	log.Printf("task %q started with pid %d", t.Name, cmd.Process.Pid)
+
+	return p, nil
+}
+
+func (s *Supervisor) fail(err error) error {
+	s.update(func(st *JobState) {
+		st.Status = JobFailed
+		st.Error = err.Error()
+	})
+
+	return err
+}
+
+func (s *Supervisor) update(f func(st *JobState)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f(&s.state)
+
+	if err := s.state.Persist(s.statePath); err != nil {
+		log.Printf("failed to persist job state: %s", err)
+	}
+}
+
+type process struct {
+	cmd  *exec.Cmd
+	done chan struct{}
+	// err is set before done is closed
+	err error
+}
+
+// terminate asks the process group to stop and kills it when it doesn't stop within the timeout.
+func (p *process) terminate(timeout time.Duration) {
+	pgid := -p.cmd.Process.Pid
+
+	if err := syscall.Kill(pgid, syscall.SIGTERM); err != nil {
+		log.Printf("failed to terminate process %d: %s", p.cmd.Process.Pid, err)
+	}
+
+	select {
+	case <-p.done:
+		return
+	case <-time.After(timeout):
+	}
+
+	log.Printf("process %d did not stop within %s, killing it", p.cmd.Process.Pid, timeout)
+
+	if err := syscall.Kill(pgid, syscall.SIGKILL); err != nil {
+		log.Printf("failed to kill process %d: %s", p.cmd.Process.Pid, err)
+	}
+
+	<-p.done
+}
+
+func envList(env map[string]string) []string {
+	out := make([]string, 0, len(env))
+	for k, v := range env {
+		out = append(out, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(out)
+
+	return out
+}
@@ -0,0 +1,113 @@
+package supervisor_test
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"code.vegaprotocol.io/vegacapsule/supervisor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSupervisor(t *testing.T, job supervisor.Job) (*supervisor.Supervisor, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	job.LogsDir = filepath.Join(dir, "logs", job.ID)
+	statePath := filepath.Join(dir, job.ID+".json")
+
+	require.NoError(t, supervisor.JobState{Job: job}.Persist(statePath))
+
+	s, err := supervisor.New(statePath)
+	require.NoError(t, err)
+
+	return s, statePath
+}
+
+func TestSupervisorRestartsFailedTask(t *testing.T) {
+	s, statePath := newSupervisor(t, supervisor.Job{
+		ID: "failing",
+		Tasks: []supervisor.Task{
+			{Name: "task", Command: "sh", Args: []string{"-c", "echo $GREETING; exit 1"}, Env: map[string]string{"GREETING": "hello"}},
+		},
+		Restart:     supervisor.RestartPolicy{Attempts: 2, Delay: time.Millisecond * 10},
+		KillTimeout: time.Second,
+	})
+
+	err := s.Run(context.Background())
+	require.Error(t, err)
+
+	state, err := supervisor.LoadJobState(statePath)
+	require.NoError(t, err)
+	assert.Equal(t, supervisor.JobFailed, state.Status)
+	assert.Equal(t, 2, state.Tasks["task"].Restarts)
+
+	logs, err := filepath.Glob(filepath.Join(state.Job.LogsDir, "task.stdout-*.log"))
+	require.NoError(t, err)
+	require.NotEmpty(t, logs)
+
+	out := ""
+	for _, l := range logs {
+		b, err := os.ReadFile(l)
+		require.NoError(t, err)
+		out += string(b)
+	}
+	assert.Equal(t, 3, strings.Count(out, "hello"))
+}
+
+func TestSupervisorStopsTasks(t *testing.T) {
+	s, statePath := newSupervisor(t, supervisor.Job{
+		ID: "running",
+		Tasks: []supervisor.Task{
+			{Name: "first", Command: "sleep", Args: []string{"30"}},
+			{Name: "second", Command: "sleep", Args: []string{"30"}},
+		},
+		KillTimeout: time.Second,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- s.Run(ctx)
+	}()
+
+	require.Eventually(t, func() bool {
+		state, err := supervisor.LoadJobState(statePath)
+		return err == nil && state.Status == supervisor.JobRunning
+	}, time.Second*5, time.Millisecond*50)
+
+	cancel()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second * 5):
+		t.Fatal("tasks have not been stopped")
+	}
+
+	state, err := supervisor.LoadJobState(statePath)
+	require.NoError(t, err)
+	assert.Equal(t, supervisor.JobStopped, state.Status)
+	assert.Len(t, state.Tasks, 2)
+}
+
+func TestTaskStateAlive(t *testing.T) {
+	cmd := exec.Command("sleep", "30")
+	require.NoError(t, cmd.Start())
+	startedAt := time.Now()
+
+	assert.True(t, supervisor.TaskState{PID: cmd.Process.Pid, StartedAt: startedAt}.Alive())
+	// process created after the task has been started only reuses its PID
+	assert.False(t, supervisor.TaskState{PID: cmd.Process.Pid, StartedAt: startedAt.Add(-time.Hour)}.Alive())
+
+	require.NoError(t, cmd.Process.Kill())
+	_ = cmd.Wait()
+
+	assert.False(t, supervisor.TaskState{PID: cmd.Process.Pid, StartedAt: startedAt}.Alive())
+}
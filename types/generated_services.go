@@ -1,6 +1,7 @@
 package types
 
 import (
+	"errors"
 	"fmt"
 	"log"
 
@@ -10,6 +11,8 @@ import (
 
 var generatedServicesCtyType cty.Type
 
+var ErrNodeSetNotFound = errors.New("node set not found")
+
 type GeneratedServices struct {
 	Wallet          *Wallet
 	Faucet          *Faucet
@@ -82,7 +85,7 @@ func (gs GeneratedServices) GetByName(name string) []GeneratedService {
 func (gs GeneratedServices) GetNodeSet(name string) (*NodeSet, error) {
 	ns, ok := gs.NodeSets[name]
 	if !ok {
-		return nil, fmt.Errorf("node set with name %q not found", name)
+		return nil, fmt.Errorf("failed to get node set with name %q: %w", name, ErrNodeSetNotFound)
 	}
 	return &ns, nil
 }
@@ -0,0 +1,23 @@
+package types
+
+const (
+	JobStatusRunning  = "running"
+	JobStatusPending  = "pending"
+	JobStatusFailed   = "failed"
+	JobStatusStopped  = "stopped"
+	JobStatusNotFound = "not found"
+)
+
+// JobStatus is a state of a network job reported by the job runner.
+type JobStatus struct {
+	ID     string       `json:"id"`
+	Status string       `json:"status"`
+	Tasks  []TaskStatus `json:"tasks,omitempty"`
+}
+
+// TaskStatus is a state of a single task of a job.
+type TaskStatus struct {
+	Name     string `json:"name"`
+	State    string `json:"state"`
+	Restarts uint64 `json:"restarts"`
+}
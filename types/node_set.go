@@ -34,6 +34,8 @@ type NodeSet struct {
 	PreGenerateJobs []NomadJob
 	// description: Pre start probes.
 	PreStartProbe *ProbesConfig `hcl:"pre_start_probe,optional"  template:""`
+	// description: Readiness probes.
+	ReadinessProbe *ProbesConfig `json:",omitempty"`
 	// description: Stores custom Nomad job definition of this node set.
 	NomadJobRaw *string `json:",omitempty"`
 }
@@ -1,7 +1,13 @@
 package types
 
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
 /*
-description: Allows the user to define pre start probes on external services.
+description: Allows the user to define probes on services, they are used by pre start and readiness probes.
 example:
 
 	type: hcl
@@ -46,6 +52,116 @@ type ProbesConfig struct {
 					}
 	*/
 	Postgres *PostgresProbe `hcl:"postgres,block" template:""`
+
+	/*
+		description: Allows the user to probe that block height reported by Vega node REST API advances.
+		example:
+
+			type: hcl
+			value: |
+					block_height {
+						...
+					}
+	*/
+	BlockHeight *BlockHeightProbe `hcl:"block_height,block" template:""`
+
+	/*
+		description: Allows the user to probe gRPC server with the standard gRPC health check.
+		example:
+
+			type: hcl
+			value: |
+					grpc {
+						...
+					}
+	*/
+	GRPC *GRPCProbe `hcl:"grpc,block" template:""`
+
+	/*
+		description: Allows the user to probe by running a command.
+		example:
+
+			type: hcl
+			value: |
+					exec {
+						...
+					}
+	*/
+	Exec *ExecProbe `hcl:"exec,block" template:""`
+
+	/*
+		description: Allows the user to probe Ethereum JSON-RPC endpoint.
+		example:
+
+			type: hcl
+			value: |
+					ethereum_rpc {
+						...
+					}
+	*/
+	EthereumRPC *EthereumRPCProbe `hcl:"ethereum_rpc,block" template:""`
+
+	/*
+		description: Maximum time for all probes to pass.
+		default: 40s
+		example:
+
+			type: hcl
+			value: |
+					timeout = "2m"
+	*/
+	Timeout *string `hcl:"timeout,optional"`
+
+	/*
+		description: Time between two calls of a probe.
+		default: 2s
+		example:
+
+			type: hcl
+			value: |
+					interval = "5s"
+	*/
+	Interval *string `hcl:"interval,optional"`
+
+	/*
+		description: Number of consecutive successful calls for a probe to pass.
+		default: 1
+		example:
+
+			type: hcl
+			value: |
+					success_threshold = 3
+	*/
+	SuccessThreshold *int `hcl:"success_threshold,optional"`
+}
+
+// Validate checks that the probe settings can be parsed.
+func (p ProbesConfig) Validate() error {
+	for name, d := range map[string]*string{"timeout": p.Timeout, "interval": p.Interval} {
+		if d == nil {
+			continue
+		}
+
+		v, err := time.ParseDuration(*d)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		if v <= 0 {
+			return fmt.Errorf("%s must be positive", name)
+		}
+	}
+
+	if p.SuccessThreshold != nil && *p.SuccessThreshold < 1 {
+		return fmt.Errorf("success_threshold must be at least 1")
+	}
+
+	if p.Exec != nil && p.Exec.StdoutRegex != nil {
+		if _, err := regexp.Compile(*p.Exec.StdoutRegex); err != nil {
+			return fmt.Errorf("failed to parse exec stdout_regex: %w", err)
+		}
+	}
+
+	return nil
 }
 
 /*
@@ -95,3 +211,114 @@ type PostgresProbe struct {
 	// description: Test query.
 	Query string `hcl:"query" template:""`
 }
+
+/*
+description: |
+
+	Allows the user to probe Vega node REST API. The probe passes when block height reported
+	by the `/statistics` endpoint is higher than the one seen by the previous call.
+
+example:
+
+	type: hcl
+	value: |
+			block_height {
+				url = "http://localhost:3003"
+			}
+*/
+type BlockHeightProbe struct {
+	// description: URL of Vega node REST API.
+	URL string `hcl:"url" template:""`
+}
+
+/*
+description: |
+
+	Allows the user to probe gRPC server using the [gRPC health checking protocol](https://github.com/grpc/grpc/blob/master/doc/health-checking.md).
+	The probe passes when the server reports serving status. Servers that do not implement
+	the health service are considered serving as long as they respond.
+
+example:
+
+	type: hcl
+	value: |
+			grpc {
+				address = "localhost:3002"
+			}
+*/
+type GRPCProbe struct {
+	// description: Address of the gRPC server.
+	Address string `hcl:"address" template:""`
+	/*
+		description: Name of the service to check, the overall health of the server is checked if empty.
+		example:
+
+			type: hcl
+			value: |
+					service = "vega.api.v1.CoreService"
+	*/
+	Service *string `hcl:"service,optional" template:""`
+}
+
+/*
+description: |
+
+	Allows the user to probe by running a command. The probe passes when the command
+	exits with 0 exit code and its output matches `stdout_regex` if set.
+
+example:
+
+	type: hcl
+	value: |
+			exec {
+				cmd = "vega"
+				args = ["wallet", "version"]
+				stdout_regex = "v0\\.7[0-9]\\."
+			}
+*/
+type ExecProbe struct {
+	// description: Command to run.
+	Cmd string `hcl:"cmd" template:""`
+	// description: Arguments of the command.
+	Args []string `hcl:"args,optional" template:""`
+	/*
+		description: Regular expression the standard output of the command has to match.
+		example:
+
+			type: hcl
+			value: |
+					stdout_regex = "ready"
+	*/
+	StdoutRegex *string `hcl:"stdout_regex,optional" template:""`
+}
+
+/*
+description: |
+
+	Allows the user to probe Ethereum JSON-RPC endpoint. The probe passes when the endpoint reports
+	the expected chain ID and the contract at `contract_address` is deployed if set.
+
+example:
+
+	type: hcl
+	value: |
+			ethereum_rpc {
+				url = "ws://127.0.0.1:8545"
+				chain_id = 1440
+			}
+*/
+type EthereumRPCProbe struct {
+	// description: URL of the Ethereum JSON-RPC endpoint.
+	URL string `hcl:"url" template:""`
+	// description: Expected chain ID.
+	ChainID int `hcl:"chain_id"`
+	/*
+		description: Address of a contract that has to be deployed.
+		example:
+
+			type: hcl
+			value: |
+					contract_address = "0xaE15d2c3a2b3b3c1aBb79f6A2F0F5F5f6a7A4B22"
+	*/
+	ContractAddress *string `hcl:"contract_address,optional" template:""`
+}
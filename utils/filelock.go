@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+const fileLockRetryInterval = time.Millisecond * 100
+
+// ErrFileLocked is returned when the file stays locked by another process until the timeout.
+var ErrFileLocked = errors.New("file is locked by another process")
+
+// FileLock is an advisory exclusive lock of a file shared by all processes.
+type FileLock struct {
+	file *os.File
+}
+
+// LockFile acquires exclusive lock of the file, the file and its directory are created when missing.
+// Acquiring is retried until the timeout, ErrFileLocked is returned then.
+func LockFile(path string, timeout time.Duration) (*FileLock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create directory for lock file %q: %w", path, err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file %q: %w", path, err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			return &FileLock{file: f}, nil
+		}
+
+		if !errors.Is(err, syscall.EWOULDBLOCK) {
+			f.Close()
+			return nil, fmt.Errorf("failed to lock file %q: %w", path, err)
+		}
+
+		if time.Now().After(deadline) {
+			f.Close()
+			return nil, ErrFileLocked
+		}
+
+		time.Sleep(fileLockRetryInterval)
+	}
+}
+
+// Unlock releases the lock.
+func (l *FileLock) Unlock() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+
+	defer l.file.Close()
+
+	if err := syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN); err != nil {
+		return fmt.Errorf("failed to unlock file %q: %w", l.file.Name(), err)
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 func CapsuleHome() (string, error) {
@@ -141,3 +142,67 @@ func CpAndChmodxFile(source, destination string) error {
 
 	return nil
 }
+
+// WriteFileAtomic writes data to a temporary file in the same directory and renames it to the
+// given path, so readers never observe partially written file even when the process crashes mid-write.
+func WriteFileAtomic(p string, data []byte, perm os.FileMode) error {
+	tmpFile, err := os.CreateTemp(filepath.Dir(p), fmt.Sprintf(".%s-*.tmp", filepath.Base(p)))
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file for %q: %w", p, err)
+	}
+
+	// no-op when the file has been successfully renamed
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("failed to write temporary file %q: %w", tmpFile.Name(), err)
+	}
+
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("failed to sync temporary file %q: %w", tmpFile.Name(), err)
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file %q: %w", tmpFile.Name(), err)
+	}
+
+	if err := os.Chmod(tmpFile.Name(), perm); err != nil {
+		return fmt.Errorf("failed to chmod temporary file %q: %w", tmpFile.Name(), err)
+	}
+
+	if err := os.Rename(tmpFile.Name(), p); err != nil {
+		return fmt.Errorf("failed to move temporary file to %q: %w", p, err)
+	}
+
+	return nil
+}
+
+// ReplacePathPrefix replaces oldPath in s only where it is a whole path or prefix of a path,
+// i.e. it is not followed by another character of a file name. So e.g. "/tmp/net2" and "/tmp/network"
+// are not rewritten when "/tmp/net" is replaced.
+func ReplacePathPrefix(s, oldPath, newPath string) string {
+	var b strings.Builder
+
+	for {
+		i := strings.Index(s, oldPath)
+		if i < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+
+		end := i + len(oldPath)
+		b.WriteString(s[:i])
+		if end == len(s) || !isFileNameChar(s[end]) {
+			b.WriteString(newPath)
+		} else {
+			b.WriteString(oldPath)
+		}
+		s = s[end:]
+	}
+}
+
+func isFileNameChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '.' || c == '_' || c == '-'
+}